@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal"
+)
+
+const apiKeyCommand = "apikey"
+
+func runAPIKey(args []string) error {
+	var (
+		opts         internal.APIKeyOptions
+		subscriberID string
+		keyID        string
+	)
+
+	fs := flag.NewFlagSet(apiKeyCommand, flag.ContinueOnError)
+	fs.StringVar(&subscriberID, "subscriber", "", "subscriber identifier of the created key")
+	fs.StringVar(&opts.Scopes, "scopes", "feed:read subscriptions:manage", "scopes of the created key separated by spaces")
+	fs.StringVar(&keyID, "id", "", "identifier of the revoked key")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] create|revoke\n", apiKeyCommand)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("api key command is required")
+	}
+
+	opts.Command = fs.Arg(0)
+
+	var err error
+	switch opts.Command {
+	case internal.APIKeyCreate:
+		if opts.SubscriberID, err = uuid.Parse(subscriberID); err != nil {
+			return fmt.Errorf("subscriber: %w", err)
+		}
+	case internal.APIKeyRevoke:
+		if opts.KeyID, err = uuid.Parse(keyID); err != nil {
+			return fmt.Errorf("id: %w", err)
+		}
+	}
+
+	return internal.APIKey(context.Background(), cfg, opts, os.Stdout)
+}
@@ -0,0 +1,95 @@
+package e2e
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
+)
+
+func TestE2ENatsBroadcaster(t *testing.T) {
+	ns := startNats(t)
+	subject := "test.broadcaster"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sender := pubsub.NewNatsBroadcaster(connectNats(t, ns.ClientURL()), subject, pubsub.NewPubSub[string](10))
+	receiver := pubsub.NewNatsBroadcaster(connectNats(t, ns.ClientURL()), subject, pubsub.NewPubSub[string](10))
+	go func() { _ = receiver.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return ns.GlobalAccount().SubscriptionInterest(subject)
+	}, startTimeout, WaitTick, "broadcaster is not subscribed")
+
+	dao := receiver.Subscribe("dao")
+	all := receiver.Subscribe()
+
+	sender.PublishNoWait("other")
+	sender.PublishNoWait("dao")
+	require.Equal(t, "dao", receive(t, dao))
+	require.Equal(t, "other", receive(t, all))
+	require.Equal(t, "dao", receive(t, all))
+
+	// the empty message is delivered to watchers of all topics
+	sender.PublishNoWait("")
+	require.Equal(t, "", receive(t, all))
+}
+
+func TestE2ENatsBroadcasterReconnect(t *testing.T) {
+	ns := startNats(t)
+	port := ns.Addr().(*net.TCPAddr).Port
+	subject := "test.broadcaster"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nc, err := nats.Connect(ns.ClientURL(), nats.MaxReconnects(-1), nats.ReconnectWait(WaitTick))
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	receiver := pubsub.NewNatsBroadcaster(nc, subject, pubsub.NewPubSub[string](10))
+	require.NoError(t, receiver.Listen())
+	go func() { _ = receiver.Start(ctx) }()
+
+	dao := receiver.Subscribe("dao")
+
+	// messages published while the replica is disconnected are lost, so watchers of all topics are woken up
+	ns.Shutdown()
+	ns.WaitForShutdown()
+	restarted, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: port, NoLog: true, NoSigs: true})
+	require.NoError(t, err)
+	go restarted.Start()
+	t.Cleanup(restarted.Shutdown)
+
+	require.Equal(t, "", receive(t, dao))
+}
+
+func connectNats(t *testing.T, url string) *nats.Conn {
+	t.Helper()
+
+	nc, err := nats.Connect(url)
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	return nc
+}
+
+func receive(t *testing.T, ch chan string) string {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(WaitTimeout):
+		t.Fatal("message is not received")
+
+		return ""
+	}
+}
@@ -0,0 +1,156 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+func publishProposal(t *testing.T, h *Harness, daoID uuid.UUID, id string) {
+	t.Helper()
+
+	now := time.Now()
+
+	h.Publish(t, pevents.SubjectDaoCreated, pevents.DaoPayload{
+		ID:        daoID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Name:      "dao",
+	})
+
+	h.Publish(t, pevents.SubjectProposalCreated, pevents.ProposalPayload{
+		ID:      id,
+		DaoID:   daoID,
+		Created: int(now.Unix()),
+		Author:  "0xauthor",
+		Title:   "proposal",
+		State:   "active",
+		Start:   int(now.Add(time.Hour).Unix()),
+		End:     int(now.Add(24 * time.Hour).Unix()),
+	})
+}
+
+func TestE2EGetByFilter(t *testing.T) {
+	h := Start(t)
+	daoID := uuid.New()
+
+	publishProposal(t, h, daoID, "proposal")
+
+	require.Eventually(t, func() bool {
+		resp, err := h.Feed.GetByFilter(h.AsAdmin(context.Background()), &feedpb.FeedByFilterRequest{
+			DaoIds: []string{daoID.String()},
+		})
+		require.NoError(t, err)
+
+		return len(resp.GetItems()) == 1 && resp.GetItems()[0].GetProposalId() == "proposal"
+	}, WaitTimeout, WaitTick)
+
+	active := true
+	resp, err := h.Feed.GetByFilter(h.AsAdmin(context.Background()), &feedpb.FeedByFilterRequest{
+		DaoIds:   []string{daoID.String()},
+		IsActive: &active,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, resp.GetTotalCount())
+}
+
+func TestE2ESubscriberCallbacks(t *testing.T) {
+	h := Start(t)
+	daoID := uuid.New()
+	callbacks := h.Callbacks(t)
+
+	_, ctx := h.CreateSubscriber(t, "https://example.com/hook")
+	_, err := h.Subscriptions.Subscribe(ctx, &feedpb.SubscribeRequest{DaoId: daoID.String()})
+	require.NoError(t, err)
+
+	publishProposal(t, h, daoID, "proposal")
+
+	for {
+		select {
+		case callback := <-callbacks:
+			require.Equal(t, "https://example.com/hook", callback.WebhookURL)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(callback.Body, &body))
+			if body["proposal_id"] == "proposal" {
+				return
+			}
+		case <-time.After(WaitTimeout):
+			t.Fatal("proposal callback is not received")
+		}
+	}
+}
+
+func TestE2EEventsSubscribe(t *testing.T) {
+	h := Start(t)
+	daoID := uuid.New()
+
+	subscriberID, ctx := h.CreateSubscriber(t, "https://example.com/hook")
+	_, err := h.Subscriptions.Subscribe(ctx, &feedpb.SubscribeRequest{DaoId: daoID.String()})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(ctx, WaitTimeout)
+	defer cancel()
+
+	stream, err := h.Events.EventsSubscribe(ctx, &feedpb.EventsSubscribeRequest{
+		SubscriberId:      subscriberID,
+		SubscriptionTypes: []feedpb.FeedItemType{feedpb.FeedItemType_FEED_ITEM_TYPE_PROPOSAL},
+	})
+	require.NoError(t, err)
+
+	publishProposal(t, h, daoID, "proposal")
+
+	item, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "proposal", item.GetProposal().GetId())
+	require.Equal(t, daoID.String(), item.GetProposal().GetDaoInternalId())
+}
+
+func TestE2EWebhookEndpointsRouting(t *testing.T) {
+	h := Start(t)
+	daoID := uuid.New()
+	callbacks := h.Callbacks(t)
+
+	_, ctx := h.CreateSubscriber(t, "")
+	for _, req := range []*feedpb.CreateWebhookEndpointRequest{
+		{Url: "https://example.com/proposals", Enabled: true, Format: "compact", Types: []string{"proposal"}},
+		{Url: "https://example.com/daos", Enabled: true, Types: []string{"dao"}},
+	} {
+		_, err := h.Subscribers.CreateWebhookEndpoint(ctx, req)
+		require.NoError(t, err)
+	}
+
+	resp, err := h.Subscribers.ListWebhookEndpoints(ctx, &feedpb.ListWebhookEndpointsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetEndpoints(), 2)
+
+	_, err = h.Subscriptions.Subscribe(ctx, &feedpb.SubscribeRequest{DaoId: daoID.String()})
+	require.NoError(t, err)
+
+	publishProposal(t, h, daoID, "proposal")
+
+	for {
+		select {
+		case callback := <-callbacks:
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(callback.Body, &body))
+			if body["proposal_id"] != "proposal" {
+				continue
+			}
+
+			require.Equal(t, "https://example.com/proposals", callback.WebhookURL)
+			require.Nil(t, body["snapshot"])
+
+			return
+		case <-time.After(WaitTimeout):
+			t.Fatal("proposal callback is not received")
+		}
+	}
+}
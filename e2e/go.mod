@@ -0,0 +1,23 @@
+module github.com/goverland-labs/goverland-core-feed/e2e
+
+go 1.22
+
+toolchain go1.23.2
+
+replace (
+	github.com/goverland-labs/goverland-core-feed => ../
+	github.com/goverland-labs/goverland-core-feed/protocol => ../protocol
+)
+
+require (
+	github.com/caarlos0/env/v6 v6.10.1
+	github.com/google/uuid v1.6.0
+	github.com/goverland-labs/goverland-core-feed v0.0.0
+	github.com/goverland-labs/goverland-core-feed/protocol v0.0.0
+	github.com/goverland-labs/goverland-platform-events v0.3.10
+	github.com/nats-io/nats-server/v2 v2.10.5
+	github.com/nats-io/nats.go v1.31.0
+	github.com/stretchr/testify v1.8.4
+	google.golang.org/grpc v1.69.4
+	google.golang.org/protobuf v1.36.3
+)
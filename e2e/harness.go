@@ -0,0 +1,254 @@
+// Package e2e runs the whole application against the embedded NATS server with JetStream and the memory storage.
+// It's the separate module to keep the NATS server out of the application dependencies.
+package e2e
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/env/v6"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/goverland-labs/goverland-core-feed/internal"
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+const (
+	startTimeout = 10 * time.Second
+	stopTimeout  = 10 * time.Second
+
+	// WaitTimeout is the time the asynchronous processing of events should fit in
+	WaitTimeout = 5 * time.Second
+	// WaitTick is the interval of checking the expected state
+	WaitTick = 50 * time.Millisecond
+
+	subscriberIDKey = "subscriber_id"
+
+	jwtSecret = "e2e-secret"
+)
+
+// Harness is the running application with clients of its API
+type Harness struct {
+	Config config.App
+	Conn   *nats.Conn
+
+	Subscribers   feedpb.SubscriberClient
+	Subscriptions feedpb.SubscriptionClient
+	Feed          feedpb.FeedClient
+	Events        feedpb.FeedEventsClient
+
+	server    *server.Server
+	publisher *natsclient.Publisher
+}
+
+// Start runs the NATS server and the application, everything is stopped on the test cleanup.
+// Configure functions could change the configuration before the application is created.
+func Start(t *testing.T, configure ...func(cfg *config.App)) *Harness {
+	t.Helper()
+
+	ns := startNats(t)
+
+	var cfg config.App
+	require.NoError(t, env.Parse(&cfg))
+
+	cfg.DB.StorageDriver = config.StorageDriverMemory
+	cfg.Nats.URL = ns.ClientURL()
+	cfg.Notifier.Driver = config.NotifierDriverLocal
+	cfg.InternalAPI.Bind = freeAddr(t)
+	cfg.Prometheus.Listen = freeAddr(t)
+	cfg.Health.Listen = freeAddr(t)
+	cfg.Outbox.RelayInterval = WaitTick
+	cfg.Auth.JWTSecret = jwtSecret
+	// webhooks of tests are not resolved
+	cfg.Webhook.AllowPrivate = true
+
+	for _, fn := range configure {
+		fn(&cfg)
+	}
+
+	app, err := internal.NewApplication(cfg)
+	require.NoError(t, err)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		app.Run()
+	}()
+
+	t.Cleanup(func() {
+		app.Stop()
+
+		select {
+		case <-stopped:
+		case <-time.After(stopTimeout):
+			t.Errorf("application is not stopped in %s", stopTimeout)
+		}
+	})
+
+	nc, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(nc.Close)
+
+	publisher, err := natsclient.NewPublisher(nc)
+	require.NoError(t, err)
+
+	conn, err := grpc.NewClient(cfg.InternalAPI.Bind, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	h := &Harness{
+		Config:        cfg,
+		Conn:          nc,
+		Subscribers:   feedpb.NewSubscriberClient(conn),
+		Subscriptions: feedpb.NewSubscriptionClient(conn),
+		Feed:          feedpb.NewFeedClient(conn),
+		Events:        feedpb.NewFeedEventsClient(conn),
+		server:        ns,
+		publisher:     publisher,
+	}
+
+	h.waitForAPI(t)
+
+	return h
+}
+
+func startNats(t *testing.T) *server.Server {
+	t.Helper()
+
+	ns, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+
+	go ns.Start()
+	if !ns.ReadyForConnections(startTimeout) {
+		t.Fatalf("nats server is not ready in %s", startTimeout)
+	}
+
+	t.Cleanup(func() {
+		ns.Shutdown()
+		ns.WaitForShutdown()
+	})
+
+	return ns
+}
+
+// freeAddr returns the local address with the port which is free at the moment
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func (h *Harness) waitForAPI(t *testing.T) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), WaitTick)
+		defer cancel()
+
+		_, err := h.Feed.GetByFilter(h.AsAdmin(ctx), &feedpb.FeedByFilterRequest{})
+
+		return err == nil
+	}, startTimeout, WaitTick, "gRPC API is not ready")
+}
+
+// Publish sends the event the same way the core services do.
+// It waits for the application consumer of the subject, so the event is not lost on startup.
+func (h *Harness) Publish(t *testing.T, subject string, payload any) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return h.server.GlobalAccount().SubscriptionInterest(subject)
+	}, startTimeout, WaitTick, "there is no consumer of %s", subject)
+
+	require.NoError(t, h.publisher.PublishJSON(context.Background(), subject, payload))
+}
+
+// Callbacks returns subscriber callbacks sent by the application after the call
+func (h *Harness) Callbacks(t *testing.T) <-chan pevents.CallbackPayload {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ch := make(chan pevents.CallbackPayload, 100)
+	consumer, err := natsclient.NewConsumer(ctx, h.Conn, "e2e_callbacks", pevents.SubjectCallback, func(payload pevents.CallbackPayload) error {
+		ch <- payload
+		return nil
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = consumer.Close()
+	})
+
+	return ch
+}
+
+// CreateSubscriber creates the subscriber with the webhook and returns its identifier
+// with the context authorized as the subscriber
+func (h *Harness) CreateSubscriber(t *testing.T, webhookURL string) (string, context.Context) {
+	t.Helper()
+
+	resp, err := h.Subscribers.Create(h.AsAdmin(context.Background()), &feedpb.CreateSubscriberRequest{
+		WebhookUrl: webhookURL,
+	})
+	require.NoError(t, err)
+
+	return resp.GetSubscriberId(), AsSubscriber(context.Background(), resp.GetSubscriberId())
+}
+
+// AsSubscriber returns the context with the subscriber identifier passed to the API
+func AsSubscriber(ctx context.Context, subscriberID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, subscriberIDKey, subscriberID)
+}
+
+// AsAdmin returns the context with the admin token passed to the API
+func (h *Harness) AsAdmin(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+adminToken())
+}
+
+// adminToken signs the HS256 token with the admin scope
+func adminToken() string {
+	encode := func(v any) string {
+		data, _ := json.Marshal(v)
+
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	unsigned := encode(map[string]any{"alg": "HS256", "typ": "JWT"}) + "." + encode(map[string]any{
+		"scope": "admin",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	})
+
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
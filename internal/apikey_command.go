@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
+)
+
+const (
+	APIKeyCreate = "create"
+	APIKeyRevoke = "revoke"
+)
+
+type APIKeyOptions struct {
+	Command      string
+	SubscriberID uuid.UUID
+	Scopes       string
+	KeyID        uuid.UUID
+}
+
+// APIKey issues or revokes API keys of subscribers and writes the result to the output
+func APIKey(ctx context.Context, cfg config.App, opts APIKeyOptions, out io.Writer) error {
+	a := &Application{cfg: cfg}
+
+	// subscribers are not changed by the command, so invalidations are not delivered to other replicas
+	a.cacheInvalidator = cache.NewInvalidator(pubsub.NewPubSub[string](0))
+
+	if err := a.initStorage(); err != nil {
+		return err
+	}
+	if err := a.initSubscribers(); err != nil {
+		return err
+	}
+
+	switch opts.Command {
+	case APIKeyCreate:
+		key, item, err := a.apiKeys.Create(ctx, opts.SubscriberID, strings.Fields(opts.Scopes))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "id: %s\nsubscriber: %s\nscopes: %s\nkey: %s\n", item.ID, item.SubscriberID, item.Scopes, key)
+
+		return nil
+	case APIKeyRevoke:
+		if err := a.apiKeys.Revoke(ctx, opts.KeyID); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "revoked: %s\n", opts.KeyID)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown api key command: %s", opts.Command)
+	}
+}
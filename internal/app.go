@@ -1,18 +1,27 @@
 package internal
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
 	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
 	"github.com/s-larionov/process-manager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/delivery"
 	"github.com/goverland-labs/goverland-core-feed/internal/feedevent"
+	"github.com/goverland-labs/goverland-core-feed/internal/migration"
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
 	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
 	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
 
@@ -20,21 +29,40 @@ import (
 	"github.com/goverland-labs/goverland-core-feed/internal/item"
 	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
 	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
 	"github.com/goverland-labs/goverland-core-feed/pkg/grpcsrv"
 	"github.com/goverland-labs/goverland-core-feed/pkg/health"
 	"github.com/goverland-labs/goverland-core-feed/pkg/prometheus"
+	"github.com/goverland-labs/goverland-core-feed/resources"
 )
 
+type subscriberRepo interface {
+	subscriber.DataProvider
+	subscriber.APIKeyProvider
+	subscriber.WebhookEndpointProvider
+}
+
 type Application struct {
 	sigChan <-chan os.Signal
 	manager *process.Manager
 	cfg     config.App
 	db      *gorm.DB
 
+	itemRepo         item.DataProvider
+	subscriberRepo   subscriberRepo
+	subscriptionRepo subscription.DataProvider
+	outboxRepo       outbox.DataProvider
+	webhookRepo      webhook.DataProvider
+	cacheInvalidator *cache.Invalidator
+	notifier         notifier
+
 	subscribers      *subscriber.Service
+	apiKeys          *subscriber.APIKeys
+	webhookEndpoints *subscriber.WebhookEndpoints
 	subscriptions    *subscription.Service
 	itemService      *item.Service
 	feedEventService *feedevent.Service
+	deliveryService  *delivery.Service
 }
 
 func NewApplication(cfg config.App) (*Application, error) {
@@ -60,9 +88,14 @@ func (a *Application) Run() {
 	a.registerShutdown()
 }
 
+// Stop stops all workers, Run returns after the workers are stopped
+func (a *Application) Stop() {
+	a.manager.StopAll()
+}
+
 func (a *Application) bootstrap() error {
 	initializers := []func() error{
-		a.initDB,
+		a.initStorage,
 
 		// Init Dependencies
 		a.initServices,
@@ -84,6 +117,39 @@ func (a *Application) bootstrap() error {
 	return nil
 }
 
+// initStorage creates repositories of the configured storage driver
+func (a *Application) initStorage() error {
+	switch a.cfg.DB.StorageDriver {
+	case config.StorageDriverPostgres:
+		if err := a.initDB(); err != nil {
+			return err
+		}
+
+		if err := a.initMigrations(); err != nil {
+			return err
+		}
+
+		a.itemRepo = item.NewRepo(a.db)
+		a.subscriberRepo = subscriber.NewRepo(a.db)
+		a.subscriptionRepo = subscription.NewRepo(a.db)
+		a.outboxRepo = outbox.NewRepo(a.db)
+		a.webhookRepo = webhook.NewRepo(a.db)
+	case config.StorageDriverMemory:
+		subscriptions := subscription.NewMemoryRepo()
+		messages := outbox.NewMemoryRepo()
+
+		a.itemRepo = item.NewMemoryRepo(subscriptions, messages)
+		a.subscriberRepo = subscriber.NewMemoryRepo()
+		a.subscriptionRepo = subscriptions
+		a.outboxRepo = messages
+		a.webhookRepo = webhook.NewMemoryRepo()
+	default:
+		return fmt.Errorf("unknown storage driver: %s", a.cfg.DB.StorageDriver)
+	}
+
+	return nil
+}
+
 func (a *Application) initDB() error {
 	db, err := gorm.Open(postgres.Open(a.cfg.DB.DSN), &gorm.Config{})
 	if err != nil {
@@ -104,6 +170,26 @@ func (a *Application) initDB() error {
 	return err
 }
 
+// initMigrations applies pending migrations if auto migration is enabled and refuses to start with the outdated schema
+func (a *Application) initMigrations() error {
+	m, err := migration.NewMigrator(a.db, resources.Migrations)
+	if err != nil {
+		return err
+	}
+
+	if a.cfg.DB.AutoMigrate {
+		applied, err := m.Up()
+		for _, mg := range applied {
+			log.Info().Str("version", mg.Version).Str("description", mg.Description).Msg("migration is applied")
+		}
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+	}
+
+	return m.Check()
+}
+
 func (a *Application) initServices() error {
 	nc, err := nats.Connect(
 		a.cfg.Nats.URL,
@@ -120,6 +206,12 @@ func (a *Application) initServices() error {
 		return err
 	}
 
+	if err = a.initCacheInvalidator(nc); err != nil {
+		return fmt.Errorf("cache invalidator: %w", err)
+	}
+	if a.notifier, err = a.initNotifier(nc); err != nil {
+		return fmt.Errorf("notifier: %w", err)
+	}
 	if err = a.initSubscribers(); err != nil {
 		return err
 	}
@@ -141,19 +233,35 @@ func (a *Application) initServices() error {
 }
 
 func (a *Application) initDataConsumers(nc *nats.Conn, pb *natsclient.Publisher) error {
-	feedItemsNotifier := pubsub.NewPubSub[string](1000) // TODO: const
-	repo := item.NewRepo(a.db)
-
-	service, err := item.NewService(repo, pb, a.subscribers, a.subscriptions, feedItemsNotifier)
+	service, err := item.NewService(a.itemRepo, a.subscribers, a.webhookEndpoints, a.subscriptions, a.newCallbacksValidator(), a.notifier, a.newFiltersCache())
 	if err != nil {
 		return fmt.Errorf("item service: %w", err)
 	}
 
-	feedEventService := feedevent.NewService(feedItemsNotifier, service)
+	feedEventService := feedevent.NewService(a.notifier, service, a.subscriptions)
 
 	a.itemService = service
 	a.feedEventService = feedEventService
 
+	pw := item.NewPartitionWorker(service, a.cfg.Partitions.MonthsAhead, a.cfg.Partitions.Interval)
+	a.manager.AddWorker(process.NewCallbackWorker("item-partitions", pw.Start))
+
+	pew := item.NewProcessedEventsWorker(service, a.cfg.Events.DedupRetention)
+	a.manager.AddWorker(process.NewCallbackWorker("item-processed-events-retention", pew.Start))
+
+	publisher, err := a.newCallbackPublisher(nc, pb)
+	if err != nil {
+		return fmt.Errorf("callback publisher: %w", err)
+	}
+
+	relay := outbox.NewRelay(a.outboxRepo, publisher, a.cfg.Outbox.RelayInterval, a.cfg.Outbox.BatchSize, a.cfg.Outbox.Retention, a.cfg.Outbox.MaxAttempts)
+	a.manager.AddWorker(process.NewCallbackWorker("outbox-relay", relay.Start))
+
+	a.deliveryService = delivery.NewService(a.webhookRepo, service, publisher)
+
+	drw := webhook.NewDeliveriesRetentionWorker(a.webhookRepo, a.cfg.Webhook.DeliveriesRetention)
+	a.manager.AddWorker(process.NewCallbackWorker("webhook-deliveries-retention", drw.Start))
+
 	dc, err := item.NewDaoConsumer(nc, service)
 	if err != nil {
 		return fmt.Errorf("item dao consumer: %w", err)
@@ -175,51 +283,319 @@ func (a *Application) initDataConsumers(nc *nats.Conn, pb *natsclient.Publisher)
 
 	a.manager.AddWorker(process.NewCallbackWorker("item-delegate-consumer", dlc.Start))
 
+	dsc, err := item.NewDiscussionConsumer(nc, service)
+	if err != nil {
+		return fmt.Errorf("item discussion consumer: %w", err)
+	}
+
+	a.manager.AddWorker(process.NewCallbackWorker("item-discussion-consumer", dsc.Start))
+
+	if a.cfg.Votes.Enabled {
+		vc, err := item.NewVoteConsumer(nc, service)
+		if err != nil {
+			return fmt.Errorf("item vote consumer: %w", err)
+		}
+
+		a.manager.AddWorker(process.NewCallbackWorker("item-vote-consumer", vc.Start))
+	}
+
+	if policies := a.retentionPolicies(); len(policies) > 0 {
+		dryRun := a.cfg.Retention.Enabled && a.cfg.Retention.DryRun
+		rw := item.NewRetentionWorker(service, policies, a.cfg.Retention.Interval, a.cfg.Retention.BatchSize, dryRun)
+		a.manager.AddWorker(process.NewCallbackWorker("item-retention", rw.Start))
+	}
+
 	return nil
 }
 
-// todo: move exclude path to config?
+// newCallbackPublisher returns the publisher of outbox messages delivering callbacks by the configured way
+func (a *Application) newCallbackPublisher(nc *nats.Conn, pb *natsclient.Publisher) (outbox.Publisher, error) {
+	switch a.cfg.Webhook.Delivery {
+	case config.WebhookDeliveryNats:
+		return pb, nil
+	case config.WebhookDeliveryHTTP:
+		client := a.newWebhookValidator().HTTPClient(a.cfg.Webhook.DeliveryTimeout)
+
+		dispatcher := webhook.NewDispatcher(pb, client, a.webhookRepo, webhook.DispatcherOptions{
+			Breaker: webhook.BreakerOptions{
+				Failures: a.cfg.Webhook.BreakerFailures,
+				Cooldown: a.cfg.Webhook.BreakerCooldown,
+			},
+			Retry: webhook.RetryOptions{
+				MaxAttempts: a.cfg.Webhook.RetryMaxAttempts,
+				Backoff:     a.cfg.Webhook.RetryBackoff,
+			},
+			DisabledSubject: a.cfg.Webhook.DisabledSubject,
+		})
+		a.manager.AddWorker(process.NewCallbackWorker("webhook-batches", dispatcher.Start))
+
+		consumer := webhook.NewConsumer(nc, a.cfg.Webhook.DeliverySubject, dispatcher)
+		a.manager.AddWorker(process.NewCallbackWorker("webhook-delivery-consumer", consumer.Start))
+
+		return webhook.NewQueue(pb, a.cfg.Webhook.DeliverySubject), nil
+	default:
+		return nil, fmt.Errorf("unknown webhook delivery: %s", a.cfg.Webhook.Delivery)
+	}
+}
+
+func (a *Application) newWebhookValidator() *webhook.Validator {
+	return webhook.NewValidator(webhook.Options{
+		RequireHTTPS: a.cfg.Webhook.RequireHTTPS,
+		AllowPrivate: a.cfg.Webhook.AllowPrivate,
+	})
+}
+
+// newCallbacksValidator returns the validator of webhook addresses before callbacks are published,
+// callbacks delivered by http are checked when addresses are dialed
+func (a *Application) newCallbacksValidator() item.WebhookValidator {
+	if a.cfg.Webhook.Delivery == config.WebhookDeliveryHTTP {
+		return nil
+	}
+
+	return a.newWebhookValidator()
+}
+
+func (a *Application) newWebhookChecker() *webhook.Checker {
+	validator := a.newWebhookValidator()
+
+	var verifier *webhook.Verifier
+	if a.cfg.Webhook.VerificationEnabled {
+		verifier = webhook.NewVerifier(validator.HTTPClient(a.cfg.Webhook.VerificationTimeout))
+	}
+
+	return webhook.NewChecker(validator, verifier)
+}
+
+func (a *Application) newFiltersCache() *item.FiltersCache {
+	return item.NewFiltersCache(cache.Options{
+		MaxSize:     a.cfg.Cache.FiltersSize,
+		TTL:         a.cfg.Cache.FiltersTTL,
+		NegativeTTL: a.cfg.Cache.FiltersNegativeTTL,
+	})
+}
+
+// retentionPolicies returns configured retention policies, votes are always removed after the votes retention period
+func (a *Application) retentionPolicies() []item.RetentionPolicy {
+	var policies []item.RetentionPolicy
+	if a.cfg.Retention.Enabled {
+		for _, p := range a.cfg.Retention.Policies {
+			policies = append(policies, item.RetentionPolicy{
+				Type:   item.Type(p.Type),
+				MaxAge: p.MaxAge,
+				Mode:   item.RetentionMode(p.Mode),
+			})
+		}
+	}
+
+	if !a.cfg.Votes.Enabled {
+		return policies
+	}
+
+	for _, p := range policies {
+		if p.Type == item.TypeVote {
+			return policies
+		}
+	}
+
+	return append(policies, item.RetentionPolicy{
+		Type:   item.TypeVote,
+		MaxAge: a.cfg.Votes.Retention,
+		Mode:   item.RetentionModeDelete,
+	})
+}
+
+type notifier interface {
+	item.Notifier
+	feedevent.Notifier
+}
+
+func (a *Application) initNotifier(nc *nats.Conn) (notifier, error) {
+	return a.newBroadcaster(nc, "feed-items", a.cfg.Notifier.NatsSubject, a.cfg.Notifier.PostgresChannel)
+}
+
+// initCacheInvalidator delivers invalidations of subscribers and subscriptions caches to other replicas
+// by the same driver as feed items notifications
+func (a *Application) initCacheInvalidator(nc *nats.Conn) error {
+	b, err := a.newBroadcaster(nc, "cache-invalidation", a.cfg.Cache.InvalidationNatsSubject, a.cfg.Cache.InvalidationPostgresChannel)
+	if err != nil {
+		return err
+	}
+
+	a.cacheInvalidator = cache.NewInvalidator(b)
+	a.manager.AddWorker(process.NewCallbackWorker("cache-invalidator", a.cacheInvalidator.Start))
+
+	return nil
+}
+
+// newBroadcaster returns the local pubsub or the broadcaster delivering messages to all replicas by the configured driver
+func (a *Application) newBroadcaster(nc *nats.Conn, name, subject, channel string) (notifier, error) {
+	local := pubsub.NewPubSub[string](1000) // TODO: const
+
+	switch a.cfg.Notifier.Driver {
+	case config.NotifierDriverLocal:
+		return local, nil
+	case config.NotifierDriverNats:
+		b := pubsub.NewNatsBroadcaster(nc, subject, local)
+		// listen right away, so messages sent while the application is bootstrapped are not lost
+		if err := b.Listen(); err != nil {
+			return nil, fmt.Errorf("%s broadcaster: %w", name, err)
+		}
+		a.manager.AddWorker(process.NewCallbackWorker(name+"-nats-broadcaster", b.Start))
+
+		return b, nil
+	case config.NotifierDriverPostgres:
+		if a.db == nil {
+			return nil, fmt.Errorf("notifier driver %s requires the postgres storage", a.cfg.Notifier.Driver)
+		}
+
+		b := pubsub.NewPgBroadcaster(a.db, a.cfg.DB.DSN, channel, local)
+		if err := b.Listen(context.Background()); err != nil {
+			return nil, fmt.Errorf("%s broadcaster: %w", name, err)
+		}
+		a.manager.AddWorker(process.NewCallbackWorker(name+"-pg-broadcaster", b.Start))
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier driver: %s", a.cfg.Notifier.Driver)
+	}
+}
+
 func (a *Application) initAPI() error {
-	authInterceptor := grpcsrv.NewAuthInterceptor(a.subscribers)
+	var opts []grpc.ServerOption
+	if a.cfg.InternalAPI.TLSCertFile != "" {
+		reloader, err := grpcsrv.NewCertReloader(grpcsrv.TLSOptions{
+			CertFile:     a.cfg.InternalAPI.TLSCertFile,
+			KeyFile:      a.cfg.InternalAPI.TLSKeyFile,
+			ClientCAFile: a.cfg.InternalAPI.TLSClientCAFile,
+			ClientAuth:   grpcsrv.ClientAuth(a.cfg.InternalAPI.TLSClientAuth),
+		}, a.cfg.InternalAPI.TLSReloadInterval)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+		a.manager.AddWorker(process.NewCallbackWorker("grpc-tls-reloader", reloader.Start))
+	}
+
+	// limits are applied after the authentication, so the subscriber is known
+	limiter := grpcsrv.NewLimiter(grpcsrv.LimitOptions{
+		Rate:       a.cfg.Limits.RateLimit,
+		Burst:      a.cfg.Limits.RateBurst,
+		MaxStreams: a.cfg.Limits.MaxStreams,
+	})
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(limiter.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(limiter.StreamInterceptor),
+	)
+	a.manager.AddWorker(process.NewCallbackWorker("grpc-limiter", limiter.Start))
+
+	authInterceptor := grpcsrv.NewAuthInterceptor(a.newAuthenticator(), apiMethodScopes)
 	srv := grpcsrv.NewGrpcServer(
-		[]string{
-			"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
-			"/feedpb.Subscriber/Create",
-			"/feedpb.Feed/GetByFilter",
-		},
-		authInterceptor.AuthAndIdentifyTickerFunc,
+		a.cfg.Auth.Exclude,
+		authInterceptor.AuthFunc,
+		opts...,
 	)
 
-	feedpb.RegisterSubscriberServer(srv, subscriber.NewServer(a.subscribers))
+	feedpb.RegisterSubscriberServer(srv, subscriber.NewServer(a.subscribers, a.webhookEndpoints, a.newWebhookChecker(), a.webhookRepo, a.cfg.Webhook.Delivery == config.WebhookDeliveryHTTP))
 	feedpb.RegisterSubscriptionServer(srv, subscription.NewServer(a.subscriptions))
 	feedpb.RegisterFeedServer(srv, item.NewServer(a.itemService))
 	feedpb.RegisterFeedEventsServer(srv, feedevent.NewServer(a.feedEventService))
+	feedpb.RegisterWebhookDeliveriesServer(srv, delivery.NewServer(a.deliveryService))
 
 	a.manager.AddWorker(grpcsrv.NewGrpcServerWorker("API", srv, a.cfg.InternalAPI.Bind))
 
 	return nil
 }
 
+// apiMethodScopes are scopes required by API methods, other methods require the admin scope
+var apiMethodScopes = map[string]grpcsrv.Scope{
+	feedpb.Subscriber_Update_FullMethodName:                grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_UpdateBatching_FullMethodName:        grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_CreateWebhookEndpoint_FullMethodName: grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_UpdateWebhookEndpoint_FullMethodName: grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_DeleteWebhookEndpoint_FullMethodName: grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_ListWebhookEndpoints_FullMethodName:  grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscriber_GetWebhookHealth_FullMethodName:      grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscription_Subscribe_FullMethodName:           grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscription_Unsubscribe_FullMethodName:         grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscription_FollowAddress_FullMethodName:       grpcsrv.ScopeManageSubscriptions,
+	feedpb.Subscription_UnfollowAddress_FullMethodName:     grpcsrv.ScopeManageSubscriptions,
+	feedpb.Feed_GetByFilter_FullMethodName:                 grpcsrv.ScopeReadFeed,
+	feedpb.FeedEvents_EventsSubscribe_FullMethodName:       grpcsrv.ScopeReadFeed,
+	feedpb.WebhookDeliveries_ListDeliveries_FullMethodName: grpcsrv.ScopeReadFeed,
+	feedpb.WebhookDeliveries_Redeliver_FullMethodName:      grpcsrv.ScopeManageSubscriptions,
+}
+
+func (a *Application) newAuthenticator() grpcsrv.Authenticator {
+	authenticators := []grpcsrv.Authenticator{
+		grpcsrv.NewAPIKeyAuthenticator(a.apiKeys),
+	}
+
+	if a.cfg.Auth.JWTSecret != "" {
+		authenticators = append(authenticators, grpcsrv.NewJWTAuthenticator(
+			a.cfg.Auth.JWTSecret,
+			a.cfg.Auth.JWTIssuer,
+			a.cfg.Auth.JWTAudience,
+			a.subscribers,
+		))
+	}
+
+	if a.cfg.InternalAPI.TLSClientAuth != string(grpcsrv.ClientAuthNone) {
+		authenticators = append(authenticators, grpcsrv.NewCertificateAuthenticator(a.subscribers, a.cfg.InternalAPI.TLSAdminNames))
+	}
+
+	if a.cfg.Auth.SubscriberIDEnabled {
+		authenticators = append(authenticators, grpcsrv.NewSubscriberIDAuthenticator(a.subscribers))
+	}
+
+	return grpcsrv.NewChainAuthenticator(authenticators...)
+}
+
 func (a *Application) initSubscribers() error {
-	repo := subscriber.NewRepo(a.db)
-	cache := subscriber.NewCache()
-	service, err := subscriber.NewService(repo, cache)
+	subscribersCache := subscriber.NewCacheWithOptions(cache.Options{
+		MaxSize: a.cfg.Cache.SubscribersSize,
+		TTL:     a.cfg.Cache.SubscribersTTL,
+	})
+	service, err := subscriber.NewService(a.subscriberRepo, subscribersCache, a.cacheInvalidator)
 	if err != nil {
 		return fmt.Errorf("subsceiber service: %w", err)
 	}
 	a.subscribers = service
+	a.apiKeys = subscriber.NewAPIKeys(a.subscriberRepo, service, a.cfg.Auth.APIKeyCacheTTL)
+	a.cacheInvalidator.Register(subscriber.CacheName, subscribersCache)
+
+	endpointsCache := subscriber.NewEndpointsCache(cache.Options{
+		MaxSize: a.cfg.Cache.SubscribersSize,
+		TTL:     a.cfg.Cache.SubscribersTTL,
+	})
+	a.webhookEndpoints = subscriber.NewWebhookEndpoints(a.subscriberRepo, endpointsCache, a.cacheInvalidator)
+	a.cacheInvalidator.Register(subscriber.EndpointsCacheName, endpointsCache)
 
 	return nil
 }
 
 func (a *Application) initSubscription() error {
-	repo := subscription.NewRepo(a.db)
-	cache := subscription.NewCache()
-	service, err := subscription.NewService(repo, cache)
+	subscriptionsCache := subscription.NewCacheWithOptions(cache.Options{
+		MaxSize: a.cfg.Cache.SubscriptionsSize,
+		TTL:     a.cfg.Cache.SubscriptionsTTL,
+	})
+	service, err := subscription.NewService(a.subscriptionRepo, subscriptionsCache, a.cacheInvalidator, a.notifier, a.cfg.Limits.MaxSubscriptions)
 	if err != nil {
 		return fmt.Errorf("subscription service: %w", err)
 	}
 	a.subscriptions = service
+	a.cacheInvalidator.Register(subscription.CacheName, subscriptionsCache)
+
+	if a.cfg.Cache.WarmUpEnabled {
+		started := time.Now()
+		keys, err := service.WarmUp(context.Background(), a.cfg.Cache.WarmUpChunkSize)
+		if err != nil {
+			// the cache is filled lazily, so the failed warm-up doesn't prevent the start
+			log.Error().Err(err).Msg("warm up subscriptions cache")
+		} else {
+			log.Info().Int("keys", keys).Dur("duration", time.Since(started)).Msg("subscriptions cache is warmed up")
+		}
+	}
 
 	return nil
 }
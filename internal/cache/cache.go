@@ -0,0 +1,306 @@
+// Package cache provides the bounded in-memory cache used by services to reduce database reads
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Options limits the cache. Zero MaxSize means there is no size limit,
+// zero TTL means entries don't expire. NegativeTTL is used for empty results stored by SetNegative.
+type Options struct {
+	MaxSize     int
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+// maxTagVersions limits versions of invalidated tags kept by the cache
+const maxTagVersions = 10_000
+
+// Version is the state of the cache taken before the value is loaded from the storage
+type Version struct {
+	seq  uint64
+	tags []string
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+	tags      []string
+}
+
+// Cache is the LRU cache with expiring entries. Entries could be marked by tags,
+// so all entries related to the changed object are invalidated without scanning the cache.
+// It's safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	name string
+	opts Options
+	now  func() time.Time
+
+	mu    sync.Mutex
+	items map[K]*list.Element
+	lru   *list.List
+	tags  map[string]map[K]struct{}
+	// seq is the number of the last change, version is the number of the last change of keys,
+	// tagVersions are numbers of the last invalidations of tags
+	seq         uint64
+	version     uint64
+	tagVersions map[string]uint64
+	tagFloor    uint64
+}
+
+// New creates the cache, the name is used as the label of cache metrics
+func New[K comparable, V any](name string, opts Options) *Cache[K, V] {
+	return &Cache[K, V]{
+		name:  name,
+		opts:  opts,
+		now:   time.Now,
+		items: make(map[K]*list.Element),
+		lru:   list.New(),
+		tags:  make(map[string]map[K]struct{}),
+
+		tagVersions: make(map[string]uint64),
+	}
+}
+
+// Get returns the value if it's cached and not expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.get(key)
+	if ok {
+		metricRequestsCounter.WithLabelValues(c.name, resultHit).Inc()
+	} else {
+		metricRequestsCounter.WithLabelValues(c.name, resultMiss).Inc()
+	}
+
+	return value, ok
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var empty V
+
+	el, ok := c.items[key]
+	if !ok {
+		return empty, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.remove(el, reasonExpired)
+
+		return empty, false
+	}
+
+	c.lru.MoveToFront(el)
+
+	return e.value, true
+}
+
+// Set stores the value for TTL, tags replace tags of the previous value
+func (c *Cache[K, V]) Set(key K, value V, tags ...string) {
+	c.set(key, value, c.opts.TTL, tags)
+}
+
+// SetNegative stores the empty result for NegativeTTL, so repeated requests of missing data don't reach the storage
+func (c *Cache[K, V]) SetNegative(key K, value V, tags ...string) {
+	c.set(key, value, c.opts.NegativeTTL, tags)
+}
+
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration, tags []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, value, ttl, tags)
+}
+
+// Version returns the version of the cache, it's changed by every update and removal of values
+// and by invalidations of the tags. Invalidations of other tags don't change the version.
+func (c *Cache[K, V]) Version(tags ...string) Version {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Version{seq: c.versionOf(tags), tags: tags}
+}
+
+// SetIfVersion stores the value for TTL only if the version is not changed since it was taken.
+// It prevents the value loaded from the storage from overriding the concurrent invalidation.
+func (c *Cache[K, V]) SetIfVersion(version Version, key K, value V, tags ...string) bool {
+	return c.setIfVersion(version, key, value, c.opts.TTL, tags)
+}
+
+// SetNegativeIfVersion stores the empty result for NegativeTTL only if the version is not changed since it was taken
+func (c *Cache[K, V]) SetNegativeIfVersion(version Version, key K, value V, tags ...string) bool {
+	return c.setIfVersion(version, key, value, c.opts.NegativeTTL, tags)
+}
+
+func (c *Cache[K, V]) setIfVersion(version Version, key K, value V, ttl time.Duration, tags []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versionOf(version.tags) != version.seq {
+		return false
+	}
+
+	c.store(key, value, ttl, tags)
+
+	return true
+}
+
+func (c *Cache[K, V]) versionOf(tags []string) uint64 {
+	version := c.version
+	if len(tags) > 0 {
+		version = max(version, c.tagFloor)
+	}
+
+	for _, tag := range tags {
+		version = max(version, c.tagVersions[tag])
+	}
+
+	return version
+}
+
+func (c *Cache[K, V]) store(key K, value V, ttl time.Duration, tags []string) {
+	if el, ok := c.items[key]; ok {
+		c.untag(el.Value.(*entry[K, V]))
+		c.lru.Remove(el)
+		delete(c.items, key)
+	}
+
+	e := &entry[K, V]{
+		key:   key,
+		value: value,
+		tags:  tags,
+	}
+	if ttl > 0 {
+		e.expiresAt = c.now().Add(ttl)
+	}
+
+	c.items[key] = c.lru.PushFront(e)
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			c.tags[tag] = keys
+		}
+
+		keys[key] = struct{}{}
+	}
+
+	for c.opts.MaxSize > 0 && c.lru.Len() > c.opts.MaxSize {
+		c.remove(c.lru.Back(), reasonSize)
+	}
+
+	metricEntriesGauge.WithLabelValues(c.name).Set(float64(c.lru.Len()))
+}
+
+// Update replaces the cached value by the result of fn keeping its expiration and tags.
+// It returns false without calling fn if the value is not cached.
+// Values could be read concurrently, so fn has to return the new value instead of modifying the passed one.
+func (c *Cache[K, V]) Update(key K, fn func(value V) V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the missing value might be loading right now, so the loaded one has to be dropped as well
+	c.changeKeys()
+
+	if _, ok := c.get(key); !ok {
+		return false
+	}
+
+	e := c.items[key].Value.(*entry[K, V])
+	e.value = fn(e.value)
+
+	return true
+}
+
+// Delete removes the value of the key
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changeKeys()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el, reasonInvalidated)
+	}
+}
+
+// Invalidate removes all values marked by any of the tags and returns the count of removed values
+func (c *Cache[K, V]) Invalidate(tags ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	for _, tag := range tags {
+		c.tagVersions[tag] = c.seq
+	}
+
+	// forgotten versions are replaced by the floor, so loading values just aren't cached once
+	if len(c.tagVersions) > maxTagVersions {
+		c.tagFloor = c.seq
+		clear(c.tagVersions)
+	}
+
+	var removed int
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if el, ok := c.items[key]; ok {
+				c.remove(el, reasonInvalidated)
+				removed++
+			}
+		}
+	}
+
+	return removed
+}
+
+// Purge removes all values, it's used when invalidations of values might be lost
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changeKeys()
+
+	for el := c.lru.Back(); el != nil; el = c.lru.Back() {
+		c.remove(el, reasonPurged)
+	}
+}
+
+func (c *Cache[K, V]) changeKeys() {
+	c.seq++
+	c.version = c.seq
+}
+
+// Len returns the count of values including expired ones which are not removed yet
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache[K, V]) remove(el *list.Element, reason string) {
+	e := el.Value.(*entry[K, V])
+
+	c.untag(e)
+	c.lru.Remove(el)
+	delete(c.items, e.key)
+
+	metricEvictionsCounter.WithLabelValues(c.name, reason).Inc()
+	metricEntriesGauge.WithLabelValues(c.name).Set(float64(c.lru.Len()))
+}
+
+func (c *Cache[K, V]) untag(e *entry[K, V]) {
+	for _, tag := range e.tags {
+		keys := c.tags[tag]
+		delete(keys, e.key)
+
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCache(opts Options) (*Cache[string, int], *fakeClock) {
+	clock := &fakeClock{now: time.Now()}
+	c := New[string, int]("test", opts)
+	c.now = clock.Now
+
+	return c, clock
+}
+
+func TestUnitCacheLRU(t *testing.T) {
+	c, _ := newTestCache(Options{MaxSize: 2})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// "a" becomes the most recently used
+	_, ok := c.Get("a")
+	require.True(t, ok)
+
+	c.Set("c", 3)
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	value, ok = c.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+}
+
+func TestUnitCacheTTL(t *testing.T) {
+	c, clock := newTestCache(Options{TTL: time.Minute, NegativeTTL: time.Second})
+
+	c.Set("positive", 1)
+	c.SetNegative("negative", 0)
+
+	clock.now = clock.now.Add(2 * time.Second)
+
+	_, ok := c.Get("negative")
+	require.False(t, ok)
+
+	_, ok = c.Get("positive")
+	require.True(t, ok)
+
+	clock.now = clock.now.Add(time.Minute)
+
+	_, ok = c.Get("positive")
+	require.False(t, ok)
+	require.Zero(t, c.Len())
+}
+
+func TestUnitCacheInvalidate(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	c.Set("a", 1, "dao:1", "proposal:1")
+	c.Set("b", 2, "dao:1")
+	c.Set("c", 3, "dao:2")
+
+	require.Equal(t, 1, c.Invalidate("proposal:1"))
+	require.Equal(t, 1, c.Invalidate("dao:1"))
+	require.Zero(t, c.Invalidate("dao:1"))
+
+	_, ok := c.Get("c")
+	require.True(t, ok)
+
+	// tags of the replaced value are dropped
+	c.Set("c", 4, "dao:3")
+	require.Zero(t, c.Invalidate("dao:2"))
+	require.Equal(t, 1, c.Invalidate("dao:3"))
+	require.Empty(t, c.tags)
+}
+
+func TestUnitCacheDelete(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	c.Set("a", 1, "tag")
+	c.Delete("a")
+	c.Delete("missing")
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+	require.Empty(t, c.tags)
+}
+
+func TestUnitCacheUpdate(t *testing.T) {
+	c, clock := newTestCache(Options{TTL: time.Minute})
+
+	require.False(t, c.Update("a", func(v int) int { return v + 1 }))
+
+	c.Set("a", 1, "tag")
+	require.True(t, c.Update("a", func(v int) int { return v + 1 }))
+
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+
+	// the update doesn't prolong the value
+	clock.now = clock.now.Add(time.Minute)
+	require.False(t, c.Update("a", func(v int) int { return v + 1 }))
+	require.Empty(t, c.tags)
+}
+
+func TestUnitCacheSetIfVersion(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	version := c.Version()
+	require.True(t, c.SetIfVersion(version, "a", 1))
+
+	// the value is invalidated while the new one is loading
+	version = c.Version()
+	c.Delete("a")
+	require.False(t, c.SetIfVersion(version, "a", 2))
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	// the missing value is updated while it's loading
+	version = c.Version()
+	require.False(t, c.Update("a", func(v int) int { return v + 1 }))
+	require.False(t, c.SetIfVersion(version, "a", 3))
+
+	version = c.Version("tag")
+	c.Invalidate("tag")
+	require.False(t, c.SetNegativeIfVersion(version, "a", 0))
+
+	require.True(t, c.SetNegativeIfVersion(c.Version(), "a", 0))
+	_, ok = c.Get("a")
+	require.True(t, ok)
+}
+
+func TestUnitCacheSetIfVersionComparesOnlyVersionTags(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	// the invalidation of another tag doesn't drop the loading value
+	version := c.Version("a")
+	c.Invalidate("b")
+	require.True(t, c.SetIfVersion(version, "key", 1, "a", "c"))
+
+	// tags of the stored value are not compared, only the tags of the version
+	version = c.Version("a")
+	c.Invalidate("c")
+	require.True(t, c.SetIfVersion(version, "key", 2, "a", "c"))
+
+	version = c.Version("a", "b")
+	c.Invalidate("b")
+	require.False(t, c.SetIfVersion(version, "key", 3, "a"))
+
+	// removals of keys change versions of all tags
+	version = c.Version("a")
+	c.Delete("other")
+	require.False(t, c.SetIfVersion(version, "key", 4, "a"))
+
+	// the version without tags isn't changed by invalidations
+	version = c.Version()
+	c.Invalidate("a")
+	require.True(t, c.SetIfVersion(version, "other", 5))
+}
+
+func TestUnitCacheForgetsTagVersions(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	version := c.Version("a")
+	for i := 0; i <= maxTagVersions; i++ {
+		c.Invalidate(fmt.Sprintf("tag-%d", i))
+	}
+
+	require.Empty(t, c.tagVersions)
+	require.False(t, c.SetIfVersion(version, "key", 1, "a"))
+	require.True(t, c.SetIfVersion(c.Version("a"), "key", 1, "a"))
+}
+
+func TestUnitCachePurge(t *testing.T) {
+	c, _ := newTestCache(Options{})
+
+	c.Set("a", 1, "tag")
+	c.Set("b", 2)
+
+	version := c.Version()
+	c.Purge()
+
+	require.Zero(t, c.Len())
+	require.Empty(t, c.tags)
+	require.NotEqual(t, version, c.Version())
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Broadcaster delivers messages to all replicas including the current one
+type Broadcaster interface {
+	Subscribe(topics ...string) chan string
+	Unsubscribe(ch chan string)
+	PublishNoWait(msg string)
+}
+
+// Handler applies invalidations received from other replicas to the local cache
+type Handler interface {
+	InvalidateKey(key string)
+	Purge()
+}
+
+type invalidation struct {
+	Origin string `json:"origin"`
+	Seq    uint64 `json:"seq"`
+	Cache  string `json:"cache"`
+	Key    string `json:"key"`
+}
+
+// Invalidator keeps caches of replicas consistent: the replica changing the data updates its own cache
+// and publishes the invalidation of the key, other replicas remove the key from their caches.
+//
+// Messages of every replica are numbered, so the replica notices lost messages by the gap in numbers
+// and purges all caches. The broadcaster sends the empty message after reconnect for the same reason.
+// The loss of the last messages is not noticed until the next one, it's bounded by TTL of caches.
+//
+// The invalidator is subscribed on creation, so invalidations received while caches are warmed up
+// are applied by Start later.
+type Invalidator struct {
+	origin      string
+	broadcaster Broadcaster
+	ch          chan string
+
+	mu       sync.Mutex
+	seq      uint64
+	handlers map[string]Handler
+	received map[string]uint64
+}
+
+func NewInvalidator(broadcaster Broadcaster) *Invalidator {
+	return &Invalidator{
+		origin:      uuid.NewString(),
+		broadcaster: broadcaster,
+		ch:          broadcaster.Subscribe(),
+		handlers:    make(map[string]Handler),
+		received:    make(map[string]uint64),
+	}
+}
+
+// Register adds the handler of invalidations of the named cache
+func (i *Invalidator) Register(name string, handler Handler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.handlers[name] = handler
+}
+
+// Publish notifies other replicas about the changed key of the named cache
+func (i *Invalidator) Publish(name, key string) {
+	i.mu.Lock()
+	i.seq++
+	msg := invalidation{
+		Origin: i.origin,
+		Seq:    i.seq,
+		Cache:  name,
+		Key:    key,
+	}
+	i.mu.Unlock()
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("marshal cache invalidation")
+
+		return
+	}
+
+	i.broadcaster.PublishNoWait(string(data))
+}
+
+func (i *Invalidator) Start(ctx context.Context) error {
+	defer i.broadcaster.Unsubscribe(i.ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-i.ch:
+			if !ok {
+				return nil
+			}
+
+			i.handle(msg)
+		}
+	}
+}
+
+func (i *Invalidator) handle(msg string) {
+	if msg == "" {
+		i.purge("reconnect")
+
+		return
+	}
+
+	var inv invalidation
+	if err := json.Unmarshal([]byte(msg), &inv); err != nil {
+		log.Error().Err(err).Str("message", msg).Msg("unmarshal cache invalidation")
+
+		return
+	}
+
+	// the cache of the current replica is updated by the change itself
+	if inv.Origin == i.origin {
+		return
+	}
+
+	i.mu.Lock()
+	last, known := i.received[inv.Origin]
+	if !known || inv.Seq > last {
+		i.received[inv.Origin] = inv.Seq
+	}
+	handler := i.handlers[inv.Cache]
+	i.mu.Unlock()
+
+	// messages sent before the start of the current replica are not expected
+	if known && inv.Seq != last+1 {
+		metricInvalidationGapsCounter.Inc()
+		i.purge("messages are lost")
+
+		return
+	}
+
+	if handler != nil {
+		handler.InvalidateKey(inv.Key)
+	}
+}
+
+func (i *Invalidator) purge(reason string) {
+	log.Warn().Str("reason", reason).Msg("purge caches")
+
+	i.mu.Lock()
+	handlers := make([]Handler, 0, len(i.handlers))
+	for _, handler := range i.handlers {
+		handlers = append(handlers, handler)
+	}
+	i.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler.Purge()
+	}
+}
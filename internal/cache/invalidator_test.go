@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
+)
+
+type recordingHandler struct {
+	keys   chan string
+	purges chan struct{}
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{
+		keys:   make(chan string, 10),
+		purges: make(chan struct{}, 10),
+	}
+}
+
+func (h *recordingHandler) InvalidateKey(key string) {
+	h.keys <- key
+}
+
+func (h *recordingHandler) Purge() {
+	h.purges <- struct{}{}
+}
+
+// loopback delivers published messages back to the subscriber like the broadcaster of the single replica
+type loopback struct {
+	ch chan string
+}
+
+func (l *loopback) Subscribe(...string) chan string { return l.ch }
+func (l *loopback) Unsubscribe(chan string)         {}
+func (l *loopback) PublishNoWait(msg string)        { l.ch <- msg }
+
+func remoteMessage(t *testing.T, seq uint64, key string) string {
+	t.Helper()
+
+	data, err := json.Marshal(invalidation{Origin: "remote", Seq: seq, Cache: "test", Key: key})
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestUnitInvalidator(t *testing.T) {
+	b := &loopback{ch: make(chan string, 10)}
+	h := newRecordingHandler()
+
+	i := NewInvalidator(b)
+	i.Register("test", h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = i.Start(ctx)
+	}()
+
+	// own messages are skipped
+	i.Publish("test", "own")
+
+	b.ch <- remoteMessage(t, 5, "a")
+	b.ch <- remoteMessage(t, 6, "b")
+	require.Equal(t, "a", <-h.keys)
+	require.Equal(t, "b", <-h.keys)
+
+	// the message 7 is lost
+	b.ch <- remoteMessage(t, 8, "c")
+	select {
+	case <-h.purges:
+	case <-time.After(time.Second):
+		t.Fatal("caches are not purged after the gap")
+	}
+
+	// the broadcaster is reconnected
+	b.ch <- ""
+	select {
+	case <-h.purges:
+	case <-time.After(time.Second):
+		t.Fatal("caches are not purged after reconnect")
+	}
+
+	b.ch <- remoteMessage(t, 9, "d")
+	require.Equal(t, "d", <-h.keys)
+	require.Empty(t, h.keys)
+}
+
+func TestUnitInvalidatorAppliesMessagesReceivedBeforeStart(t *testing.T) {
+	b := pubsub.NewPubSub[string](10)
+	h := newRecordingHandler()
+
+	i := NewInvalidator(b)
+	i.Register("test", h)
+
+	// caches are warmed up before workers are started
+	b.PublishNoWait(remoteMessage(t, 1, "a"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = i.Start(ctx)
+	}()
+
+	select {
+	case key := <-h.keys:
+		require.Equal(t, "a", key)
+	case <-time.After(time.Second):
+		t.Fatal("the invalidation received before start is not applied")
+	}
+}
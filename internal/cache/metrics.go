@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+var metricRequestsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Count of cache requests by the result: hit or miss",
+	}, []string{"cache", "result"},
+)
+
+var metricEvictionsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "evictions_total",
+		Help:      "Count of removed cache entries by the reason: size, expired, invalidated or purged",
+	}, []string{"cache", "reason"},
+)
+
+var metricEntriesGauge = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "entries",
+		Help:      "Count of cache entries",
+	}, []string{"cache"},
+)
+
+var metricInvalidationGapsCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "invalidation_gaps_total",
+		Help:      "Count of noticed gaps in invalidation messages of other replicas leading to purge of caches",
+	},
+)
+
+const (
+	resultHit  = "hit"
+	resultMiss = "miss"
+
+	reasonSize        = "size"
+	reasonExpired     = "expired"
+	reasonInvalidated = "invalidated"
+	reasonPurged      = "purged"
+)
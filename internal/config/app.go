@@ -7,4 +7,14 @@ type App struct {
 	DB          DB
 	Nats        Nats
 	InternalAPI InternalAPI
+	Auth        Auth
+	Limits      Limits
+	Votes       Votes
+	Notifier    Notifier
+	Outbox      Outbox
+	Events      Events
+	Retention   Retention
+	Partitions  Partitions
+	Cache       Cache
+	Webhook     Webhook
 }
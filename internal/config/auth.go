@@ -0,0 +1,19 @@
+package config
+
+import "time"
+
+type Auth struct {
+	// Exclude is the list of gRPC methods available without credentials
+	Exclude []string `env:"AUTH_EXCLUDE" envSeparator:"," envDefault:"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo,/grpc.reflection.v1.ServerReflection/ServerReflectionInfo"`
+
+	// JWT authentication is disabled if the secret is empty
+	JWTSecret   string `env:"AUTH_JWT_SECRET"`
+	JWTIssuer   string `env:"AUTH_JWT_ISSUER"`
+	JWTAudience string `env:"AUTH_JWT_AUDIENCE"`
+
+	// APIKeyCacheTTL is also the time the revoked key is accepted by replicas which have checked it
+	APIKeyCacheTTL time.Duration `env:"AUTH_API_KEY_CACHE_TTL" envDefault:"1m"`
+
+	// SubscriberIDEnabled keeps the deprecated authentication by the subscriber_id metadata
+	SubscriberIDEnabled bool `env:"AUTH_SUBSCRIBER_ID_ENABLED" envDefault:"true"`
+}
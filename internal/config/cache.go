@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+type Cache struct {
+	FiltersSize        int           `env:"CACHE_FILTERS_SIZE" envDefault:"1000"`
+	FiltersTTL         time.Duration `env:"CACHE_FILTERS_TTL" envDefault:"5m"`
+	FiltersNegativeTTL time.Duration `env:"CACHE_FILTERS_NEGATIVE_TTL" envDefault:"10s"`
+
+	SubscribersSize   int           `env:"CACHE_SUBSCRIBERS_SIZE" envDefault:"10000"`
+	SubscribersTTL    time.Duration `env:"CACHE_SUBSCRIBERS_TTL" envDefault:"1h"`
+	SubscriptionsSize int           `env:"CACHE_SUBSCRIPTIONS_SIZE" envDefault:"10000"`
+	SubscriptionsTTL  time.Duration `env:"CACHE_SUBSCRIPTIONS_TTL" envDefault:"1h"`
+
+	WarmUpEnabled   bool `env:"CACHE_WARM_UP_ENABLED" envDefault:"true"`
+	WarmUpChunkSize int  `env:"CACHE_WARM_UP_CHUNK_SIZE" envDefault:"1000"`
+
+	// Invalidations are delivered to other replicas by the NOTIFIER_DRIVER
+	InvalidationNatsSubject     string `env:"CACHE_INVALIDATION_NATS_SUBJECT" envDefault:"feed.internal.cache_invalidation"`
+	InvalidationPostgresChannel string `env:"CACHE_INVALIDATION_POSTGRES_CHANNEL" envDefault:"feed_cache_invalidation"`
+}
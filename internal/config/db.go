@@ -1,7 +1,15 @@
 package config
 
+const (
+	StorageDriverPostgres = "postgres"
+	StorageDriverMemory   = "memory"
+)
+
 type DB struct {
+	// StorageDriver is one of postgres or memory. The memory storage is lost on restart and is used for local runs and tests.
+	StorageDriver      string `env:"STORAGE_DRIVER" envDefault:"postgres"`
 	DSN                string `env:"POSTGRES_DSN" envDefault:"host=localhost port=5432 user=postgres password=DB_PASSWORD dbname=postgres sslmode=disable"`
 	MaxOpenConnections int    `env:"POSTGRES_MAX_OPEN_CONNECTIONS" envDefault:"30"`
 	Debug              bool   `env:"POSTGRES_DEBUG" envDefault:"false"`
+	AutoMigrate        bool   `env:"POSTGRES_AUTO_MIGRATE" envDefault:"false"`
 }
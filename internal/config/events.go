@@ -0,0 +1,9 @@
+package config
+
+import (
+	"time"
+)
+
+type Events struct {
+	DedupRetention time.Duration `env:"EVENTS_DEDUP_RETENTION" envDefault:"168h"`
+}
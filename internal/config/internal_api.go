@@ -1,5 +1,18 @@
 package config
 
+import "time"
+
 type InternalAPI struct {
 	Bind string `env:"INTERNAL_API_GRPC_SERVER_BIND" envDefault:":11000"`
+
+	// TLS is enabled if the certificate file is set, files are reloaded when they are changed
+	TLSCertFile       string        `env:"INTERNAL_API_TLS_CERT_FILE"`
+	TLSKeyFile        string        `env:"INTERNAL_API_TLS_KEY_FILE"`
+	TLSReloadInterval time.Duration `env:"INTERNAL_API_TLS_RELOAD_INTERVAL" envDefault:"1m"`
+
+	// TLSClientAuth is one of none, optional or require. The common name of the client certificate
+	// is the subscriber identifier or one of TLSAdminNames.
+	TLSClientAuth   string   `env:"INTERNAL_API_TLS_CLIENT_AUTH" envDefault:"none"`
+	TLSClientCAFile string   `env:"INTERNAL_API_TLS_CLIENT_CA_FILE"`
+	TLSAdminNames   []string `env:"INTERNAL_API_TLS_ADMIN_NAMES" envSeparator:","`
 }
@@ -0,0 +1,12 @@
+package config
+
+type Limits struct {
+	// RateLimit is the count of API calls per second of the subscriber, zero disables the limit.
+	// Calls without the subscriber are limited by the peer address.
+	RateLimit float64 `env:"LIMITS_RATE" envDefault:"0"`
+	RateBurst int     `env:"LIMITS_RATE_BURST" envDefault:"20"`
+	// MaxStreams is the count of concurrent feed events streams of the subscriber, zero disables the limit
+	MaxStreams int `env:"LIMITS_MAX_STREAMS" envDefault:"0"`
+	// MaxSubscriptions is the count of DAO and address subscriptions of the subscriber, zero disables the limit
+	MaxSubscriptions int `env:"LIMITS_MAX_SUBSCRIPTIONS" envDefault:"0"`
+}
@@ -0,0 +1,14 @@
+package config
+
+const (
+	NotifierDriverLocal    = "local"
+	NotifierDriverNats     = "nats"
+	NotifierDriverPostgres = "postgres"
+)
+
+type Notifier struct {
+	// Driver is one of local, nats or postgres. Use nats or postgres when running several replicas.
+	Driver          string `env:"NOTIFIER_DRIVER" envDefault:"local"`
+	NatsSubject     string `env:"NOTIFIER_NATS_SUBJECT" envDefault:"feed.internal.items_updated"`
+	PostgresChannel string `env:"NOTIFIER_POSTGRES_CHANNEL" envDefault:"feed_items_updated"`
+}
@@ -0,0 +1,13 @@
+package config
+
+import (
+	"time"
+)
+
+type Outbox struct {
+	RelayInterval time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	BatchSize     int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	Retention     time.Duration `env:"OUTBOX_RETENTION" envDefault:"24h"`
+	// MaxAttempts is the count of failed attempts after which the message is parked, zero means no limit
+	MaxAttempts int `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"20"`
+}
@@ -0,0 +1,10 @@
+package config
+
+import (
+	"time"
+)
+
+type Partitions struct {
+	MonthsAhead int           `env:"PARTITIONS_MONTHS_AHEAD" envDefault:"3"`
+	Interval    time.Duration `env:"PARTITIONS_INTERVAL" envDefault:"24h"`
+}
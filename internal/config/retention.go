@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	RetentionModeDelete     = "delete"
+	RetentionModeSoftDelete = "soft_delete"
+	RetentionModeArchive    = "archive"
+)
+
+type Retention struct {
+	Enabled   bool              `env:"RETENTION_ENABLED" envDefault:"false"`
+	Interval  time.Duration     `env:"RETENTION_INTERVAL" envDefault:"1h"`
+	BatchSize int               `env:"RETENTION_BATCH_SIZE" envDefault:"1000"`
+	DryRun    bool              `env:"RETENTION_DRY_RUN" envDefault:"false"`
+	Policies  []RetentionPolicy `env:"RETENTION_POLICIES" envSeparator:","`
+}
+
+// RetentionPolicy is parsed from the <type>:<max age>:<mode> string, e.g. proposal:17520h:archive
+type RetentionPolicy struct {
+	Type   string
+	MaxAge time.Duration
+	Mode   string
+}
+
+func (p *RetentionPolicy) UnmarshalText(text []byte) error {
+	parts := strings.Split(strings.TrimSpace(string(text)), ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid retention policy %q, expected <type>:<max age>:<mode>", text)
+	}
+
+	maxAge, err := time.ParseDuration(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid retention policy max age %q: %w", parts[1], err)
+	}
+
+	switch parts[2] {
+	case RetentionModeDelete, RetentionModeSoftDelete, RetentionModeArchive:
+	default:
+		return fmt.Errorf("invalid retention policy mode %q", parts[2])
+	}
+
+	*p = RetentionPolicy{
+		Type:   parts[0],
+		MaxAge: maxAge,
+		Mode:   parts[2],
+	}
+
+	return nil
+}
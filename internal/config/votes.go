@@ -0,0 +1,10 @@
+package config
+
+import (
+	"time"
+)
+
+type Votes struct {
+	Enabled   bool          `env:"VOTES_ENABLED" envDefault:"false"`
+	Retention time.Duration `env:"VOTES_RETENTION" envDefault:"720h"`
+}
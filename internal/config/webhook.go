@@ -0,0 +1,34 @@
+package config
+
+import "time"
+
+const (
+	WebhookDeliveryNats = "nats"
+	WebhookDeliveryHTTP = "http"
+)
+
+type Webhook struct {
+	RequireHTTPS bool `env:"WEBHOOK_REQUIRE_HTTPS" envDefault:"true"`
+	// AllowPrivate disables checks of webhook addresses, it's meant for local environments only
+	AllowPrivate bool `env:"WEBHOOK_ALLOW_PRIVATE" envDefault:"false"`
+	// VerificationEnabled requires webhooks to echo the challenge before they are registered
+	VerificationEnabled bool          `env:"WEBHOOK_VERIFICATION_ENABLED" envDefault:"false"`
+	VerificationTimeout time.Duration `env:"WEBHOOK_VERIFICATION_TIMEOUT" envDefault:"5s"`
+
+	// Delivery is nats for publishing callbacks to the callback subject or http for sending them to webhooks directly.
+	// Webhooks health is tracked and the circuit breaker is applied only by the http delivery.
+	Delivery        string        `env:"WEBHOOK_DELIVERY" envDefault:"nats"`
+	DeliveryTimeout time.Duration `env:"WEBHOOK_DELIVERY_TIMEOUT" envDefault:"10s"`
+	// DeliverySubject is the subject callbacks are enqueued to by the outbox relay for the http delivery
+	DeliverySubject string `env:"WEBHOOK_DELIVERY_SUBJECT" envDefault:"feed.webhook.delivery"`
+	// BreakerFailures is the count of consecutive failures pausing the webhook, zero disables the circuit breaker
+	BreakerFailures int           `env:"WEBHOOK_BREAKER_FAILURES" envDefault:"5"`
+	BreakerCooldown time.Duration `env:"WEBHOOK_BREAKER_COOLDOWN" envDefault:"5m"`
+	DisabledSubject string        `env:"WEBHOOK_DISABLED_SUBJECT" envDefault:"feed.webhook.disabled"`
+	// RetryMaxAttempts limits attempts of failed callbacks including the first one, the retry delay starts
+	// from RetryBackoff and is doubled after every attempt
+	RetryMaxAttempts int           `env:"WEBHOOK_RETRY_MAX_ATTEMPTS" envDefault:"5"`
+	RetryBackoff     time.Duration `env:"WEBHOOK_RETRY_BACKOFF" envDefault:"30s"`
+	// DeliveriesRetention is the period of keeping attempts of the http delivery in the deliveries log
+	DeliveriesRetention time.Duration `env:"WEBHOOK_DELIVERIES_RETENTION" envDefault:"168h"`
+}
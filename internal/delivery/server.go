@@ -0,0 +1,170 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
+	"github.com/goverland-labs/goverland-core-feed/pkg/grpcsrv"
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type Server struct {
+	feedpb.UnimplementedWebhookDeliveriesServer
+
+	service *Service
+}
+
+func NewServer(sp *Service) *Server {
+	return &Server{
+		service: sp,
+	}
+}
+
+func (s *Server) ListDeliveries(ctx context.Context, req *feedpb.ListDeliveriesRequest) (*feedpb.ListDeliveriesResponse, error) {
+	filter, err := convertFilter(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if !canAccess(ctx, filter.SubscriberID) {
+		return nil, status.Error(codes.PermissionDenied, "deliveries of another subscriber")
+	}
+
+	list, err := s.service.List(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Str("subscriber", req.GetSubscriberId()).Msg("list webhook deliveries")
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	res := &feedpb.ListDeliveriesResponse{
+		Deliveries: make([]*feedpb.Delivery, 0, len(list)),
+	}
+	for i := range list {
+		res.Deliveries = append(res.Deliveries, convertDeliveryToAPI(&list[i]))
+	}
+
+	return res, nil
+}
+
+func (s *Server) Redeliver(ctx context.Context, req *feedpb.RedeliverRequest) (*feedpb.RedeliverResponse, error) {
+	subscriberID, err := uuid.Parse(req.GetSubscriberId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscriber id")
+	}
+
+	itemID, err := uuid.Parse(req.GetFeedItemId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid feed item id")
+	}
+
+	if !canAccess(ctx, subscriberID) {
+		return nil, status.Error(codes.PermissionDenied, "deliveries of another subscriber")
+	}
+
+	count, err := s.service.Redeliver(ctx, subscriberID, itemID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "feed item not found")
+	}
+	if err != nil {
+		log.Error().Err(err).
+			Str("subscriber", req.GetSubscriberId()).
+			Str("feed_item", req.GetFeedItemId()).
+			Int("sent", count).
+			Msg("redeliver callbacks")
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	return &feedpb.RedeliverResponse{Callbacks: uint32(count)}, nil
+}
+
+// canAccess checks the caller reads own deliveries, only admins could access deliveries of any subscriber
+func canAccess(ctx context.Context, subscriberID uuid.UUID) bool {
+	identity, ok := grpcsrv.IdentityFromContext(ctx)
+	if !ok {
+		// the method is excluded from the authentication, so the caller is unknown
+		return false
+	}
+
+	return (subscriberID != uuid.Nil && identity.SubscriberID == subscriberID) || identity.HasScope(grpcsrv.ScopeAdmin)
+}
+
+func convertFilter(req *feedpb.ListDeliveriesRequest) (webhook.DeliveryFilter, error) {
+	filter := webhook.DeliveryFilter{
+		Status: webhook.DeliveryStatus(req.GetStatus()),
+		Limit:  defaultLimit,
+		Offset: int(req.GetOffset()),
+	}
+
+	if req.GetLimit() > 0 {
+		filter.Limit = min(int(req.GetLimit()), maxLimit)
+	}
+
+	switch filter.Status {
+	case "", webhook.DeliveryStatusSuccess, webhook.DeliveryStatusFailure, webhook.DeliveryStatusPaused:
+	default:
+		return filter, errors.New("unknown status")
+	}
+
+	ids := []struct {
+		value string
+		dest  *uuid.UUID
+		name  string
+	}{
+		{req.GetSubscriberId(), &filter.SubscriberID, "subscriber id"},
+		{req.GetDaoId(), &filter.DaoID, "dao id"},
+		{req.GetFeedItemId(), &filter.FeedItemID, "feed item id"},
+	}
+	for _, id := range ids {
+		if id.value == "" {
+			continue
+		}
+
+		parsed, err := uuid.Parse(id.value)
+		if err != nil {
+			return filter, errors.New("invalid " + id.name)
+		}
+
+		*id.dest = parsed
+	}
+
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	return filter, nil
+}
+
+func convertDeliveryToAPI(d *webhook.Delivery) *feedpb.Delivery {
+	return &feedpb.Delivery{
+		Id:           d.ID.String(),
+		CreatedAt:    timestamppb.New(d.CreatedAt),
+		SubscriberId: d.SubscriberID.String(),
+		DaoId:        d.DaoID.String(),
+		FeedItemId:   d.FeedItemID.String(),
+		Action:       d.Action,
+		Url:          d.URL,
+		Status:       string(d.Status),
+		HttpStatus:   int32(d.HTTPStatus),
+		LatencyMs:    d.LatencyMs,
+		Response:     d.Response,
+		Redelivery:   d.Redelivery,
+	}
+}
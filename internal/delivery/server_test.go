@@ -0,0 +1,146 @@
+package delivery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
+	"github.com/goverland-labs/goverland-core-feed/pkg/grpcsrv"
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+type staticCallbacks map[uuid.UUID][]webhook.Callback
+
+func (c staticCallbacks) GetCallbacks(_ context.Context, subscriberID, itemID uuid.UUID) ([]webhook.Callback, error) {
+	list, ok := c[itemID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	res := make([]webhook.Callback, 0, len(list))
+	for _, cb := range list {
+		cb.SubscriberID = subscriberID
+		cb.Redelivery = true
+		res = append(res, cb)
+	}
+
+	return res, nil
+}
+
+type recordingPublisher struct {
+	subjects  []string
+	callbacks []webhook.Callback
+}
+
+func (p *recordingPublisher) PublishJSON(_ context.Context, subject string, obj any) error {
+	p.subjects = append(p.subjects, subject)
+	p.callbacks = append(p.callbacks, obj.(webhook.Callback))
+
+	return nil
+}
+
+func withSubscriber(id uuid.UUID) context.Context {
+	return grpcsrv.WithIdentity(context.Background(), &grpcsrv.Identity{
+		SubscriberID: id,
+		Scopes:       []grpcsrv.Scope{grpcsrv.ScopeReadFeed},
+	})
+}
+
+func TestUnitServerListDeliveries(t *testing.T) {
+	var (
+		repo     = webhook.NewMemoryRepo()
+		own      = uuid.New()
+		another  = uuid.New()
+		now      = time.Now()
+		srv      = NewServer(NewService(repo, staticCallbacks{}, &recordingPublisher{}))
+		admin    = grpcsrv.WithIdentity(context.Background(), &grpcsrv.Identity{Scopes: []grpcsrv.Scope{grpcsrv.ScopeAdmin}})
+		ownCtx   = withSubscriber(own)
+		failures = 0
+	)
+
+	for i := 0; i < 5; i++ {
+		st := webhook.DeliveryStatusSuccess
+		if i%2 == 0 {
+			st = webhook.DeliveryStatusFailure
+			failures++
+		}
+
+		require.NoError(t, repo.CreateDelivery(&webhook.Delivery{ID: uuid.New(), CreatedAt: now.Add(-time.Duration(i) * time.Minute), SubscriberID: own, Status: st}))
+	}
+	require.NoError(t, repo.CreateDelivery(&webhook.Delivery{ID: uuid.New(), CreatedAt: now, SubscriberID: another, Status: webhook.DeliveryStatusSuccess}))
+
+	resp, err := srv.ListDeliveries(ownCtx, &feedpb.ListDeliveriesRequest{SubscriberId: own.String(), Status: "failure"})
+	require.NoError(t, err)
+	require.Len(t, resp.Deliveries, failures)
+	require.Equal(t, own.String(), resp.Deliveries[0].SubscriberId)
+
+	resp, err = srv.ListDeliveries(ownCtx, &feedpb.ListDeliveriesRequest{
+		SubscriberId: own.String(),
+		From:         timestamppb.New(now.Add(-150 * time.Second)),
+		Limit:        2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Deliveries, 2)
+
+	_, err = srv.ListDeliveries(ownCtx, &feedpb.ListDeliveriesRequest{SubscriberId: another.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// the method excluded from the authentication doesn't expose deliveries
+	_, err = srv.ListDeliveries(context.Background(), &feedpb.ListDeliveriesRequest{SubscriberId: own.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// only admins list deliveries of all subscribers
+	_, err = srv.ListDeliveries(ownCtx, &feedpb.ListDeliveriesRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err = srv.ListDeliveries(admin, &feedpb.ListDeliveriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Deliveries, 6)
+
+	_, err = srv.ListDeliveries(admin, &feedpb.ListDeliveriesRequest{Status: "unknown"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = srv.ListDeliveries(admin, &feedpb.ListDeliveriesRequest{DaoId: "dao"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUnitServerRedeliver(t *testing.T) {
+	var (
+		own       = uuid.New()
+		itemID    = uuid.New()
+		publisher = &recordingPublisher{}
+		callbacks = staticCallbacks{
+			itemID: {
+				{CallbackPayload: core.CallbackPayload{WebhookURL: "https://a.com"}, FeedItemID: itemID},
+				{CallbackPayload: core.CallbackPayload{WebhookURL: "https://b.com"}, FeedItemID: itemID},
+			},
+		}
+		srv = NewServer(NewService(webhook.NewMemoryRepo(), callbacks, publisher))
+		ctx = withSubscriber(own)
+	)
+
+	resp, err := srv.Redeliver(ctx, &feedpb.RedeliverRequest{SubscriberId: own.String(), FeedItemId: itemID.String()})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, resp.Callbacks)
+	require.Equal(t, []string{core.SubjectCallback, core.SubjectCallback}, publisher.subjects)
+	require.Equal(t, own, publisher.callbacks[0].SubscriberID)
+	require.True(t, publisher.callbacks[1].Redelivery)
+
+	_, err = srv.Redeliver(ctx, &feedpb.RedeliverRequest{SubscriberId: own.String(), FeedItemId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = srv.Redeliver(ctx, &feedpb.RedeliverRequest{SubscriberId: uuid.NewString(), FeedItemId: itemID.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = srv.Redeliver(ctx, &feedpb.RedeliverRequest{SubscriberId: own.String()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,61 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
+)
+
+type CallbacksProvider interface {
+	GetCallbacks(ctx context.Context, subscriberID, itemID uuid.UUID) ([]webhook.Callback, error)
+}
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+// Service reads the webhook deliveries log and redelivers callbacks.
+// Redelivered callbacks are sent by the callback publisher directly, bypassing the outbox.
+type Service struct {
+	repo      webhook.DeliveryProvider
+	callbacks CallbacksProvider
+	publisher Publisher
+}
+
+func NewService(repo webhook.DeliveryProvider, callbacks CallbacksProvider, publisher Publisher) *Service {
+	return &Service{
+		repo:      repo,
+		callbacks: callbacks,
+		publisher: publisher,
+	}
+}
+
+func (s *Service) List(_ context.Context, filter webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	list, err := s.repo.GetDeliveries(filter)
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+
+	return list, nil
+}
+
+// Redeliver sends the current payload of the feed item to webhooks of the subscriber routing it
+// and returns the count of sent callbacks
+func (s *Service) Redeliver(ctx context.Context, subscriberID, itemID uuid.UUID) (int, error) {
+	callbacks, err := s.callbacks.GetCallbacks(ctx, subscriberID, itemID)
+	if err != nil {
+		return 0, fmt.Errorf("get callbacks: %w", err)
+	}
+
+	for i := range callbacks {
+		if err := s.publisher.PublishJSON(ctx, core.SubjectCallback, callbacks[i]); err != nil {
+			return i, fmt.Errorf("publish callback to %s: %w", callbacks[i].WebhookURL, err)
+		}
+	}
+
+	return len(callbacks), nil
+}
@@ -14,15 +14,19 @@ import (
 )
 
 var typesMapping = map[item.Type]feedpb.FeedItemType{
-	item.TypeDao:      feedpb.FeedItemType_FEED_ITEM_TYPE_DAO,
-	item.TypeProposal: feedpb.FeedItemType_FEED_ITEM_TYPE_PROPOSAL,
-	item.TypeDelegate: feedpb.FeedItemType_FEED_ITEM_TYPE_DELEGATE,
+	item.TypeDao:        feedpb.FeedItemType_FEED_ITEM_TYPE_DAO,
+	item.TypeProposal:   feedpb.FeedItemType_FEED_ITEM_TYPE_PROPOSAL,
+	item.TypeDelegate:   feedpb.FeedItemType_FEED_ITEM_TYPE_DELEGATE,
+	item.TypeDiscussion: feedpb.FeedItemType_FEED_ITEM_TYPE_DISCUSSION,
+	item.TypeVote:       feedpb.FeedItemType_FEED_ITEM_TYPE_VOTE,
 }
 
 var snapshotConverters = map[item.Type]func(item.FeedItem) (any, error){
-	item.TypeDao:      daoSnapshotConverter,
-	item.TypeProposal: proposalSnapshotConverter,
-	item.TypeDelegate: delegateSnapshotConverter,
+	item.TypeDao:        daoSnapshotConverter,
+	item.TypeProposal:   proposalSnapshotConverter,
+	item.TypeDelegate:   delegateSnapshotConverter,
+	item.TypeDiscussion: discussionSnapshotConverter,
+	item.TypeVote:       voteSnapshotConverter,
 }
 
 func convertToFeedItem(fItem item.FeedItem) (*feedpb.FeedItem, error) {
@@ -55,6 +59,10 @@ func convertToFeedItem(fItem item.FeedItem) (*feedpb.FeedItem, error) {
 		feedItem.Snapshot = v
 	case *feedpb.FeedItem_Delegate:
 		feedItem.Snapshot = v
+	case *feedpb.FeedItem_Discussion:
+		feedItem.Snapshot = v
+	case *feedpb.FeedItem_Vote:
+		feedItem.Snapshot = v
 	}
 
 	return feedItem, nil
@@ -151,3 +159,54 @@ func delegateSnapshotConverter(fItem item.FeedItem) (any, error) {
 		},
 	}, nil
 }
+
+func discussionSnapshotConverter(fItem item.FeedItem) (any, error) {
+	var dPayload item.DiscussionPayload
+	err := json.Unmarshal(fItem.Snapshot, &dPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeline []*feedpb.Timeline
+	for _, tItem := range fItem.Timeline {
+		timeline = append(timeline, &feedpb.Timeline{
+			Action:    string(tItem.Action),
+			CreatedAt: timestamppb.New(tItem.CreatedAt),
+		})
+	}
+
+	return &feedpb.FeedItem_Discussion{
+		Discussion: &feedpb.Discussion{
+			CreatedAt:     timestamppb.New(dPayload.CreatedAt),
+			Id:            dPayload.ID,
+			DaoInternalId: dPayload.DaoID.String(),
+			ProposalId:    fItem.ProposalID,
+			Title:         dPayload.Title,
+			Author:        dPayload.Author,
+			Url:           dPayload.URL,
+			RepliesCount:  dPayload.RepliesCount,
+			Timeline:      timeline,
+		},
+	}, nil
+}
+
+func voteSnapshotConverter(fItem item.FeedItem) (any, error) {
+	var dPayload item.VotePayload
+	err := json.Unmarshal(fItem.Snapshot, &dPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &feedpb.FeedItem_Vote{
+		Vote: &feedpb.Vote{
+			CreatedAt:     timestamppb.New(time.Unix(int64(dPayload.Created), 0)),
+			Id:            dPayload.ID,
+			DaoInternalId: dPayload.DaoID.String(),
+			ProposalId:    dPayload.ProposalID,
+			Voter:         fItem.Voter,
+			Choice:        string(dPayload.Choice),
+			Reason:        dPayload.Reason,
+			VotingPower:   dPayload.Vp,
+		},
+	}, nil
+}
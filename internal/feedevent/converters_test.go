@@ -0,0 +1,46 @@
+package feedevent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/item"
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+func TestUnitConvertDelegateFeedItem(t *testing.T) {
+	daoID := uuid.New()
+	snapshot, err := json.Marshal(pevents.DelegatePayload{
+		Initiator:  "0x1",
+		Delegator:  "0x2",
+		DaoID:      daoID,
+		ProposalID: "proposal-id",
+	})
+	require.NoError(t, err)
+
+	for _, action := range []item.TimelineAction{
+		item.DelegateCreateProposal,
+		item.DelegateVotingVoted,
+		item.DelegateVotingSkipVote,
+		item.DelegateCreated,
+		item.DelegateDelegationExpired,
+		item.DelegateDelegationExpiringSoon,
+	} {
+		t.Run(string(action), func(t *testing.T) {
+			converted, err := convertToFeedItem(item.FeedItem{
+				DaoID:    daoID,
+				Type:     item.TypeDelegate,
+				Action:   action,
+				Snapshot: snapshot,
+			})
+			require.NoError(t, err)
+			require.Equal(t, feedpb.FeedItemType_FEED_ITEM_TYPE_DELEGATE, converted.GetType())
+			require.Equal(t, string(action), converted.GetDelegate().GetAction())
+			require.Equal(t, daoID.String(), converted.GetDelegate().GetDaoInternalId())
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package feedevent
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
@@ -8,13 +10,16 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/goverland-labs/goverland-core-feed/internal/item"
+	"github.com/goverland-labs/goverland-core-feed/pkg/grpcsrv"
 	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
 )
 
 var subscriptionTypesMapping = map[feedpb.FeedItemType]item.Type{
-	feedpb.FeedItemType_FEED_ITEM_TYPE_DAO:      item.TypeDao,
-	feedpb.FeedItemType_FEED_ITEM_TYPE_PROPOSAL: item.TypeProposal,
-	feedpb.FeedItemType_FEED_ITEM_TYPE_DELEGATE: item.TypeDelegate,
+	feedpb.FeedItemType_FEED_ITEM_TYPE_DAO:        item.TypeDao,
+	feedpb.FeedItemType_FEED_ITEM_TYPE_PROPOSAL:   item.TypeProposal,
+	feedpb.FeedItemType_FEED_ITEM_TYPE_DELEGATE:   item.TypeDelegate,
+	feedpb.FeedItemType_FEED_ITEM_TYPE_DISCUSSION: item.TypeDiscussion,
+	feedpb.FeedItemType_FEED_ITEM_TYPE_VOTE:       item.TypeVote,
 }
 
 type Server struct {
@@ -43,6 +48,10 @@ func (s *Server) EventsSubscribe(req *feedpb.EventsSubscribeRequest, stream grpc
 		return status.Error(codes.InvalidArgument, "invalid subscriber id")
 	}
 
+	if !canWatch(ctx, subscriberID) {
+		return status.Error(codes.PermissionDenied, "events of another subscriber")
+	}
+
 	var fTypes []item.Type
 	for _, t := range req.GetSubscriptionTypes() {
 		fType, ok := subscriptionTypesMapping[t]
@@ -80,3 +89,14 @@ func (s *Server) EventsSubscribe(req *feedpb.EventsSubscribeRequest, stream grpc
 
 	return nil
 }
+
+// canWatch checks the caller watches own events, only admins could watch events of any subscriber
+func canWatch(ctx context.Context, subscriberID uuid.UUID) bool {
+	identity, ok := grpcsrv.IdentityFromContext(ctx)
+	if !ok {
+		// the method is excluded from the authentication, so the caller is unknown
+		return false
+	}
+
+	return identity.SubscriberID == subscriberID || identity.HasScope(grpcsrv.ScopeAdmin)
+}
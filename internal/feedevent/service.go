@@ -14,6 +14,8 @@ import (
 
 const (
 	forcedFetchTime = 1 * time.Minute
+	// coalesceWindow groups bursts of notifications into the one fetch
+	coalesceWindow = 100 * time.Millisecond
 
 	feedItemsLimit = 1000
 )
@@ -22,22 +24,43 @@ type FeedItemsProvider interface {
 	GetLastItems(subscriberID string, fTypes []item.Type, lastUpdatedAt time.Time, limit int) ([]item.FeedItem, error)
 }
 
+// Notifier notifies about new feed items saved by any replica
+type Notifier interface {
+	Subscribe(topics ...string) chan string
+	UpdateTopics(ch chan string, topics ...string)
+	Unsubscribe(ch chan string)
+}
+
+type TopicsProvider interface {
+	GetSubscriberTopics(ctx context.Context, subscriberID uuid.UUID) ([]string, error)
+}
+
 type Service struct {
-	notifier *pubsub.PubSub[string]
+	notifier Notifier
 
 	feedItemsProvider FeedItemsProvider
+	topicsProvider    TopicsProvider
 }
 
-func NewService(notifier *pubsub.PubSub[string], feedItemsProvider FeedItemsProvider) *Service {
-	return &Service{notifier: notifier, feedItemsProvider: feedItemsProvider}
+func NewService(notifier Notifier, feedItemsProvider FeedItemsProvider, topicsProvider TopicsProvider) *Service {
+	return &Service{notifier: notifier, feedItemsProvider: feedItemsProvider, topicsProvider: topicsProvider}
 }
 
 func (s *Service) Watch(ctx context.Context, subscriberID uuid.UUID, fTypes []item.Type, lastUpdatedAt time.Time, handler func(entity item.FeedItem) error) error {
-	notificationsCh := s.notifier.Subscribe()
+	topics, err := s.topicsProvider.GetSubscriberTopics(ctx, subscriberID)
+	if err != nil {
+		return fmt.Errorf("fail to fetch subscriber topics: %v", err)
+	}
+
+	notificationsCh := s.notifier.Subscribe(topics...)
 	defer func() {
 		s.notifier.Unsubscribe(notificationsCh)
 	}()
 
+	ticker := time.NewTicker(forcedFetchTime)
+	defer ticker.Stop()
+
+	subscriberTopic := pubsub.SubscriberTopic(subscriberID)
 	for {
 		feedItems, err := s.feedItemsProvider.GetLastItems(subscriberID.String(), fTypes, lastUpdatedAt, feedItemsLimit)
 		if err != nil {
@@ -71,8 +94,45 @@ func (s *Service) Watch(ctx context.Context, subscriberID uuid.UUID, fTypes []it
 			log.Info().Msg("ctx is done, finished subscription")
 			return nil
 
-		case <-notificationsCh:
-		case <-time.After(forcedFetchTime):
+		case msg := <-notificationsCh:
+			// subscriptions are changed or notifications might be lost by the broadcaster
+			changed := coalesce(ctx, notificationsCh, subscriberTopic)
+			if msg == subscriberTopic || msg == "" || changed {
+				s.refreshTopics(ctx, subscriberID, notificationsCh)
+			}
+		case <-ticker.C:
+			// subscriptions could be changed on the replica without the notification
+			s.refreshTopics(ctx, subscriberID, notificationsCh)
+		}
+	}
+}
+
+func (s *Service) refreshTopics(ctx context.Context, subscriberID uuid.UUID, ch chan string) {
+	topics, err := s.topicsProvider.GetSubscriberTopics(ctx, subscriberID)
+	if err != nil {
+		log.Error().Err(err).Str("subscriber", subscriberID.String()).Msg("refresh subscriber topics")
+
+		return
+	}
+
+	s.notifier.UpdateTopics(ch, topics...)
+}
+
+// coalesce drains notifications received during the coalesce window,
+// it reports whether the personal topic or the empty message was among them
+func coalesce(ctx context.Context, ch <-chan string, subscriberTopic string) bool {
+	timer := time.NewTimer(coalesceWindow)
+	defer timer.Stop()
+
+	changed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return changed
+		case <-timer.C:
+			return changed
+		case msg := <-ch:
+			changed = changed || msg == subscriberTopic || msg == ""
 		}
 	}
 }
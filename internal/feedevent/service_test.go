@@ -0,0 +1,218 @@
+package feedevent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/item"
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+)
+
+type countingProvider struct {
+	queries atomic.Int64
+}
+
+func (p *countingProvider) GetLastItems(_ string, _ []item.Type, _ time.Time, _ int) ([]item.FeedItem, error) {
+	p.queries.Add(1)
+
+	return nil, nil
+}
+
+type staticTopics map[uuid.UUID][]string
+
+func (t staticTopics) GetSubscriberTopics(_ context.Context, id uuid.UUID) ([]string, error) {
+	return t[id], nil
+}
+
+// startWatchers runs watchers for all subscribers and waits for their initial fetch
+func startWatchers(t testing.TB, s *Service, provider *countingProvider, subscribers []uuid.UUID) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	wg := sync.WaitGroup{}
+	for _, id := range subscribers {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			_ = s.Watch(ctx, id, nil, time.Now(), func(_ item.FeedItem) error {
+				return nil
+			})
+		}(id)
+	}
+
+	require.Eventually(t, func() bool {
+		return provider.queries.Load() == int64(len(subscribers))
+	}, 10*time.Second, time.Millisecond)
+	provider.queries.Store(0)
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func TestUnitWatchWakesUpOnlyForSubscribedTopics(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	defer zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	daoA, daoB := uuid.New(), uuid.New()
+	subscriberID := uuid.New()
+
+	notifier := pubsub.NewPubSub[string](1)
+	provider := &countingProvider{}
+	s := NewService(notifier, provider, staticTopics{
+		subscriberID: {pubsub.SubscriberTopic(subscriberID), pubsub.DaoTopic(daoA)},
+	})
+
+	stop := startWatchers(t, s, provider, []uuid.UUID{subscriberID})
+	defer stop()
+
+	notifier.PublishNoWait(pubsub.DaoTopic(daoB))
+	time.Sleep(2 * coalesceWindow)
+	require.EqualValues(t, 0, provider.queries.Load())
+
+	// burst of notifications is coalesced into the one fetch
+	for i := 0; i < 10; i++ {
+		notifier.PublishNoWait(pubsub.DaoTopic(daoA))
+	}
+	require.Eventually(t, func() bool {
+		return provider.queries.Load() == 1
+	}, time.Second, time.Millisecond)
+	time.Sleep(2 * coalesceWindow)
+	require.EqualValues(t, 1, provider.queries.Load())
+}
+
+type nopInvalidator struct{}
+
+func (nopInvalidator) Publish(string, string) {}
+
+// daoItemsProvider returns stored items of all DAOs, the topics decide which notifications wake up the stream
+type daoItemsProvider struct {
+	mu    sync.Mutex
+	items []item.FeedItem
+}
+
+func (p *daoItemsProvider) add(fi item.FeedItem) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.items = append(p.items, fi)
+}
+
+func (p *daoItemsProvider) GetLastItems(_ string, _ []item.Type, lastUpdatedAt time.Time, _ int) ([]item.FeedItem, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var list []item.FeedItem
+	for _, fi := range p.items {
+		if fi.UpdatedAt.After(lastUpdatedAt) {
+			list = append(list, fi)
+		}
+	}
+
+	return list, nil
+}
+
+func TestUnitWatchReceivesItemsOfFollowedDao(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	defer zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	daoID := uuid.New()
+	subscriberID := uuid.New()
+
+	notifier := pubsub.NewPubSub[string](10)
+	subscriptions, err := subscription.NewService(subscription.NewMemoryRepo(), subscription.NewCache(), nopInvalidator{}, notifier, 0)
+	require.NoError(t, err)
+
+	provider := &daoItemsProvider{}
+	s := NewService(notifier, provider, subscriptions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan item.FeedItem, 10)
+	done := make(chan error)
+	go func() {
+		done <- s.Watch(ctx, subscriberID, nil, time.Now(), func(fi item.FeedItem) error {
+			received <- fi
+
+			return nil
+		})
+	}()
+
+	// the stream waits for notifications of the personal topic only
+	time.Sleep(2 * coalesceWindow)
+	_, err = subscriptions.Subscribe(ctx, subscription.Subscription{SubscriberID: subscriberID, DaoID: daoID})
+	require.NoError(t, err)
+	time.Sleep(2 * coalesceWindow)
+
+	provider.add(item.FeedItem{ID: uuid.New(), DaoID: daoID, UpdatedAt: time.Now()})
+	notifier.PublishNoWait(pubsub.DaoTopic(daoID))
+
+	select {
+	case fi := <-received:
+		require.Equal(t, daoID, fi.DaoID)
+	case <-time.After(time.Second):
+		t.Fatal("the item of the followed DAO is not received")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// BenchmarkWatchQueries reports how many queries are made by thousands of streams
+// for bursts of updates with and without topic-aware subscriptions.
+func BenchmarkWatchQueries(b *testing.B) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	defer zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	const (
+		streams = 2000
+		daos    = 200
+		bursts  = 10
+	)
+
+	for _, withTopics := range []bool{false, true} {
+		b.Run(fmt.Sprintf("topics=%t", withTopics), func(b *testing.B) {
+			var total int64
+			for i := 0; i < b.N; i++ {
+				daoIDs := make([]uuid.UUID, daos)
+				for j := range daoIDs {
+					daoIDs[j] = uuid.New()
+				}
+
+				topics := make(staticTopics, streams)
+				subscribers := make([]uuid.UUID, streams)
+				for j := range subscribers {
+					subscribers[j] = uuid.New()
+					if withTopics {
+						topics[subscribers[j]] = []string{pubsub.DaoTopic(daoIDs[j%daos])}
+					}
+				}
+
+				notifier := pubsub.NewPubSub[string](1)
+				provider := &countingProvider{}
+				s := NewService(notifier, provider, topics)
+				stop := startWatchers(b, s, provider, subscribers)
+
+				for j := 0; j < bursts; j++ {
+					notifier.PublishNoWait(pubsub.DaoTopic(daoIDs[j%daos]))
+				}
+				time.Sleep(3 * coalesceWindow)
+
+				total += provider.queries.Load()
+				stop()
+			}
+
+			b.ReportMetric(float64(total)/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package item
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
+)
+
+type staticSubscriptions []uuid.UUID
+
+func (s staticSubscriptions) GetSubscribers(_ context.Context, _ uuid.UUID) ([]uuid.UUID, error) {
+	return s, nil
+}
+
+func (s staticSubscriptions) GetAddressFollowers(_ context.Context, _ string) ([]uuid.UUID, error) {
+	return s, nil
+}
+
+type failingSubscriptions struct{}
+
+func (failingSubscriptions) GetSubscribers(_ context.Context, _ uuid.UUID) ([]uuid.UUID, error) {
+	return nil, errors.New("subscriptions are unavailable")
+}
+
+func (failingSubscriptions) GetAddressFollowers(_ context.Context, _ string) ([]uuid.UUID, error) {
+	return nil, errors.New("subscriptions are unavailable")
+}
+
+type blockedWebhooks map[string]struct{}
+
+func (b blockedWebhooks) ValidateAddress(_ context.Context, url string) error {
+	if _, ok := b[url]; ok {
+		return errors.New("blocked")
+	}
+
+	return nil
+}
+
+type nopInvalidator struct{}
+
+func (nopInvalidator) Publish(_, _ string) {}
+
+func TestUnitPrepareUpdatesRoutesCallbacks(t *testing.T) {
+	ctx := context.Background()
+	repo := subscriber.NewMemoryRepo()
+
+	subs, err := subscriber.NewService(repo, subscriber.NewCache(), nopInvalidator{})
+	require.NoError(t, err)
+	endpoints := subscriber.NewWebhookEndpoints(repo, subscriber.NewEndpointsCache(cache.Options{}), nopInvalidator{})
+
+	legacy, err := subs.Create(ctx, "https://example.com/legacy")
+	require.NoError(t, err)
+	routed, err := subs.Create(ctx, "")
+	require.NoError(t, err)
+	require.NoError(t, subs.UpdateBatching(ctx, routed.ID, 10, time.Second))
+
+	daoID := uuid.New()
+	for _, e := range []subscriber.WebhookEndpoint{
+		{URL: "https://example.com/proposals", Enabled: true, Types: []string{"proposal"}, Format: subscriber.WebhookFormatCompact},
+		{URL: "https://example.com/delegates", Enabled: true, Types: []string{"delegate"}},
+		{URL: "https://example.com/dao", Enabled: true, Actions: []string{"proposal.created"}, DaoIDs: []uuid.UUID{daoID}},
+		{URL: "https://example.com/disabled", Enabled: false},
+		{URL: "https://example.com/blocked", Enabled: true},
+	} {
+		e.SubscriberID = routed.ID
+		_, err := endpoints.Create(ctx, e)
+		require.NoError(t, err)
+	}
+
+	itemsRepo := newMemoryRepo()
+	s, err := NewService(itemsRepo, subs, endpoints, staticSubscriptions{legacy.ID, routed.ID},
+		blockedWebhooks{"https://example.com/blocked": {}}, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	item := &FeedItem{
+		ID:         uuid.New(),
+		DaoID:      daoID,
+		ProposalID: "proposal",
+		Type:       TypeProposal,
+		Snapshot:   json.RawMessage(`{"title":"proposal"}`),
+	}
+	item.Timeline.AddUniqueAction(time.Now(), ProposalCreated)
+
+	bodies := make(map[string]map[string]any)
+	callbacks := make(map[string]webhook.Callback)
+	messages, err := s.prepareUpdates(ctx, item)
+	require.NoError(t, err)
+
+	for _, msg := range messages {
+		if msg.Subject != pevents.SubjectCallback {
+			continue
+		}
+
+		var payload webhook.Callback
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		callbacks[payload.WebhookURL] = payload
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(payload.Body, &body))
+		bodies[payload.WebhookURL] = body
+	}
+
+	require.Len(t, bodies, 3)
+	require.Contains(t, bodies, "https://example.com/legacy")
+	require.Contains(t, bodies, "https://example.com/proposals")
+	require.Contains(t, bodies, "https://example.com/dao")
+
+	require.NotNil(t, bodies["https://example.com/legacy"]["snapshot"])
+	require.Nil(t, bodies["https://example.com/proposals"]["snapshot"])
+	require.Equal(t, item.ID.String(), bodies["https://example.com/proposals"]["id"])
+
+	// callbacks contain identifiers for the deliveries log
+	require.Equal(t, legacy.ID, callbacks["https://example.com/legacy"].SubscriberID)
+	require.Equal(t, routed.ID, callbacks["https://example.com/dao"].SubscriberID)
+	require.Equal(t, item.ID, callbacks["https://example.com/dao"].FeedItemID)
+	require.Equal(t, daoID, callbacks["https://example.com/dao"].DaoID)
+	require.Equal(t, "proposal.created", callbacks["https://example.com/dao"].Action)
+	require.False(t, callbacks["https://example.com/dao"].Redelivery)
+
+	// batching options of the subscriber are applied to all its webhooks
+	require.Equal(t, 10, callbacks["https://example.com/dao"].BatchMaxSize)
+	require.Equal(t, time.Second, callbacks["https://example.com/proposals"].BatchMaxWait)
+	require.Zero(t, callbacks["https://example.com/legacy"].BatchMaxSize)
+
+	// the redelivery is routed to endpoints of the requested subscriber only
+	require.NoError(t, itemsRepo.Save(item, ""))
+
+	redelivered, err := s.GetCallbacks(ctx, routed.ID, item.ID)
+	require.NoError(t, err)
+	require.Len(t, redelivered, 2)
+	for _, cb := range redelivered {
+		require.Equal(t, routed.ID, cb.SubscriberID)
+		require.True(t, cb.Redelivery)
+	}
+
+	_, err = s.GetCallbacks(ctx, routed.ID, uuid.New())
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestUnitHandleEventIsNotSavedWithoutUpdates(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepo()
+
+	s, err := NewService(repo, noSubscribers{}, noEndpoints{}, failingSubscriptions{}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	item := &FeedItem{
+		DaoID:      uuid.New(),
+		ProposalID: "proposal",
+		Type:       TypeProposal,
+		Snapshot:   json.RawMessage(`{"title":"proposal"}`),
+	}
+	item.Timeline.AddUniqueAction(time.Now(), ProposalCreated)
+
+	// the event is redelivered, so it's neither saved nor recorded as processed
+	require.Error(t, s.HandleEvent(ctx, "proposal.created:proposal", item, true))
+
+	_, err = repo.GetProposalItem("proposal")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	processed, err := repo.IsEventProcessed("proposal.created:proposal")
+	require.NoError(t, err)
+	require.False(t, processed)
+}
@@ -21,6 +21,8 @@ const (
 	daoExecutionTtl       = time.Minute
 )
 
+var daoSubjects = []string{pevents.SubjectDaoCreated, pevents.SubjectDaoUpdated}
+
 type DaoConsumer struct {
 	conn      *nats.Conn
 	service   *Service
@@ -46,6 +48,16 @@ func (c *DaoConsumer) handler(action string) pevents.DaoHandler {
 				Observe(time.Since(start).Seconds())
 		}(time.Now())
 
+		key, processed, err := c.service.checkEvent(action, payload)
+		if err != nil {
+			return err
+		}
+
+		if processed {
+			skipEvent(TypeDao, skipReasonDuplicate)
+			return nil
+		}
+
 		item, err := c.service.GetDaoItem(context.TODO(), payload.ID)
 		if err != nil {
 			return err
@@ -72,11 +84,26 @@ func (c *DaoConsumer) handler(action string) pevents.DaoHandler {
 				return err
 			}
 		} else {
+			sn, err := json.Marshal(payload)
+			if err != nil {
+				return fmt.Errorf("cant marshal payload: %w", err)
+			}
+
+			// the late update must not overwrite the newer snapshot and add the timeline entry
+			if isStaleSnapshot(item.Snapshot, sn) {
+				if action == pevents.SubjectDaoUpdated {
+					skipEvent(TypeDao, skipReasonStale)
+					return nil
+				}
+			} else {
+				item.Snapshot = sn
+			}
+
 			item.Timeline = timeline
 		}
 
 		// todo: enable when we will be ready to handle proposal feed
-		err = c.service.HandleItem(context.TODO(), item, false)
+		err = c.service.HandleEvent(context.TODO(), key, item, false)
 		if err != nil {
 			log.Error().Str("dao_id", payload.ID.String()).Err(err).Msg("process dao")
 			return err
@@ -112,7 +139,7 @@ func (c *DaoConsumer) Start(ctx context.Context) error {
 		client.WithAckWait(daoExecutionTtl),
 	}
 
-	for _, subj := range []string{pevents.SubjectDaoCreated, pevents.SubjectDaoUpdated} {
+	for _, subj := range daoSubjects {
 		consumer, err := client.NewConsumer(ctx, c.conn, group, subj, c.handler(subj), opts...)
 		if err != nil {
 			return fmt.Errorf("consume for %s/%s: %w", group, subj, err)
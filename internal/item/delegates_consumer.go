@@ -38,12 +38,23 @@ func NewDelegatesConsumer(nc *nats.Conn, s *Service) (*DelegatesConsumer, error)
 
 func (c *DelegatesConsumer) handler(action string) pevents.DelegatesHandler {
 	return func(payload pevents.DelegatePayload) error {
+		// delegate items have no unique key, so redelivered events would be stored twice
+		key, processed, err := c.service.checkEvent(action, payload)
+		if err != nil {
+			return err
+		}
+
+		if processed {
+			skipEvent(TypeDelegate, skipReasonDuplicate)
+			return nil
+		}
+
 		item, err := c.convertToFeedItem(convertToTimeLineAction(action), payload)
 		if err != nil {
 			return err
 		}
 
-		if err = c.service.HandleItem(context.TODO(), item, true); err != nil {
+		if err = c.service.HandleEvent(context.TODO(), key, item, true); err != nil {
 			log.Error().
 				Str("dao_id", payload.DaoID.String()).
 				Err(err).
@@ -58,23 +69,23 @@ func (c *DelegatesConsumer) handler(action string) pevents.DelegatesHandler {
 	}
 }
 
+// delegateEvents is map subject:string => timeline action
+var delegateEvents = map[string]TimelineAction{
+	pevents.SubjectDelegateCreateProposal:         DelegateCreateProposal,
+	pevents.SubjectDelegateVotingVoted:            DelegateVotingVoted,
+	pevents.SubjectDelegateVotingSkipVote:         DelegateVotingSkipVote,
+	pevents.SubjectDelegateCreated:                DelegateCreated,
+	pevents.SubjectDelegateDelegationExpired:      DelegateDelegationExpired,
+	pevents.SubjectDelegateDelegationExpiringSoon: DelegateDelegationExpiringSoon,
+}
+
 func convertToTimeLineAction(action string) TimelineAction {
-	switch action {
-	case pevents.SubjectDelegateCreateProposal:
-		return DelegateCreateProposal
-	case pevents.SubjectDelegateVotingVoted:
-		return DelegateVotingVoted
-	case pevents.SubjectDelegateVotingSkipVote:
-		return DelegateVotingSkipVote
-	case pevents.SubjectDelegateCreated:
-		return DelegateCreated
-	case pevents.SubjectDelegateDelegationExpired:
-		return DelegateDelegationExpired
-	case pevents.SubjectDelegateDelegationExpiringSoon:
-		return DelegateDelegationExpiringSoon
-	default:
+	converted, ok := delegateEvents[action]
+	if !ok {
 		return None
 	}
+
+	return converted
 }
 
 func (c *DelegatesConsumer) convertToFeedItem(action TimelineAction, pl pevents.DelegatePayload) (*FeedItem, error) {
@@ -102,11 +113,7 @@ func (c *DelegatesConsumer) Start(ctx context.Context) error {
 		client.WithAckWait(delegatesExecutionTtl),
 	}
 
-	for _, subj := range []string{
-		pevents.SubjectDelegateVotingSkipVote,
-		pevents.SubjectDelegateDelegationExpiringSoon,
-		pevents.SubjectDelegateDelegationExpired,
-	} {
+	for subj := range delegateEvents {
 		consumer, err := client.NewConsumer(ctx, c.conn, group, subj, c.handler(subj), opts...)
 		if err != nil {
 			return fmt.Errorf("consume for %s/%s: %w", group, subj, err)
@@ -0,0 +1,37 @@
+package item
+
+import (
+	"testing"
+
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitConvertToTimeLineAction(t *testing.T) {
+	for _, tc := range []struct {
+		subject  string
+		expected TimelineAction
+	}{
+		{subject: pevents.SubjectDelegateCreateProposal, expected: DelegateCreateProposal},
+		{subject: pevents.SubjectDelegateVotingVoted, expected: DelegateVotingVoted},
+		{subject: pevents.SubjectDelegateVotingSkipVote, expected: DelegateVotingSkipVote},
+		{subject: pevents.SubjectDelegateCreated, expected: DelegateCreated},
+		{subject: pevents.SubjectDelegateDelegationExpired, expected: DelegateDelegationExpired},
+		{subject: pevents.SubjectDelegateDelegationExpiringSoon, expected: DelegateDelegationExpiringSoon},
+		{subject: "unknown.subject", expected: None},
+	} {
+		t.Run(tc.subject, func(t *testing.T) {
+			require.Equal(t, tc.expected, convertToTimeLineAction(tc.subject))
+		})
+	}
+}
+
+func TestUnitDelegateEventsAreConsumed(t *testing.T) {
+	require.Len(t, delegateEvents, 6)
+
+	for subject, action := range delegateEvents {
+		require.NotEqual(t, None, action, subject)
+		require.Contains(t, timelineActionsMap, action, subject)
+		require.Contains(t, inboxTimelineActionMap, action, subject)
+	}
+}
@@ -0,0 +1,229 @@
+package item
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+const (
+	discussionMaxPendingElements = 100
+	discussionRateLimit          = 500 * client.KiB
+	discussionExecutionTtl       = time.Minute
+)
+
+// Forum thread events are not declared in platform events yet
+const (
+	SubjectDiscussionCreated          = "core.discussion.created"
+	SubjectDiscussionReplied          = "core.discussion.replied"
+	SubjectDiscussionLinkedToProposal = "core.discussion.linked_to_proposal"
+)
+
+type DiscussionPayload struct {
+	ID           string    `json:"id"`
+	DaoID        uuid.UUID `json:"dao_id"`
+	ProposalID   string    `json:"proposal_id"`
+	Title        string    `json:"title"`
+	Author       string    `json:"author"`
+	URL          string    `json:"url"`
+	RepliesCount uint64    `json:"replies_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastReplyAt  time.Time `json:"last_reply_at"`
+}
+
+type DiscussionHandler func(payload DiscussionPayload) error
+
+// discussionEvents is map event:string => event config
+var discussionEvents = map[string]eventConfig{
+	SubjectDiscussionCreated:          {isUnique: true, action: DiscussionCreated},
+	SubjectDiscussionReplied:          {isUnique: false, action: DiscussionReplied},
+	SubjectDiscussionLinkedToProposal: {isUnique: true, action: DiscussionLinkedToProposal},
+}
+
+type DiscussionConsumer struct {
+	conn      *nats.Conn
+	service   *Service
+	consumers []*client.Consumer[DiscussionPayload]
+}
+
+func NewDiscussionConsumer(nc *nats.Conn, s *Service) (*DiscussionConsumer, error) {
+	c := &DiscussionConsumer{
+		conn:      nc,
+		service:   s,
+		consumers: make([]*client.Consumer[DiscussionPayload], 0),
+	}
+
+	return c, nil
+}
+
+func (c *DiscussionConsumer) handler(action string) DiscussionHandler {
+	return func(payload DiscussionPayload) error {
+		var err error
+		defer func(start time.Time) {
+			metricHandleHistogram.
+				WithLabelValues("discussion", metrics.ErrLabelValue(err)).
+				Observe(time.Since(start).Seconds())
+		}(time.Now())
+
+		key, processed, err := c.service.checkEvent(action, payload)
+		if err != nil {
+			return err
+		}
+
+		if processed {
+			skipEvent(TypeDiscussion, skipReasonDuplicate)
+			return nil
+		}
+
+		item, err := c.service.GetDiscussionItem(context.TODO(), payload.ID)
+		if err != nil {
+			return err
+		}
+
+		var timeline Timeline
+		if item != nil {
+			timeline = item.Timeline
+		}
+
+		cfg := discussionEvents[action]
+
+		eventTime := time.Now().UTC()
+		if action == SubjectDiscussionReplied && !payload.LastReplyAt.IsZero() {
+			eventTime = payload.LastReplyAt.UTC()
+		}
+
+		var sendUpdates = true
+		if cfg.isUnique {
+			sendUpdates = timeline.AddUniqueAction(eventTime, cfg.action)
+		} else {
+			timeline.AddNonUniqueAction(eventTime, cfg.action)
+		}
+
+		timeline = c.prefillTimelineInNeeded(payload, timeline)
+
+		if item == nil {
+			item, err = c.convertToFeedItem(payload, timeline)
+			if err != nil {
+				return err
+			}
+		} else {
+			sn, err := json.Marshal(payload)
+			if err != nil {
+				return fmt.Errorf("cant marshal payload: %w", err)
+			}
+
+			// the late event must not overwrite the newer snapshot, but unique actions are still added to the timeline
+			if isStaleSnapshot(item.Snapshot, sn) {
+				if !cfg.isUnique {
+					skipEvent(TypeDiscussion, skipReasonStale)
+					return nil
+				}
+			} else {
+				item.Snapshot = sn
+			}
+
+			item.Timeline = timeline
+			if payload.ProposalID != "" {
+				item.ProposalID = payload.ProposalID
+			}
+		}
+
+		err = c.service.HandleEvent(context.TODO(), key, item, sendUpdates)
+		if err != nil {
+			log.Error().Str("discussion_id", payload.ID).Err(err).Msg("process discussion")
+			return err
+		}
+
+		if item.ProposalID != "" {
+			err = c.service.LinkDiscussion(context.TODO(), item.ProposalID, item.DiscussionID)
+			if err != nil {
+				log.Error().Str("discussion_id", payload.ID).Err(err).Msg("link discussion to proposal")
+				return err
+			}
+		}
+
+		log.Debug().Msgf("discussion was processed: %s", payload.ID)
+
+		return nil
+	}
+}
+
+func (c *DiscussionConsumer) convertToFeedItem(pl DiscussionPayload, timeline Timeline) (*FeedItem, error) {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return nil, fmt.Errorf("cant marshal payload: %w", err)
+	}
+
+	return &FeedItem{
+		DaoID:        pl.DaoID,
+		ProposalID:   pl.ProposalID,
+		DiscussionID: pl.ID,
+		Type:         TypeDiscussion,
+		Action:       DiscussionCreated,
+		Snapshot:     b,
+		Timeline:     timeline,
+	}, nil
+}
+
+func (c *DiscussionConsumer) Start(ctx context.Context) error {
+	group := config.GenerateGroupName("item_discussion")
+
+	opts := []client.ConsumerOpt{
+		client.WithRateLimit(discussionRateLimit),
+		client.WithMaxAckPending(discussionMaxPendingElements),
+		client.WithAckWait(discussionExecutionTtl),
+	}
+
+	for event := range discussionEvents {
+		cc, err := client.NewConsumer(ctx, c.conn, group, event, c.handler(event), opts...)
+		if err != nil {
+			return fmt.Errorf("consume for %s/%s: %w", group, event, err)
+		}
+		c.consumers = append(c.consumers, cc)
+	}
+
+	log.Info().Msg("feed item discussion consumers is started")
+
+	// todo: handle correct stopping the consumer by context
+	<-ctx.Done()
+	return c.stop()
+}
+
+func (c *DiscussionConsumer) stop() error {
+	for _, cs := range c.consumers {
+		if err := cs.Close(); err != nil {
+			log.Error().Err(err).Msg("cant close feed item discussion consumer")
+		}
+	}
+
+	return nil
+}
+
+func (c *DiscussionConsumer) prefillTimelineInNeeded(payload DiscussionPayload, timeline Timeline) Timeline {
+	prepend := make([]TimelineItem, 0, 2)
+
+	if !timeline.ContainsAction(DiscussionCreated) {
+		createdAt := payload.CreatedAt.UTC()
+		if payload.CreatedAt.IsZero() {
+			createdAt = time.Now().UTC()
+		}
+
+		prepend = append(prepend, TimelineItem{
+			CreatedAt: createdAt,
+			Action:    DiscussionCreated,
+		})
+	}
+
+	timeline = append(prepend, timeline...)
+
+	return timeline
+}
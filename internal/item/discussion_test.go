@@ -0,0 +1,72 @@
+package item
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+func TestUnitLinkDiscussion(t *testing.T) {
+	ctx := context.Background()
+	subRepo := subscriber.NewMemoryRepo()
+
+	subs, err := subscriber.NewService(subRepo, subscriber.NewCache(), nopInvalidator{})
+	require.NoError(t, err)
+	sub, err := subs.Create(ctx, "https://example.com/hook")
+	require.NoError(t, err)
+
+	messages := outbox.NewMemoryRepo()
+	repo := NewMemoryRepo(nil, messages)
+	endpoints := subscriber.NewWebhookEndpoints(subRepo, subscriber.NewEndpointsCache(cache.Options{}), nopInvalidator{})
+
+	s, err := NewService(repo, subs, endpoints, staticSubscriptions{sub.ID}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	proposal := &FeedItem{
+		ID:         uuid.New(),
+		DaoID:      uuid.New(),
+		ProposalID: "proposal",
+		Type:       TypeProposal,
+	}
+	proposal.Timeline.AddUniqueAction(time.Now(), ProposalCreated)
+	require.NoError(t, repo.Save(proposal, ""))
+
+	// nothing is linked yet
+	id, err := s.GetLinkedDiscussionID(ctx, "proposal")
+	require.NoError(t, err)
+	require.Empty(t, id)
+
+	require.NoError(t, repo.Save(&FeedItem{
+		ID:           uuid.New(),
+		DaoID:        proposal.DaoID,
+		ProposalID:   "proposal",
+		DiscussionID: "discussion",
+		Type:         TypeDiscussion,
+	}, ""))
+
+	id, err = s.GetLinkedDiscussionID(ctx, "proposal")
+	require.NoError(t, err)
+	require.Equal(t, "discussion", id)
+
+	require.NoError(t, s.LinkDiscussion(ctx, "proposal", "discussion"))
+
+	stored, err := s.GetProposalItem(ctx, "proposal")
+	require.NoError(t, err)
+	require.Equal(t, "discussion", stored.DiscussionID)
+	require.Equal(t, proposal.ID, stored.ID)
+
+	// linking doesn't change the proposal, so updates and callbacks are not sent
+	pending, err := messages.GetPending(10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	// unknown proposals are skipped
+	require.NoError(t, s.LinkDiscussion(ctx, "unknown", "discussion"))
+}
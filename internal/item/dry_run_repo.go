@@ -0,0 +1,285 @@
+package item
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+)
+
+// ItemDiff describes changes of the feed item made during the dry run
+type ItemDiff struct {
+	Type            Type             `json:"type"`
+	DaoID           string           `json:"dao_id"`
+	ProposalID      string           `json:"proposal_id,omitempty"`
+	DiscussionID    string           `json:"discussion_id,omitempty"`
+	Voter           string           `json:"voter,omitempty"`
+	IsNew           bool             `json:"is_new"`
+	AddedActions    []TimelineAction `json:"added_actions,omitempty"`
+	SnapshotChanged bool             `json:"snapshot_changed"`
+}
+
+type dryRunChange struct {
+	before *FeedItem
+	after  FeedItem
+}
+
+// DryRunRepo keeps saved feed items in memory on top of the read-only repository,
+// so events could be replayed without changing the stored feed
+type DryRunRepo struct {
+	repo DataProvider
+
+	mu        sync.Mutex
+	changes   map[string]*dryRunChange
+	processed map[string]struct{}
+}
+
+func NewDryRunRepo(repo DataProvider) *DryRunRepo {
+	return &DryRunRepo{
+		repo:      repo,
+		changes:   make(map[string]*dryRunChange),
+		processed: make(map[string]struct{}),
+	}
+}
+
+func (r *DryRunRepo) Save(item *FeedItem, eventKey string, _ ...outbox.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if eventKey != "" {
+		if _, ok := r.processed[eventKey]; ok {
+			return errEventProcessed
+		}
+		r.processed[eventKey] = struct{}{}
+	}
+
+	key := itemIdentity(item)
+	change, ok := r.changes[key]
+	if !ok {
+		before, err := r.stored(item)
+		if err != nil {
+			return err
+		}
+
+		change = &dryRunChange{before: before}
+		r.changes[key] = change
+	}
+
+	change.after = copyItem(item)
+
+	return nil
+}
+
+func (r *DryRunRepo) GetByID(id uuid.UUID) (*FeedItem, error) {
+	if item, ok := r.find(func(item *FeedItem) bool { return item.ID == id }); ok {
+		return item, nil
+	}
+
+	return r.repo.GetByID(id)
+}
+
+func (r *DryRunRepo) GetDaoItem(id uuid.UUID) (*FeedItem, error) {
+	if item, ok := r.find(func(item *FeedItem) bool { return item.Type == TypeDao && item.DaoID == id }); ok {
+		return item, nil
+	}
+
+	return r.repo.GetDaoItem(id)
+}
+
+func (r *DryRunRepo) GetProposalItem(id string) (*FeedItem, error) {
+	if item, ok := r.find(func(item *FeedItem) bool { return item.Type == TypeProposal && item.ProposalID == id }); ok {
+		return item, nil
+	}
+
+	return r.repo.GetProposalItem(id)
+}
+
+func (r *DryRunRepo) GetDiscussionItem(id string) (*FeedItem, error) {
+	if item, ok := r.find(func(item *FeedItem) bool { return item.Type == TypeDiscussion && item.DiscussionID == id }); ok {
+		return item, nil
+	}
+
+	return r.repo.GetDiscussionItem(id)
+}
+
+func (r *DryRunRepo) GetProposalDiscussionItem(proposalID string) (*FeedItem, error) {
+	if item, ok := r.find(func(item *FeedItem) bool { return item.Type == TypeDiscussion && item.ProposalID == proposalID }); ok {
+		return item, nil
+	}
+
+	return r.repo.GetProposalDiscussionItem(proposalID)
+}
+
+func (r *DryRunRepo) GetByFilters(filters []Filter) (FeedList, error) {
+	return r.repo.GetByFilters(filters)
+}
+
+func (r *DryRunRepo) GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt time.Time, limit int) ([]FeedItem, error) {
+	return r.repo.GetLastItems(subscriberID, fTypes, lastUpdatedAt, limit)
+}
+
+func (r *DryRunRepo) WithRetentionLock(fn func() error) (bool, error) {
+	return r.repo.WithRetentionLock(fn)
+}
+
+func (r *DryRunRepo) CountExpired(fType Type, before time.Time, onlyActive bool) (int64, error) {
+	return r.repo.CountExpired(fType, before, onlyActive)
+}
+
+func (r *DryRunRepo) DeleteExpired(_ Type, _ time.Time, _ int, _ bool) (int64, error) {
+	return 0, nil
+}
+
+func (r *DryRunRepo) ArchiveExpired(_ Type, _ time.Time, _ int) (int64, error) {
+	return 0, nil
+}
+
+func (r *DryRunRepo) CreateMonthPartition(_ time.Time) error {
+	return nil
+}
+
+func (r *DryRunRepo) IsEventProcessed(key string) (bool, error) {
+	r.mu.Lock()
+	_, ok := r.processed[key]
+	r.mu.Unlock()
+
+	if ok {
+		return true, nil
+	}
+
+	return r.repo.IsEventProcessed(key)
+}
+
+func (r *DryRunRepo) DeleteProcessedEventsBefore(_ time.Time, _ int) (int64, error) {
+	return 0, nil
+}
+
+// Diff returns changes of all feed items saved during the dry run
+func (r *DryRunRepo) Diff() []ItemDiff {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]string, 0, len(r.changes))
+	for key := range r.changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	diffs := make([]ItemDiff, 0, len(keys))
+	for _, key := range keys {
+		change := r.changes[key]
+		diff := ItemDiff{
+			Type:         change.after.Type,
+			DaoID:        change.after.DaoID.String(),
+			ProposalID:   change.after.ProposalID,
+			DiscussionID: change.after.DiscussionID,
+			Voter:        change.after.Voter,
+			IsNew:        change.before == nil,
+		}
+
+		var before Timeline
+		if change.before != nil {
+			before = change.before.Timeline
+			diff.SnapshotChanged = !bytes.Equal(change.before.Snapshot, change.after.Snapshot)
+		}
+		diff.AddedActions = addedActions(before, change.after.Timeline)
+
+		if !diff.IsNew && !diff.SnapshotChanged && len(diff.AddedActions) == 0 {
+			continue
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+func (r *DryRunRepo) find(match func(item *FeedItem) bool) (*FeedItem, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, change := range r.changes {
+		if match(&change.after) {
+			item := copyItem(&change.after)
+			return &item, true
+		}
+	}
+
+	return nil, false
+}
+
+// stored returns the stored state of the feed item or nil if there is no such item
+func (r *DryRunRepo) stored(item *FeedItem) (*FeedItem, error) {
+	var (
+		stored *FeedItem
+		err    error
+	)
+
+	switch item.Type {
+	case TypeDao:
+		stored, err = r.repo.GetDaoItem(item.DaoID)
+	case TypeProposal:
+		stored, err = r.repo.GetProposalItem(item.ProposalID)
+	case TypeDiscussion:
+		stored, err = r.repo.GetDiscussionItem(item.DiscussionID)
+	default:
+		// delegate and vote items are always stored as the new ones
+		return nil, nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	return stored, err
+}
+
+func itemIdentity(item *FeedItem) string {
+	switch item.Type {
+	case TypeDao:
+		return fmt.Sprintf("%s/%s", item.Type, item.DaoID)
+	case TypeProposal:
+		return fmt.Sprintf("%s/%s", item.Type, item.ProposalID)
+	case TypeDiscussion:
+		return fmt.Sprintf("%s/%s", item.Type, item.DiscussionID)
+	case TypeVote:
+		return fmt.Sprintf("%s/%s/%s", item.Type, item.ProposalID, item.Voter)
+	default:
+		return fmt.Sprintf("%s/%s", item.Type, item.ID)
+	}
+}
+
+func copyItem(item *FeedItem) FeedItem {
+	cp := *item
+	cp.Snapshot = append([]byte(nil), item.Snapshot...)
+	cp.Timeline = append(Timeline(nil), item.Timeline...)
+
+	return cp
+}
+
+// addedActions returns actions of the timeline which are missed in the previous one
+func addedActions(before, after Timeline) []TimelineAction {
+	counts := make(map[TimelineAction]int, len(before))
+	for _, t := range before {
+		counts[t.Action]++
+	}
+
+	var added []TimelineAction
+	for _, t := range after {
+		if counts[t.Action] > 0 {
+			counts[t.Action]--
+			continue
+		}
+
+		added = append(added, t.Action)
+	}
+
+	return added
+}
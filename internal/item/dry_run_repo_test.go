@@ -0,0 +1,52 @@
+package item
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitDryRunRepoDiff(t *testing.T) {
+	stored := newMemoryRepo()
+	daoID := uuid.New()
+	require.NoError(t, stored.Save(&FeedItem{
+		ID:       uuid.New(),
+		DaoID:    daoID,
+		Type:     TypeDao,
+		Snapshot: []byte(`{"name":"dao"}`),
+		Timeline: Timeline{{CreatedAt: time.Now(), Action: DaoCreated}},
+	}, ""))
+
+	repo := NewDryRunRepo(stored)
+	service := newTestService(t, repo)
+	service.EnableReplayMode(false)
+
+	dc := &DaoConsumer{service: service}
+	require.NoError(t, dc.HandleMessage(pevents.SubjectDaoUpdated, []byte(`{"id":"`+daoID.String()+`","name":"renamed"}`)))
+
+	pc := &ProposalConsumer{service: service}
+	require.NoError(t, pc.HandleMessage(pevents.SubjectProposalCreated, []byte(`{"id":"proposal","dao_id":"`+daoID.String()+`","start":4102444800,"end":4102444800}`)))
+	require.NoError(t, pc.HandleMessage(pevents.SubjectProposalUpdated, []byte(`{"id":"proposal","dao_id":"`+daoID.String()+`","start":4102444800,"end":4102444800,"votes":1}`)))
+
+	// nothing is stored in the underlying repo
+	require.Equal(t, 1, stored.saves)
+
+	require.Equal(t, []ItemDiff{
+		{
+			Type:            TypeDao,
+			DaoID:           daoID.String(),
+			AddedActions:    []TimelineAction{DaoUpdated},
+			SnapshotChanged: true,
+		},
+		{
+			Type:         TypeProposal,
+			DaoID:        daoID.String(),
+			ProposalID:   "proposal",
+			IsNew:        true,
+			AddedActions: []TimelineAction{ProposalCreated, ProposalUpdated},
+		},
+	}, repo.Diff())
+}
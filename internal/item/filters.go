@@ -47,11 +47,16 @@ type ActiveFilter struct {
 }
 
 func (f ActiveFilter) Apply(db *gorm.DB) *gorm.DB {
+	var (
+		dummy FeedItem
+		_     = dummy.VoteEnd
+	)
+
 	if f.IsActive {
-		return db.Where("to_timestamp((snapshot->'end')::double precision) >= now()")
+		return db.Where("vote_end >= now()")
 	}
 
-	return db.Where("to_timestamp((snapshot->'end')::double precision) < now()")
+	return db.Where("vote_end < now()")
 }
 
 type ActionFilter struct {
@@ -75,10 +80,11 @@ type SkipSpammed struct {
 func (f SkipSpammed) Apply(db *gorm.DB) *gorm.DB {
 	var (
 		dummy FeedItem
-		_     = dummy.Snapshot // spam flag
+		_     = dummy.Spam
 	)
 
-	return db.Where(`snapshot->>'spam' != 'true'`)
+	// items without the spam flag are skipped as well
+	return db.Where(`spam = false`)
 }
 
 type SkipCanceled struct {
@@ -87,10 +93,11 @@ type SkipCanceled struct {
 func (f SkipCanceled) Apply(db *gorm.DB) *gorm.DB {
 	var (
 		dummy FeedItem
-		_     = dummy.Snapshot // state
+		_     = dummy.State
 	)
 
-	return db.Where(`snapshot->>'state' != 'canceled'`)
+	// items without the state are skipped as well
+	return db.Where(`state != 'canceled'`)
 }
 
 type SkipDelegates struct {
@@ -105,6 +112,32 @@ func (f SkipDelegates) Apply(db *gorm.DB) *gorm.DB {
 	return db.Where(`type != ?`, TypeDelegate)
 }
 
+type SkipVotes struct {
+}
+
+func (f SkipVotes) Apply(db *gorm.DB) *gorm.DB {
+	var (
+		dummy FeedItem
+		_     = dummy.Type
+	)
+
+	return db.Where(`type != ?`, TypeVote)
+}
+
+type VoterFilter struct {
+	Voters []string
+}
+
+func (f VoterFilter) Apply(db *gorm.DB) *gorm.DB {
+	var (
+		dummy FeedItem
+		_     = dummy.Voter
+		_     = dummy.Type
+	)
+
+	return db.Where("type = ?", TypeVote).Where("voter IN ?", f.Voters)
+}
+
 type SortedByActuality struct {
 }
 
@@ -112,7 +145,7 @@ func (f SortedByActuality) Apply(db *gorm.DB) *gorm.DB {
 	var (
 		dummy FeedItem
 		_     = dummy.CreatedAt
-		_     = dummy.Snapshot // state
+		_     = dummy.State
 	)
 
 	return db.Order(`
@@ -123,7 +156,7 @@ func (f SortedByActuality) Apply(db *gorm.DB) *gorm.DB {
 					'failed',
 					'defeated',
 					'canceled'
-				], snapshot->>'state'), 
+				], state), 
 				created_at desc`)
 }
 
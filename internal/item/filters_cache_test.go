@@ -0,0 +1,126 @@
+package item
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+)
+
+type countingRepo struct {
+	*MemoryRepo
+	filterRequests int
+}
+
+func (r *countingRepo) GetByFilters(filters []Filter) (FeedList, error) {
+	r.filterRequests++
+
+	return r.MemoryRepo.GetByFilters(filters)
+}
+
+func TestUnitGetByFiltersCache(t *testing.T) {
+	repo := &countingRepo{MemoryRepo: NewMemoryRepo(subscription.NewMemoryRepo(), nil)}
+	s, err := NewService(repo, noSubscribers{}, noEndpoints{}, noSubscriptions{}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	var (
+		daoID      = uuid.New()
+		otherDaoID = uuid.New()
+		byDao      = []Filter{DaoIDFilter{IDs: []string{daoID.String()}}}
+		byType     = []Filter{TypeFilter{Types: []string{string(TypeProposal)}}}
+	)
+
+	// empty results are cached as well
+	for i := 0; i < 2; i++ {
+		list, err := s.GetByFilters(byDao)
+		require.NoError(t, err)
+		require.Empty(t, list.Items)
+	}
+	require.Equal(t, 1, repo.filterRequests)
+
+	_, err = s.GetByFilters(byType)
+	require.NoError(t, err)
+	require.Equal(t, 2, repo.filterRequests)
+
+	// the item of another DAO invalidates only results which could contain any DAO
+	require.NoError(t, s.HandleItem(context.Background(), testItem(TypeProposal, otherDaoID, "other", `{}`), false))
+
+	_, err = s.GetByFilters(byDao)
+	require.NoError(t, err)
+	require.Equal(t, 2, repo.filterRequests)
+
+	list, err := s.GetByFilters(byType)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, 3, repo.filterRequests)
+
+	require.NoError(t, s.HandleItem(context.Background(), testItem(TypeProposal, daoID, "proposal", `{}`), false))
+
+	list, err = s.GetByFilters(byDao)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, 4, repo.filterRequests)
+}
+
+// changingRepo stores the item while the filtered list is read, like the concurrent consumer does
+type changingRepo struct {
+	countingRepo
+	onRead func()
+}
+
+func (r *changingRepo) GetByFilters(filters []Filter) (FeedList, error) {
+	list, err := r.countingRepo.GetByFilters(filters)
+	if r.onRead != nil {
+		onRead := r.onRead
+		r.onRead = nil
+		onRead()
+	}
+
+	return list, err
+}
+
+func TestUnitGetByFiltersSkipsCachingAfterConcurrentChange(t *testing.T) {
+	repo := &changingRepo{countingRepo: countingRepo{MemoryRepo: NewMemoryRepo(subscription.NewMemoryRepo(), nil)}}
+	s, err := NewService(repo, noSubscribers{}, noEndpoints{}, noSubscriptions{}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	daoID := uuid.New()
+	byDao := []Filter{DaoIDFilter{IDs: []string{daoID.String()}}}
+
+	repo.onRead = func() {
+		require.NoError(t, s.HandleItem(context.Background(), testItem(TypeProposal, daoID, "proposal", `{}`), false))
+	}
+
+	list, err := s.GetByFilters(byDao)
+	require.NoError(t, err)
+	require.Empty(t, list.Items)
+
+	// the stale empty result is not cached
+	list, err = s.GetByFilters(byDao)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, 2, repo.filterRequests)
+}
+
+func TestUnitGetByFiltersCachesDespiteConcurrentChangeOfAnotherDao(t *testing.T) {
+	repo := &changingRepo{countingRepo: countingRepo{MemoryRepo: NewMemoryRepo(subscription.NewMemoryRepo(), nil)}}
+	s, err := NewService(repo, noSubscribers{}, noEndpoints{}, noSubscriptions{}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	byDao := []Filter{DaoIDFilter{IDs: []string{uuid.NewString()}}}
+
+	repo.onRead = func() {
+		require.NoError(t, s.HandleItem(context.Background(), testItem(TypeProposal, uuid.New(), "proposal", `{}`), false))
+	}
+
+	for i := 0; i < 2; i++ {
+		list, err := s.GetByFilters(byDao)
+		require.NoError(t, err)
+		require.Empty(t, list.Items)
+	}
+	require.Equal(t, 1, repo.filterRequests)
+}
@@ -0,0 +1,70 @@
+package item
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var errEventProcessed = errors.New("event is already processed")
+
+// ProcessedEvent is a key of the handled event, it protects feed items from applying redelivered events twice
+type ProcessedEvent struct {
+	Key       string `gorm:"primarykey"`
+	CreatedAt time.Time
+}
+
+func (ProcessedEvent) TableName() string {
+	return "processed_events"
+}
+
+// eventKey builds the key of the event by its subject and payload, so redelivered messages have the same key
+func eventKey(subject string, payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(subject))
+	h.Write([]byte{0})
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// snapshotVersion contains snapshot fields which only grow with newer versions of the entity
+type snapshotVersion struct {
+	UpdatedAt     time.Time `json:"updated_at"`
+	LastReplyAt   time.Time `json:"last_reply_at"`
+	ScoresUpdated int64     `json:"scores_updated"`
+}
+
+// isStaleSnapshot reports whether the next snapshot is older than the current one.
+// Missing or zero values are treated as unknown and never make the snapshot stale.
+func isStaleSnapshot(current, next json.RawMessage) bool {
+	if len(current) == 0 || len(next) == 0 {
+		return false
+	}
+
+	var cv, nv snapshotVersion
+	if err := json.Unmarshal(current, &cv); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(next, &nv); err != nil {
+		return false
+	}
+
+	switch {
+	case !cv.UpdatedAt.IsZero() && !nv.UpdatedAt.IsZero() && nv.UpdatedAt.Before(cv.UpdatedAt):
+		return true
+	case !cv.LastReplyAt.IsZero() && !nv.LastReplyAt.IsZero() && nv.LastReplyAt.Before(cv.LastReplyAt):
+		return true
+	case cv.ScoresUpdated > 0 && nv.ScoresUpdated > 0 && nv.ScoresUpdated < cv.ScoresUpdated:
+		return true
+	default:
+		return false
+	}
+}
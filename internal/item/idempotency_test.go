@@ -0,0 +1,240 @@
+package item
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+type memoryRepo struct {
+	DataProvider
+
+	items     map[string]FeedItem
+	processed map[string]struct{}
+	saves     int
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		items:     make(map[string]FeedItem),
+		processed: make(map[string]struct{}),
+	}
+}
+
+func (r *memoryRepo) Save(item *FeedItem, eventKey string, _ ...outbox.Message) error {
+	if eventKey != "" {
+		if _, ok := r.processed[eventKey]; ok {
+			return errEventProcessed
+		}
+		r.processed[eventKey] = struct{}{}
+	}
+
+	stored := *item
+	stored.Timeline = append(Timeline(nil), item.Timeline...)
+	r.items[string(item.Type)+item.DaoID.String()+item.ProposalID] = stored
+	r.saves++
+
+	return nil
+}
+
+func (r *memoryRepo) get(fType Type, daoID uuid.UUID, proposalID string) (*FeedItem, error) {
+	item, ok := r.items[string(fType)+daoID.String()+proposalID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	item.Timeline = append(Timeline(nil), item.Timeline...)
+
+	return &item, nil
+}
+
+func (r *memoryRepo) GetByID(id uuid.UUID) (*FeedItem, error) {
+	for _, item := range r.items {
+		if item.ID == id {
+			return r.get(item.Type, item.DaoID, item.ProposalID)
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) GetDaoItem(id uuid.UUID) (*FeedItem, error) {
+	return r.get(TypeDao, id, "")
+}
+
+func (r *memoryRepo) GetProposalItem(id string) (*FeedItem, error) {
+	for _, item := range r.items {
+		if item.Type == TypeProposal && item.ProposalID == id {
+			return r.get(TypeProposal, item.DaoID, id)
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) GetProposalDiscussionItem(_ string) (*FeedItem, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRepo) IsEventProcessed(key string) (bool, error) {
+	_, ok := r.processed[key]
+
+	return ok, nil
+}
+
+type noSubscriptions struct{}
+
+func (noSubscriptions) GetSubscribers(_ context.Context, _ uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (noSubscriptions) GetAddressFollowers(_ context.Context, _ string) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+type noSubscribers struct{}
+
+func (noSubscribers) GetByID(_ context.Context, _ uuid.UUID) (*subscriber.Subscriber, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+type noEndpoints struct{}
+
+func (noEndpoints) List(_ context.Context, _ uuid.UUID) ([]subscriber.WebhookEndpoint, error) {
+	return nil, nil
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) PublishNoWait(_ string) {}
+
+func newTestService(t *testing.T, repo DataProvider) *Service {
+	s, err := NewService(repo, noSubscribers{}, noEndpoints{}, noSubscriptions{}, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestUnitDaoConsumerSkipsRedeliveredEvent(t *testing.T) {
+	repo := newMemoryRepo()
+	c := &DaoConsumer{service: newTestService(t, repo)}
+
+	payload := pevents.DaoPayload{ID: uuid.New(), Name: "dao", UpdatedAt: time.Now()}
+	handler := c.handler(pevents.SubjectDaoUpdated)
+
+	require.NoError(t, handler(payload))
+	require.NoError(t, handler(payload))
+
+	item, err := repo.GetDaoItem(payload.ID)
+	require.NoError(t, err)
+	require.Len(t, item.Timeline, 2) // created and the only one updated
+	require.Equal(t, 1, repo.saves)
+}
+
+func TestUnitDaoConsumerRejectsStaleUpdate(t *testing.T) {
+	repo := newMemoryRepo()
+	c := &DaoConsumer{service: newTestService(t, repo)}
+
+	now := time.Now()
+	newer := pevents.DaoPayload{ID: uuid.New(), Name: "newer", UpdatedAt: now}
+	older := pevents.DaoPayload{ID: newer.ID, Name: "older", UpdatedAt: now.Add(-time.Minute)}
+	handler := c.handler(pevents.SubjectDaoUpdated)
+
+	require.NoError(t, handler(newer))
+	require.NoError(t, handler(older))
+
+	item, err := repo.GetDaoItem(newer.ID)
+	require.NoError(t, err)
+
+	var snapshot pevents.DaoPayload
+	require.NoError(t, json.Unmarshal(item.Snapshot, &snapshot))
+	require.Equal(t, "newer", snapshot.Name)
+	require.Len(t, item.Timeline, 2)
+}
+
+func TestUnitProposalConsumerHandlesReorderedEvents(t *testing.T) {
+	repo := newMemoryRepo()
+	c := &ProposalConsumer{service: newTestService(t, repo)}
+
+	now := time.Now()
+	base := pevents.ProposalPayload{
+		ID:      "proposal",
+		DaoID:   uuid.New(),
+		Created: int(now.Add(-time.Hour).Unix()),
+		End:     int(now.Unix()),
+	}
+
+	created, older, newer, ended := base, base, base, base
+	older.ScoresUpdated, older.Votes = int(now.Add(-2*time.Minute).Unix()), 10
+	newer.ScoresUpdated, newer.Votes = int(now.Add(-time.Minute).Unix()), 20
+	ended.ScoresUpdated, ended.Votes = older.ScoresUpdated, older.Votes
+	ended.State = "closed"
+
+	require.NoError(t, c.handler(pevents.SubjectProposalCreated)(created))
+	require.NoError(t, c.handler(pevents.SubjectProposalUpdated)(newer))
+	// late update with older scores is rejected
+	require.NoError(t, c.handler(pevents.SubjectProposalUpdated)(older))
+	// late unique action is added to the timeline but keeps the newer snapshot
+	require.NoError(t, c.handler(pevents.SubjectProposalVotingEnded)(ended))
+
+	item, err := repo.GetProposalItem(base.ID)
+	require.NoError(t, err)
+
+	var snapshot pevents.ProposalPayload
+	require.NoError(t, json.Unmarshal(item.Snapshot, &snapshot))
+	require.Equal(t, 20, snapshot.Votes)
+	require.True(t, item.Timeline.ContainsAction(ProposalVotingEnded))
+
+	var updates int
+	for _, ti := range item.Timeline {
+		if ti.Action == ProposalUpdated {
+			updates++
+		}
+	}
+	require.Equal(t, 1, updates)
+}
+
+func TestUnitIsStaleSnapshot(t *testing.T) {
+	for name, tc := range map[string]struct {
+		current, next string
+		expected      bool
+	}{
+		"empty current":        {current: ``, next: `{"updated_at":"2026-10-01T00:00:00Z"}`, expected: false},
+		"newer updated_at":     {current: `{"updated_at":"2026-10-01T00:00:00Z"}`, next: `{"updated_at":"2026-10-02T00:00:00Z"}`, expected: false},
+		"older updated_at":     {current: `{"updated_at":"2026-10-02T00:00:00Z"}`, next: `{"updated_at":"2026-10-01T00:00:00Z"}`, expected: true},
+		"zero updated_at":      {current: `{"updated_at":"2026-10-02T00:00:00Z"}`, next: `{"updated_at":"0001-01-01T00:00:00Z"}`, expected: false},
+		"older last_reply_at":  {current: `{"last_reply_at":"2026-10-02T00:00:00Z"}`, next: `{"last_reply_at":"2026-10-01T00:00:00Z"}`, expected: true},
+		"older scores_updated": {current: `{"scores_updated":200}`, next: `{"scores_updated":100}`, expected: true},
+		"same scores_updated":  {current: `{"scores_updated":200}`, next: `{"scores_updated":200,"state":"closed"}`, expected: false},
+		"unknown scores":       {current: `{"scores_updated":200}`, next: `{"scores_updated":0}`, expected: false},
+		"invalid json":         {current: `{"scores_updated":200}`, next: `[`, expected: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isStaleSnapshot(json.RawMessage(tc.current), json.RawMessage(tc.next)))
+		})
+	}
+}
+
+func TestUnitEventKey(t *testing.T) {
+	payload := pevents.DaoPayload{ID: uuid.New()}
+
+	first, err := eventKey(pevents.SubjectDaoUpdated, payload)
+	require.NoError(t, err)
+	second, err := eventKey(pevents.SubjectDaoUpdated, payload)
+	require.NoError(t, err)
+	other, err := eventKey(pevents.SubjectDaoCreated, payload)
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+	require.NotEqual(t, first, other)
+}
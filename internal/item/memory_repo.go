@@ -0,0 +1,533 @@
+package item
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+)
+
+// actualityOrder is the order of proposal states used by SortedByActuality
+var actualityOrder = []string{
+	"active",
+	"pending",
+	"succeeded",
+	"failed",
+	"defeated",
+	"canceled",
+}
+
+// FollowingsProvider returns DAOs and addresses followed by the subscriber
+type FollowingsProvider interface {
+	GetBySubscriber(subscriberID uuid.UUID) ([]subscription.Subscription, error)
+	GetAddressesBySubscriber(subscriberID uuid.UUID) ([]subscription.AddressSubscription, error)
+}
+
+// MemoryRepo keeps feed items in memory, it follows the semantics of the postgres repository
+// and is used for running the application and tests without the database
+type MemoryRepo struct {
+	followings FollowingsProvider
+	outbox     *outbox.MemoryRepo
+
+	mu        sync.RWMutex
+	items     []FeedItem
+	archive   []FeedItem
+	processed map[string]time.Time
+}
+
+// NewMemoryRepo creates the repository, outbox messages are dropped if the outbox repository is nil
+func NewMemoryRepo(followings FollowingsProvider, outbox *outbox.MemoryRepo) *MemoryRepo {
+	return &MemoryRepo{
+		followings: followings,
+		outbox:     outbox,
+		processed:  make(map[string]time.Time),
+	}
+}
+
+func (r *MemoryRepo) Save(item *FeedItem, eventKey string, messages ...outbox.Message) error {
+	if item.ID == emptyID {
+		item.ID = uuid.New()
+	}
+
+	if err := item.fillSnapshotColumns(); err != nil {
+		return fmt.Errorf("fill snapshot columns: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if eventKey != "" {
+		if _, ok := r.processed[eventKey]; ok {
+			return errEventProcessed
+		}
+		r.processed[eventKey] = time.Now()
+	}
+
+	now := time.Now()
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	}
+	item.UpdatedAt = now
+
+	idx := r.indexOfExisting(item)
+	if idx < 0 {
+		idx = r.indexOf(func(stored *FeedItem) bool { return stored.ID == item.ID })
+	}
+
+	if idx >= 0 {
+		item.ID = r.items[idx].ID
+		item.CreatedAt = r.items[idx].CreatedAt
+		r.items[idx] = copyItem(item)
+	} else {
+		r.items = append(r.items, copyItem(item))
+	}
+
+	if r.outbox != nil {
+		r.outbox.Add(messages...)
+	}
+
+	return nil
+}
+
+// indexOfExisting returns the index of the stored item with the same unique key as resolveExisting does
+func (r *MemoryRepo) indexOfExisting(item *FeedItem) int {
+	switch item.Type {
+	case TypeDelegate:
+		return -1
+	case TypeVote:
+		return r.indexOf(func(stored *FeedItem) bool {
+			return stored.Type == TypeVote && stored.ProposalID == item.ProposalID && stored.Voter == item.Voter
+		})
+	case TypeDiscussion:
+		return r.indexOf(func(stored *FeedItem) bool {
+			return stored.Type == TypeDiscussion && stored.DiscussionID == item.DiscussionID
+		})
+	default:
+		return r.indexOf(func(stored *FeedItem) bool {
+			return stored.DaoID == item.DaoID &&
+				stored.ProposalID == item.ProposalID &&
+				stored.Type == item.Type &&
+				stored.Action == item.Action
+		})
+	}
+}
+
+func (r *MemoryRepo) indexOf(match func(stored *FeedItem) bool) int {
+	for i := range r.items {
+		if match(&r.items[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (r *MemoryRepo) first(match func(item *FeedItem) bool) (*FeedItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := range r.items {
+		if r.items[i].DeletedAt.Valid || !match(&r.items[i]) {
+			continue
+		}
+
+		item := copyItem(&r.items[i])
+
+		return &item, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepo) GetByID(id uuid.UUID) (*FeedItem, error) {
+	return r.first(func(item *FeedItem) bool { return item.ID == id })
+}
+
+func (r *MemoryRepo) GetDaoItem(id uuid.UUID) (*FeedItem, error) {
+	return r.first(func(item *FeedItem) bool { return item.DaoID == id && item.Type == TypeDao })
+}
+
+func (r *MemoryRepo) GetProposalItem(id string) (*FeedItem, error) {
+	return r.first(func(item *FeedItem) bool { return item.ProposalID == id && item.Type == TypeProposal })
+}
+
+func (r *MemoryRepo) GetDiscussionItem(id string) (*FeedItem, error) {
+	return r.first(func(item *FeedItem) bool { return item.DiscussionID == id && item.Type == TypeDiscussion })
+}
+
+func (r *MemoryRepo) GetProposalDiscussionItem(proposalID string) (*FeedItem, error) {
+	return r.first(func(item *FeedItem) bool { return item.ProposalID == proposalID && item.Type == TypeDiscussion })
+}
+
+func (r *MemoryRepo) GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt time.Time, limit int) ([]FeedItem, error) {
+	id, err := uuid.Parse(subscriberID)
+	if err != nil {
+		return nil, fmt.Errorf("parse subscriber id: %w", err)
+	}
+
+	daos, err := r.followings.GetBySubscriber(id)
+	if err != nil {
+		return nil, fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	addresses, err := r.followings.GetAddressesBySubscriber(id)
+	if err != nil {
+		return nil, fmt.Errorf("get address subscriptions: %w", err)
+	}
+
+	followedDaos := make(map[uuid.UUID]struct{}, len(daos))
+	for _, sub := range daos {
+		followedDaos[sub.DaoID] = struct{}{}
+	}
+
+	followedAddresses := make(map[string]struct{}, len(addresses))
+	for _, sub := range addresses {
+		followedAddresses[sub.Address] = struct{}{}
+	}
+
+	types := make(map[Type]struct{}, len(fTypes))
+	for _, t := range fTypes {
+		types[t] = struct{}{}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []FeedItem
+	for i := range r.items {
+		item := &r.items[i]
+		if item.DeletedAt.Valid || !item.UpdatedAt.After(lastUpdatedAt) {
+			continue
+		}
+
+		if _, ok := types[item.Type]; len(types) > 0 && !ok {
+			continue
+		}
+
+		// votes are delivered by followed addresses, all other items by subscribed DAOs
+		if item.Type == TypeVote {
+			if _, ok := followedAddresses[item.Voter]; !ok {
+				continue
+			}
+		} else if _, ok := followedDaos[item.DaoID]; !ok {
+			continue
+		}
+
+		list = append(list, copyItem(item))
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].UpdatedAt.Before(list[j].UpdatedAt)
+	})
+
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list, nil
+}
+
+func (r *MemoryRepo) GetByFilters(filters []Filter) (FeedList, error) {
+	var (
+		matchers []func(item *FeedItem) bool
+		orders   []func(a, b *FeedItem) int
+		page     *PageFilter
+		now      = time.Now()
+	)
+
+	for _, f := range filters {
+		switch f := f.(type) {
+		case PageFilter:
+			page = &f
+		case SortedByActuality, SortedByCreated, OrderByTriggeredFilter:
+			orders = append(orders, orderOf(f))
+		default:
+			match, err := matcherOf(f, now)
+			if err != nil {
+				return FeedList{}, err
+			}
+
+			matchers = append(matchers, match)
+		}
+	}
+
+	r.mu.RLock()
+	var list []FeedItem
+	for i := range r.items {
+		if r.items[i].DeletedAt.Valid || !matchAll(&r.items[i], matchers) {
+			continue
+		}
+
+		list = append(list, copyItem(&r.items[i]))
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(list, func(i, j int) bool {
+		for _, cmp := range orders {
+			if c := cmp(&list[i], &list[j]); c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	total := int64(len(list))
+	if page != nil {
+		list = paginate(list, page.Offset, page.Limit)
+	}
+
+	return FeedList{
+		Items:      list,
+		TotalCount: total,
+	}, nil
+}
+
+// matcherOf returns the predicate with the same semantics as the filter condition in postgres
+func matcherOf(f Filter, now time.Time) (func(item *FeedItem) bool, error) {
+	switch f := f.(type) {
+	case DaoIDFilter:
+		return func(item *FeedItem) bool { return contains(f.IDs, item.DaoID.String()) }, nil
+	case TypeFilter:
+		return func(item *FeedItem) bool { return contains(f.Types, string(item.Type)) }, nil
+	case ActionFilter:
+		return func(item *FeedItem) bool { return contains(f.Actions, string(item.Action)) }, nil
+	case ActiveFilter:
+		return func(item *FeedItem) bool {
+			if item.VoteEnd == nil {
+				return false
+			}
+
+			return !item.VoteEnd.Before(now) == f.IsActive
+		}, nil
+	case SkipSpammed:
+		return func(item *FeedItem) bool { return item.Spam != nil && !*item.Spam }, nil
+	case SkipCanceled:
+		return func(item *FeedItem) bool { return item.State != nil && *item.State != "canceled" }, nil
+	case SkipDelegates:
+		return func(item *FeedItem) bool { return item.Type != TypeDelegate }, nil
+	case SkipVotes:
+		return func(item *FeedItem) bool { return item.Type != TypeVote }, nil
+	case VoterFilter:
+		return func(item *FeedItem) bool { return item.Type == TypeVote && contains(f.Voters, item.Voter) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported filter: %T", f)
+	}
+}
+
+func orderOf(f Filter) func(a, b *FeedItem) int {
+	switch f := f.(type) {
+	case SortedByActuality:
+		return func(a, b *FeedItem) int {
+			if c := compareInts(actualityPosition(a.State), actualityPosition(b.State)); c != 0 {
+				return c
+			}
+
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+	case SortedByCreated:
+		return func(a, b *FeedItem) int {
+			if strings.EqualFold(string(f.Direction), string(DirectionDesc)) {
+				return b.CreatedAt.Compare(a.CreatedAt)
+			}
+
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	default:
+		return func(a, b *FeedItem) int {
+			return b.TriggeredAt.Compare(a.TriggeredAt)
+		}
+	}
+}
+
+// actualityPosition returns the position of the state, unknown states are sorted last like nulls in postgres
+func actualityPosition(state *string) int {
+	if state != nil {
+		for i, s := range actualityOrder {
+			if s == *state {
+				return i
+			}
+		}
+	}
+
+	return len(actualityOrder)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func matchAll(item *FeedItem, matchers []func(item *FeedItem) bool) bool {
+	for _, match := range matchers {
+		if !match(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(list []string, value string) bool {
+	for _, el := range list {
+		if el == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// paginate applies the offset and the limit, negative limit means no limit
+func paginate(list []FeedItem, offset, limit int) []FeedItem {
+	if offset > len(list) {
+		offset = len(list)
+	}
+	if offset > 0 {
+		list = list[offset:]
+	}
+
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list
+}
+
+// WithRetentionLock runs fn, there is the only retention worker per memory repository
+func (r *MemoryRepo) WithRetentionLock(fn func() error) (bool, error) {
+	return true, fn()
+}
+
+func (r *MemoryRepo) CountExpired(fType Type, before time.Time, onlyActive bool) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for i := range r.items {
+		if isExpired(&r.items[i], fType, before, !onlyActive) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *MemoryRepo) DeleteExpired(fType Type, before time.Time, limit int, soft bool) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		deleted int64
+		kept    = r.items[:0]
+	)
+
+	for _, item := range r.items {
+		if (limit < 0 || deleted < int64(limit)) && isExpired(&item, fType, before, !soft) {
+			deleted++
+			if !soft {
+				continue
+			}
+
+			item.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		}
+
+		kept = append(kept, item)
+	}
+
+	r.items = kept
+
+	return deleted, nil
+}
+
+func (r *MemoryRepo) ArchiveExpired(fType Type, before time.Time, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		archived int64
+		kept     = r.items[:0]
+	)
+
+	for _, item := range r.items {
+		if (limit < 0 || archived < int64(limit)) && isExpired(&item, fType, before, true) {
+			archived++
+			r.archive = append(r.archive, item)
+
+			continue
+		}
+
+		kept = append(kept, item)
+	}
+
+	r.items = kept
+
+	return archived, nil
+}
+
+// Archived returns feed items moved to the archive
+func (r *MemoryRepo) Archived() []FeedItem {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]FeedItem, 0, len(r.archive))
+	for i := range r.archive {
+		list = append(list, copyItem(&r.archive[i]))
+	}
+
+	return list
+}
+
+func isExpired(item *FeedItem, fType Type, before time.Time, withDeleted bool) bool {
+	if item.DeletedAt.Valid && !withDeleted {
+		return false
+	}
+
+	return item.Type == fType && item.TriggeredAt.Before(before)
+}
+
+// CreateMonthPartition does nothing, there are no partitions in memory
+func (r *MemoryRepo) CreateMonthPartition(_ time.Time) error {
+	return nil
+}
+
+func (r *MemoryRepo) IsEventProcessed(key string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.processed[key]
+
+	return ok, nil
+}
+
+func (r *MemoryRepo) DeleteProcessedEventsBefore(before time.Time, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key, createdAt := range r.processed {
+		if limit >= 0 && deleted >= int64(limit) {
+			break
+		}
+
+		if createdAt.Before(before) {
+			delete(r.processed, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -3,6 +3,7 @@ package item
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
 
 	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
 )
@@ -16,3 +17,50 @@ var metricHandleHistogram = promauto.NewHistogramVec(
 		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .5, 1, 2.5, 5, 10},
 	}, []string{"type", "error"},
 )
+
+var metricSkippedEventsCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "feed_item",
+		Name:      "skipped_events_total",
+		Help:      "Count of skipped duplicated or stale events",
+	}, []string{"type", "reason"},
+)
+
+const (
+	skipReasonDuplicate = "duplicate"
+	skipReasonStale     = "stale"
+)
+
+func skipEvent(fType Type, reason string) {
+	metricSkippedEventsCounter.WithLabelValues(string(fType), reason).Inc()
+	log.Debug().Str("type", string(fType)).Str("reason", reason).Msg("event is skipped")
+}
+
+var metricRetentionCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "feed_item",
+		Name:      "retention_processed_total",
+		Help:      "Count of feed items removed or archived by retention policies",
+	}, []string{"type", "mode"},
+)
+
+var metricRetentionHistogram = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "feed_item",
+		Name:      "retention_duration_seconds",
+		Help:      "Retention policy execution duration seconds",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"type", "error"},
+)
+
+var metricBlockedCallbacksCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "feed_item",
+		Name:      "blocked_callbacks_total",
+		Help:      "Count of callbacks which are not published because the webhook address is not safe anymore",
+	},
+)
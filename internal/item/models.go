@@ -13,9 +13,11 @@ import (
 type Type string
 
 const (
-	TypeDao      Type = "dao"
-	TypeProposal Type = "proposal"
-	TypeDelegate Type = "delegate"
+	TypeDao        Type = "dao"
+	TypeProposal   Type = "proposal"
+	TypeDelegate   Type = "delegate"
+	TypeDiscussion Type = "discussion"
+	TypeVote       Type = "vote"
 
 	None                           TimelineAction = ""
 	DaoCreated                     TimelineAction = "dao.created"
@@ -33,6 +35,10 @@ const (
 	DelegateCreated                TimelineAction = "delegate.created"
 	DelegateDelegationExpired      TimelineAction = "delegate.delegation.expired"
 	DelegateDelegationExpiringSoon TimelineAction = "delegate.delegation.expiring_soon"
+	DiscussionCreated              TimelineAction = "discussion.created"
+	DiscussionReplied              TimelineAction = "discussion.replied"
+	DiscussionLinkedToProposal     TimelineAction = "discussion.linked_to_proposal"
+	VoteCreated                    TimelineAction = "vote.created"
 )
 
 type FeedItem struct {
@@ -45,11 +51,56 @@ type FeedItem struct {
 	DaoID        uuid.UUID
 	ProposalID   string
 	DiscussionID string
+	Voter        string // filled only for vote items
 	Type         Type
 	Action       TimelineAction
 
 	Snapshot json.RawMessage
 	Timeline Timeline `gorm:"serializer:json"`
+
+	// extracted from the snapshot on saving, nil if the snapshot doesn't contain the field
+	State     *string
+	Spam      *bool
+	VoteStart *time.Time
+	VoteEnd   *time.Time
+	Author    string
+}
+
+// snapshotColumns contains snapshot fields stored in the separate columns
+type snapshotColumns struct {
+	State  *string  `json:"state"`
+	Spam   *bool    `json:"spam"`
+	Start  *float64 `json:"start"`
+	End    *float64 `json:"end"`
+	Author string   `json:"author"`
+}
+
+// fillSnapshotColumns copies filterable fields of the snapshot to the feed item columns
+func (i *FeedItem) fillSnapshotColumns() error {
+	var columns snapshotColumns
+	if len(i.Snapshot) > 0 {
+		if err := json.Unmarshal(i.Snapshot, &columns); err != nil {
+			return err
+		}
+	}
+
+	i.State = columns.State
+	i.Spam = columns.Spam
+	i.VoteStart = unixToTime(columns.Start)
+	i.VoteEnd = unixToTime(columns.End)
+	i.Author = columns.Author
+
+	return nil
+}
+
+func unixToTime(ts *float64) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := time.Unix(int64(*ts), 0).UTC()
+
+	return &t
 }
 
 type Timeline []TimelineItem
@@ -0,0 +1,50 @@
+package item
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFillSnapshotColumns(t *testing.T) {
+	for name, tc := range map[string]struct {
+		snapshot string
+		check    func(t *testing.T, item *FeedItem)
+	}{
+		"proposal": {
+			snapshot: `{"state":"active","spam":false,"start":1700000000,"end":1700086400,"author":"0xabc"}`,
+			check: func(t *testing.T, item *FeedItem) {
+				require.NotNil(t, item.State)
+				require.Equal(t, "active", *item.State)
+				require.NotNil(t, item.Spam)
+				require.False(t, *item.Spam)
+				require.Equal(t, time.Unix(1700000000, 0).UTC(), *item.VoteStart)
+				require.Equal(t, time.Unix(1700086400, 0).UTC(), *item.VoteEnd)
+				require.Equal(t, "0xabc", item.Author)
+			},
+		},
+		"missing fields": {
+			snapshot: `{"name":"dao"}`,
+			check: func(t *testing.T, item *FeedItem) {
+				require.Nil(t, item.State)
+				require.Nil(t, item.Spam)
+				require.Nil(t, item.VoteStart)
+				require.Nil(t, item.VoteEnd)
+				require.Empty(t, item.Author)
+			},
+		},
+		"empty snapshot": {
+			check: func(t *testing.T, item *FeedItem) {
+				require.Nil(t, item.State)
+				require.Nil(t, item.VoteEnd)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			item := &FeedItem{Snapshot: []byte(tc.snapshot)}
+			require.NoError(t, item.fillSnapshotColumns())
+			tc.check(t, item)
+		})
+	}
+}
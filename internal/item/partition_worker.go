@@ -0,0 +1,53 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EnsurePartitions creates monthly partitions of feed items for the current month and the months ahead
+func (s *Service) EnsurePartitions(now time.Time, monthsAhead int) error {
+	now = now.UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= monthsAhead; i++ {
+		if err := s.repo.CreateMonthPartition(month.AddDate(0, i, 0)); err != nil {
+			return fmt.Errorf("create partition %s: %w", partitionName(month.AddDate(0, i, 0)), err)
+		}
+	}
+
+	return nil
+}
+
+// PartitionWorker periodically pre-creates partitions of feed items,
+// items without the partition are stored in the default one
+type PartitionWorker struct {
+	service     *Service
+	monthsAhead int
+	interval    time.Duration
+}
+
+func NewPartitionWorker(s *Service, monthsAhead int, interval time.Duration) *PartitionWorker {
+	return &PartitionWorker{
+		service:     s,
+		monthsAhead: monthsAhead,
+		interval:    interval,
+	}
+}
+
+func (w *PartitionWorker) Start(ctx context.Context) error {
+	for {
+		if err := w.service.EnsurePartitions(time.Now(), w.monthsAhead); err != nil {
+			log.Error().Err(err).Msg("ensure feed items partitions")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.interval):
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package item
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type partitionRepo struct {
+	DataProvider
+
+	months []string
+}
+
+func (r *partitionRepo) CreateMonthPartition(month time.Time) error {
+	r.months = append(r.months, partitionName(month))
+
+	return nil
+}
+
+func TestUnitEnsurePartitions(t *testing.T) {
+	repo := &partitionRepo{}
+	s := newTestService(t, repo)
+
+	now := time.Date(2026, time.November, 30, 23, 0, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+	require.NoError(t, s.EnsurePartitions(now, 2))
+
+	// the time is already in December by UTC
+	require.Equal(t, []string{"feed_items_y2026m12", "feed_items_y2027m01", "feed_items_y2027m02"}, repo.months)
+}
@@ -0,0 +1,48 @@
+package item
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	processedEventsBatchSize = 1000
+	processedEventsInterval  = time.Hour
+)
+
+// ProcessedEventsWorker periodically removes keys of processed events older than the retention period.
+// The retention has to cover the longest possible redelivery delay of the consumers.
+type ProcessedEventsWorker struct {
+	service   *Service
+	retention time.Duration
+}
+
+func NewProcessedEventsWorker(s *Service, retention time.Duration) *ProcessedEventsWorker {
+	return &ProcessedEventsWorker{
+		service:   s,
+		retention: retention,
+	}
+}
+
+func (w *ProcessedEventsWorker) Start(ctx context.Context) error {
+	for {
+		before := time.Now().Add(-w.retention)
+		deleted, err := w.service.DeleteProcessedEventsBefore(before, processedEventsBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("processed events retention")
+		}
+
+		log.Info().
+			Int64("deleted", deleted).
+			Time("before", before).
+			Msg("processed events retention finished")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(processedEventsInterval):
+		}
+	}
+}
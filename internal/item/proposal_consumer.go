@@ -72,6 +72,16 @@ func (c *ProposalConsumer) handler(action string) pevents.ProposalHandler {
 				Observe(time.Since(start).Seconds())
 		}(time.Now())
 
+		key, processed, err := c.service.checkEvent(action, payload)
+		if err != nil {
+			return err
+		}
+
+		if processed {
+			skipEvent(TypeProposal, skipReasonDuplicate)
+			return nil
+		}
+
 		item, err := c.service.GetProposalItem(context.TODO(), payload.ID)
 		if err != nil {
 			return err
@@ -106,17 +116,31 @@ func (c *ProposalConsumer) handler(action string) pevents.ProposalHandler {
 			if err != nil {
 				return err
 			}
+
+			item.DiscussionID, err = c.service.GetLinkedDiscussionID(context.TODO(), payload.ID)
+			if err != nil {
+				return fmt.Errorf("get linked discussion: %w", err)
+			}
 		} else {
 			sn, err := json.Marshal(payload)
 			if err != nil {
 				return fmt.Errorf("cant marshal payload: %w", err)
 			}
 
-			item.Snapshot = sn
+			// the late event must not overwrite the newer snapshot, but unique actions are still added to the timeline
+			if isStaleSnapshot(item.Snapshot, sn) {
+				if !cfg.isUnique {
+					skipEvent(TypeProposal, skipReasonStale)
+					return nil
+				}
+			} else {
+				item.Snapshot = sn
+			}
+
 			item.Timeline = timeline
 		}
 
-		err = c.service.HandleItem(context.TODO(), item, sendUpdates)
+		err = c.service.HandleEvent(context.TODO(), key, item, sendUpdates)
 		if err != nil {
 			log.Error().Err(err).Msg("process proposal")
 			return err
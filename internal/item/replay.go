@@ -0,0 +1,67 @@
+package item
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Subjects returns subjects handled by the consumer
+func (c *DaoConsumer) Subjects() []string {
+	return daoSubjects
+}
+
+// HandleMessage decodes the raw message of the subject and handles it the same way as the consumed one
+func (c *DaoConsumer) HandleMessage(subject string, data []byte) error {
+	return handleMessage(subject, data, c.handler(subject))
+}
+
+// Subjects returns subjects handled by the consumer
+func (c *ProposalConsumer) Subjects() []string {
+	return mapSubjects(proposalEvents)
+}
+
+// HandleMessage decodes the raw message of the subject and handles it the same way as the consumed one
+func (c *ProposalConsumer) HandleMessage(subject string, data []byte) error {
+	return handleMessage(subject, data, c.handler(subject))
+}
+
+// Subjects returns subjects handled by the consumer
+func (c *DelegatesConsumer) Subjects() []string {
+	return mapSubjects(delegateEvents)
+}
+
+// HandleMessage decodes the raw message of the subject and handles it the same way as the consumed one
+func (c *DelegatesConsumer) HandleMessage(subject string, data []byte) error {
+	return handleMessage(subject, data, c.handler(subject))
+}
+
+// Subjects returns subjects handled by the consumer
+func (c *DiscussionConsumer) Subjects() []string {
+	return mapSubjects(discussionEvents)
+}
+
+// HandleMessage decodes the raw message of the subject and handles it the same way as the consumed one
+func (c *DiscussionConsumer) HandleMessage(subject string, data []byte) error {
+	return handleMessage(subject, data, c.handler(subject))
+}
+
+func handleMessage[T any, H ~func(T) error](subject string, data []byte, handler H) error {
+	var payload T
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("unmarshal %s payload: %w", subject, err)
+	}
+
+	return handler(payload)
+}
+
+func mapSubjects[T any](events map[string]T) []string {
+	subjects := make([]string, 0, len(events))
+	for subject := range events {
+		subjects = append(subjects, subject)
+	}
+
+	sort.Strings(subjects)
+
+	return subjects
+}
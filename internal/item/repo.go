@@ -1,11 +1,16 @@
 package item
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/pglock"
 )
 
 var emptyID uuid.UUID
@@ -18,7 +23,9 @@ func NewRepo(conn *gorm.DB) *Repo {
 	return &Repo{conn: conn}
 }
 
-func (r *Repo) Save(item *FeedItem) error {
+// Save stores the feed item and its outbox messages in the one transaction.
+// Non-empty event key is recorded as processed, errEventProcessed is returned if the key already exists.
+func (r *Repo) Save(item *FeedItem, eventKey string, messages ...outbox.Message) error {
 	var (
 		_ = item.DaoID
 		_ = item.ProposalID
@@ -30,66 +37,253 @@ func (r *Repo) Save(item *FeedItem) error {
 		item.ID = uuid.New()
 	}
 
-	conn := r.conn
-	// there is no unique key for delegate type
-	if item.Type != TypeDelegate {
-		conn = conn.Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "dao_id"},
-				{Name: "proposal_id"},
-				{Name: "type"},
-				{Name: "action"},
-			},
-			TargetWhere: clause.Where{Exprs: []clause.Expression{
-				clause.Expr{
-					SQL: "type <> 'delegate'::text",
-				},
-			}},
-			UpdateAll: true,
-		})
-	}
-
-	err := conn.
-		Create(item).
+	if err := item.fillSnapshotColumns(); err != nil {
+		return fmt.Errorf("fill snapshot columns: %w", err)
+	}
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		if eventKey != "" {
+			res := tx.
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&ProcessedEvent{Key: eventKey})
+			if res.Error != nil {
+				return res.Error
+			}
+
+			if res.RowsAffected == 0 {
+				return errEventProcessed
+			}
+		}
+
+		if err := resolveExisting(tx, item); err != nil {
+			return fmt.Errorf("resolve existing item: %w", err)
+		}
+
+		err := tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}, {Name: "created_at"}},
+				UpdateAll: true,
+			}).
+			Create(item).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return outbox.Create(tx, messages)
+	})
+}
+
+// itemKey is the unique key of the feed item, the partitioned table can't have unique indexes without the partition key
+type itemKey struct {
+	Key           string `gorm:"primarykey"`
+	ItemID        uuid.UUID
+	ItemCreatedAt time.Time
+}
+
+func (itemKey) TableName() string {
+	return "feed_item_keys"
+}
+
+// uniqueKey returns the unique key of the feed item or the empty string if the type has no unique key
+func uniqueKey(item *FeedItem) string {
+	switch item.Type {
+	case TypeDelegate:
+		// there is no unique key for delegate type
+		return ""
+	case TypeVote:
+		// the voter could change the choice, so we keep only the last vote
+		return fmt.Sprintf("%s/%s/%s", item.Type, item.ProposalID, item.Voter)
+	case TypeDiscussion:
+		// discussion could be linked to the proposal later, so proposal id is not a part of the key
+		return fmt.Sprintf("%s/%s", item.Type, item.DiscussionID)
+	default:
+		return fmt.Sprintf("%s/%s/%s/%s", item.Type, item.DaoID, item.ProposalID, item.Action)
+	}
+}
+
+// resolveExisting takes the identifier of the stored item with the same unique key.
+// The key is stored in the same transaction, the concurrent transaction with the same key waits for it on insert.
+func resolveExisting(tx *gorm.DB, item *FeedItem) error {
+	key := uniqueKey(item)
+	if key == "" {
+		return nil
+	}
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	err := tx.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&itemKey{Key: key, ItemID: item.ID, ItemCreatedAt: item.CreatedAt}).
+		Error
+	if err != nil {
+		return err
+	}
+
+	var existing itemKey
+	if err = tx.Where("key = ?", key).Take(&existing).Error; err != nil {
+		return err
+	}
+
+	item.ID = existing.ItemID
+	item.CreatedAt = existing.ItemCreatedAt
+
+	return nil
+}
+
+// CreateMonthPartition creates the partition of feed items for the month if it doesn't exist.
+// Items of the month stored in the default partition are moved to the new one,
+// otherwise the partition can't be attached.
+func (r *Repo) CreateMonthPartition(month time.Time) error {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	name := partitionName(start)
+
+	return r.conn.Transaction(func(tx *gorm.DB) error {
+		var exists bool
+		if err := tx.Raw("select to_regclass(?) is not null", name).Scan(&exists).Error; err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		// DDL doesn't support bind parameters, all values are generated from the time
+		steps := []string{
+			fmt.Sprintf("create table %s (like feed_items including defaults including constraints)", name),
+			fmt.Sprintf("lock table %s in access exclusive mode", defaultPartition),
+			fmt.Sprintf("insert into %s select * from %s where created_at >= '%s' and created_at < '%s'",
+				name, defaultPartition, start.Format(partitionBoundLayout), end.Format(partitionBoundLayout)),
+			fmt.Sprintf("delete from %s where created_at >= '%s' and created_at < '%s'",
+				defaultPartition, start.Format(partitionBoundLayout), end.Format(partitionBoundLayout)),
+			fmt.Sprintf("alter table feed_items attach partition %s for values from ('%s') to ('%s')",
+				name, start.Format(partitionBoundLayout), end.Format(partitionBoundLayout)),
+		}
+
+		for _, step := range steps {
+			if err := tx.Exec(step).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// defaultPartition keeps feed items created out of ranges of month partitions
+const defaultPartition = "feed_items_default"
+
+const partitionBoundLayout = "2006-01-02 15:04:05-07"
+
+func partitionName(month time.Time) string {
+	return fmt.Sprintf("feed_items_y%04dm%02d", month.Year(), int(month.Month()))
+}
+
+func (r *Repo) GetByID(id uuid.UUID) (*FeedItem, error) {
+	var item FeedItem
+
+	err := r.conn.
+		Where("id = ?", id).
+		First(&item).
 		Error
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	return &item, nil
 }
 
 func (r *Repo) GetDaoItem(id uuid.UUID) (*FeedItem, error) {
 	var (
 		item FeedItem
 		_    = item.DaoID
+		_    = item.Type
 	)
 
-	err := r.conn.Where("dao_id = ?", id).First(&item).Error
+	err := r.conn.
+		Where("dao_id = ?", id).
+		Where("type = ?", TypeDao).
+		First(&item).
+		Error
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	return &item, nil
 }
 
 func (r *Repo) GetProposalItem(id string) (*FeedItem, error) {
 	var (
 		item FeedItem
 		_    = item.ProposalID
+		_    = item.Type
+	)
+
+	err := r.conn.
+		Where("proposal_id = ?", id).
+		Where("type = ?", TypeProposal).
+		First(&item).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (r *Repo) GetDiscussionItem(id string) (*FeedItem, error) {
+	var (
+		item FeedItem
+		_    = item.DiscussionID
+		_    = item.Type
 	)
 
-	err := r.conn.Where("proposal_id = ?", id).First(&item).Error
+	err := r.conn.
+		Where("discussion_id = ?", id).
+		Where("type = ?", TypeDiscussion).
+		First(&item).
+		Error
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	return &item, nil
+}
+
+func (r *Repo) GetProposalDiscussionItem(proposalID string) (*FeedItem, error) {
+	var (
+		item FeedItem
+		_    = item.ProposalID
+		_    = item.Type
+	)
+
+	err := r.conn.
+		Where("proposal_id = ?", proposalID).
+		Where("type = ?", TypeDiscussion).
+		First(&item).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
 }
 
 func (r *Repo) GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt time.Time, limit int) ([]FeedItem, error) {
-	var feedItems []FeedItem
+	var (
+		feedItems []FeedItem
+		dummy     FeedItem
+		_         = dummy.Voter
+	)
 
+	// votes are delivered by followed addresses, all other items by subscribed DAOs
 	query := r.conn.
-		Joins("JOIN subscriptions ON subscriptions.dao_id = feed_items.dao_id").
-		Where("subscriptions.subscriber_id = ?", subscriberID).
+		Where(`((feed_items.type <> ? and feed_items.dao_id in (select dao_id from subscriptions where subscriber_id = ? and deleted_at is null))
+			or (feed_items.type = ? and feed_items.voter in (select address from address_subscriptions where subscriber_id = ? and deleted_at is null)))`,
+			TypeVote, subscriberID, TypeVote, subscriberID,
+		).
 		Where("feed_items.updated_at > ?", lastUpdatedAt)
 
 	if len(fTypes) > 0 {
@@ -107,6 +301,161 @@ func (r *Repo) GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt ti
 	return feedItems, nil
 }
 
+// WithRetentionLock runs fn holding the retention lock, it returns false without calling fn
+// if the retention is run by another replica
+func (r *Repo) WithRetentionLock(fn func() error) (bool, error) {
+	return pglock.WithSessionLock(context.Background(), r.conn, pglock.ItemsRetentionKey, fn)
+}
+
+// CountExpired returns count of feed items of the type without activity since the time,
+// with onlyActive soft deleted items are not counted
+func (r *Repo) CountExpired(fType Type, before time.Time, onlyActive bool) (int64, error) {
+	var count int64
+	err := expired(r.conn, fType, before, !onlyActive).
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// DeleteExpired removes the batch of feed items of the type without activity since the time
+func (r *Repo) DeleteExpired(fType Type, before time.Time, limit int, soft bool) (int64, error) {
+	if soft {
+		ids := expired(r.conn, fType, before, false).
+			Select("id").
+			Limit(limit)
+
+		res := r.conn.
+			Where("id in (?)", ids).
+			Delete(&FeedItem{})
+
+		return res.RowsAffected, res.Error
+	}
+
+	var deleted int64
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := expired(tx, fType, before, true).
+			Limit(limit).
+			Pluck("id", &ids).
+			Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		res := tx.
+			Unscoped().
+			Where("id in ?", ids).
+			Delete(&FeedItem{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+
+		// the item with the same key could be created again
+		return deleteKeys(tx, ids)
+	})
+
+	return deleted, err
+}
+
+func deleteKeys(tx *gorm.DB, ids []uuid.UUID) error {
+	return tx.
+		Where("item_id in ?", ids).
+		Delete(&itemKey{}).
+		Error
+}
+
+// ArchiveExpired moves the batch of feed items of the type without activity since the time to the archive table
+func (r *Repo) ArchiveExpired(fType Type, before time.Time, limit int) (int64, error) {
+	var archived int64
+	err := r.conn.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := expired(tx, fType, before, true).
+			Limit(limit).
+			Pluck("id", &ids).
+			Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		err = tx.Exec(`insert into feed_items_archive (id, type, dao_id, triggered_at, archived_at, data)
+			select f.id, f.type, f.dao_id, f.triggered_at, now(), to_jsonb(f)
+			from feed_items f
+			where f.id in ?
+			on conflict (id) do nothing`, ids).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.
+			Unscoped().
+			Where("id in ?", ids).
+			Delete(&FeedItem{})
+		if res.Error != nil {
+			return res.Error
+		}
+		archived = res.RowsAffected
+
+		return deleteKeys(tx, ids)
+	})
+
+	return archived, err
+}
+
+func expired(conn *gorm.DB, fType Type, before time.Time, withDeleted bool) *gorm.DB {
+	var (
+		dummy FeedItem
+		_     = dummy.Type
+		_     = dummy.TriggeredAt
+	)
+
+	conn = conn.Model(&FeedItem{})
+	if withDeleted {
+		conn = conn.Unscoped()
+	}
+
+	return conn.
+		Where("type = ?", fType).
+		Where("triggered_at < ?", before)
+}
+
+func (r *Repo) IsEventProcessed(key string) (bool, error) {
+	var (
+		dummy ProcessedEvent
+		_     = dummy.Key
+		count int64
+	)
+
+	err := r.conn.
+		Model(&ProcessedEvent{}).
+		Where("key = ?", key).
+		Count(&count).
+		Error
+
+	return count > 0, err
+}
+
+func (r *Repo) DeleteProcessedEventsBefore(before time.Time, limit int) (int64, error) {
+	var (
+		dummy ProcessedEvent
+		_     = dummy.Key
+		_     = dummy.CreatedAt
+	)
+
+	keys := r.conn.
+		Model(&ProcessedEvent{}).
+		Select("key").
+		Where("created_at < ?", before).
+		Limit(limit)
+
+	res := r.conn.
+		Where("key in (?)", keys).
+		Delete(&ProcessedEvent{})
+
+	return res.RowsAffected, res.Error
+}
+
 func (r *Repo) GetByFilters(filters []Filter) (FeedList, error) {
 	db := r.conn.Model(&FeedItem{})
 	for _, f := range filters {
@@ -0,0 +1,427 @@
+package item
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+// repoFactory creates the empty feed items repository and the subscriptions repository it reads followings from
+type repoFactory func(t *testing.T) (DataProvider, subscription.DataProvider)
+
+func TestUnitMemoryRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(_ *testing.T) (DataProvider, subscription.DataProvider) {
+		subscriptions := subscription.NewMemoryRepo()
+
+		return NewMemoryRepo(subscriptions, outbox.NewMemoryRepo()), subscriptions
+	})
+}
+
+func TestIntegrationRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(t *testing.T) (DataProvider, subscription.DataProvider) {
+		db := testdb.Open(t,
+			"feed_items",
+			"feed_item_keys",
+			"feed_items_archive",
+			"processed_events",
+			"outbox_messages",
+			"subscriptions",
+			"address_subscriptions",
+		)
+
+		return NewRepo(db), subscription.NewRepo(db)
+	})
+}
+
+// testRepoConformance checks the behaviour every feed items repository has to follow
+func testRepoConformance(t *testing.T, factory repoFactory) {
+	t.Run("save and get", func(t *testing.T) {
+		repo, _ := factory(t)
+		daoID := uuid.New()
+
+		_, err := repo.GetDaoItem(daoID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		dao := testItem(TypeDao, daoID, "", `{"name":"dao"}`)
+		require.NoError(t, repo.Save(dao, ""))
+		require.NotEqual(t, uuid.Nil, dao.ID)
+
+		stored, err := repo.GetDaoItem(daoID)
+		require.NoError(t, err)
+		require.Equal(t, dao.ID, stored.ID)
+		require.JSONEq(t, `{"name":"dao"}`, string(stored.Snapshot))
+		require.Len(t, stored.Timeline, 1)
+
+		proposal := testItem(TypeProposal, daoID, "proposal", `{"state":"active"}`)
+		require.NoError(t, repo.Save(proposal, ""))
+
+		stored, err = repo.GetProposalItem("proposal")
+		require.NoError(t, err)
+		require.Equal(t, proposal.ID, stored.ID)
+		require.Equal(t, "active", *stored.State)
+
+		discussion := testItem(TypeDiscussion, daoID, "proposal", `{}`)
+		discussion.DiscussionID = "discussion"
+		require.NoError(t, repo.Save(discussion, ""))
+
+		stored, err = repo.GetDiscussionItem("discussion")
+		require.NoError(t, err)
+		require.Equal(t, discussion.ID, stored.ID)
+
+		stored, err = repo.GetProposalDiscussionItem("proposal")
+		require.NoError(t, err)
+		require.Equal(t, discussion.ID, stored.ID)
+
+		stored, err = repo.GetByID(proposal.ID)
+		require.NoError(t, err)
+		require.Equal(t, "proposal", stored.ProposalID)
+
+		_, err = repo.GetByID(uuid.New())
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("unique keys", func(t *testing.T) {
+		repo, _ := factory(t)
+		daoID := uuid.New()
+
+		first := testItem(TypeProposal, daoID, "proposal", `{"state":"pending"}`)
+		require.NoError(t, repo.Save(first, ""))
+
+		second := testItem(TypeProposal, daoID, "proposal", `{"state":"active"}`)
+		require.NoError(t, repo.Save(second, ""))
+		require.Equal(t, first.ID, second.ID)
+
+		stored, err := repo.GetProposalItem("proposal")
+		require.NoError(t, err)
+		require.Equal(t, "active", *stored.State)
+
+		vote := testItem(TypeVote, daoID, "proposal", `{"choice":1}`)
+		vote.Voter = "0xvoter"
+		require.NoError(t, repo.Save(vote, ""))
+
+		revote := testItem(TypeVote, daoID, "proposal", `{"choice":2}`)
+		revote.Voter = "0xvoter"
+		require.NoError(t, repo.Save(revote, ""))
+		require.Equal(t, vote.ID, revote.ID)
+
+		first = testItem(TypeDelegate, daoID, "proposal", `{}`)
+		require.NoError(t, repo.Save(first, ""))
+
+		second = testItem(TypeDelegate, daoID, "proposal", `{}`)
+		require.NoError(t, repo.Save(second, ""))
+		require.NotEqual(t, first.ID, second.ID)
+
+		list, err := repo.GetByFilters([]Filter{TypeFilter{Types: []string{string(TypeVote), string(TypeDelegate)}}})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, list.TotalCount)
+	})
+
+	t.Run("processed events", func(t *testing.T) {
+		repo, _ := factory(t)
+		daoID := uuid.New()
+
+		processed, err := repo.IsEventProcessed("key")
+		require.NoError(t, err)
+		require.False(t, processed)
+
+		require.NoError(t, repo.Save(testItem(TypeDao, daoID, "", `{}`), "key"))
+
+		err = repo.Save(testItem(TypeDao, daoID, "", `{}`), "key")
+		require.ErrorIs(t, err, errEventProcessed)
+
+		processed, err = repo.IsEventProcessed("key")
+		require.NoError(t, err)
+		require.True(t, processed)
+
+		deleted, err := repo.DeleteProcessedEventsBefore(time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		deleted, err = repo.DeleteProcessedEventsBefore(time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		processed, err = repo.IsEventProcessed("key")
+		require.NoError(t, err)
+		require.False(t, processed)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		repo, _ := factory(t)
+		testFiltersConformance(t, repo)
+	})
+
+	t.Run("last items", func(t *testing.T) {
+		repo, subscriptions := factory(t)
+
+		var (
+			subscriberID = uuid.New()
+			followedDao  = uuid.New()
+			otherDao     = uuid.New()
+			start        = time.Now().Add(-time.Second)
+		)
+
+		require.NoError(t, subscriptions.Create(subscription.Subscription{SubscriberID: subscriberID, DaoID: followedDao}))
+		require.NoError(t, subscriptions.CreateAddress(subscription.AddressSubscription{SubscriberID: subscriberID, Address: "0xfollowed"}))
+
+		followed := testItem(TypeProposal, followedDao, "followed", `{}`)
+		require.NoError(t, repo.Save(followed, ""))
+		require.NoError(t, repo.Save(testItem(TypeProposal, otherDao, "other", `{}`), ""))
+
+		vote := testItem(TypeVote, otherDao, "other", `{}`)
+		vote.Voter = "0xfollowed"
+		require.NoError(t, repo.Save(vote, ""))
+
+		notFollowedVote := testItem(TypeVote, followedDao, "followed", `{}`)
+		notFollowedVote.Voter = "0xother"
+		require.NoError(t, repo.Save(notFollowedVote, ""))
+
+		list, err := repo.GetLastItems(subscriberID.String(), nil, start, 10)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{followed.ID, vote.ID}, itemIDs(list))
+
+		list, err = repo.GetLastItems(subscriberID.String(), []Type{TypeVote}, start, 10)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{vote.ID}, itemIDs(list))
+
+		list, err = repo.GetLastItems(subscriberID.String(), nil, start, 1)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{followed.ID}, itemIDs(list))
+
+		list, err = repo.GetLastItems(subscriberID.String(), nil, time.Now().Add(time.Hour), 10)
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		repo, _ := factory(t)
+
+		var (
+			daoID  = uuid.New()
+			now    = time.Now()
+			before = now.Add(-time.Hour)
+		)
+
+		for i := 0; i < 3; i++ {
+			vote := testItem(TypeVote, daoID, "proposal", `{}`)
+			vote.Voter = fmt.Sprintf("0xold%d", i)
+			vote.TriggeredAt = now.Add(-2 * time.Hour)
+			require.NoError(t, repo.Save(vote, ""))
+		}
+
+		fresh := testItem(TypeVote, daoID, "proposal", `{}`)
+		fresh.Voter = "0xfresh"
+		fresh.TriggeredAt = now
+		require.NoError(t, repo.Save(fresh, ""))
+
+		count, err := repo.CountExpired(TypeVote, before, true)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, count)
+
+		deleted, err := repo.DeleteExpired(TypeVote, before, 1, true)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		count, err = repo.CountExpired(TypeVote, before, true)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+
+		count, err = repo.CountExpired(TypeVote, before, false)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, count)
+
+		archived, err := repo.ArchiveExpired(TypeVote, before, 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, archived)
+
+		count, err = repo.CountExpired(TypeVote, before, false)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		list, err := repo.GetByFilters([]Filter{TypeFilter{Types: []string{string(TypeVote)}}})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{fresh.ID}, itemIDs(list.Items))
+
+		deleted, err = repo.DeleteExpired(TypeVote, now.Add(time.Hour), 10, false)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		// the key of the removed item could be used again
+		again := testItem(TypeVote, daoID, "proposal", `{}`)
+		again.Voter = "0xfresh"
+		require.NoError(t, repo.Save(again, ""))
+		require.NotEqual(t, fresh.ID, again.ID)
+
+		stored, err := repo.GetByID(again.ID)
+		require.NoError(t, err)
+		require.Equal(t, "0xfresh", stored.Voter)
+	})
+}
+
+func TestIntegrationCreateMonthPartitionMovesDefaultItems(t *testing.T) {
+	db := testdb.Open(t, "feed_items", "feed_item_keys")
+	month := time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	dropPartition := func() {
+		require.NoError(t, db.Exec("drop table if exists "+partitionName(month)).Error)
+	}
+	dropPartition()
+	t.Cleanup(dropPartition)
+
+	repo := NewRepo(db)
+	item := testItem(TypeProposal, uuid.New(), "proposal", `{}`)
+	item.CreatedAt = month.Add(24 * time.Hour)
+	require.NoError(t, repo.Save(item, ""))
+
+	require.NoError(t, repo.CreateMonthPartition(month))
+	require.NoError(t, repo.CreateMonthPartition(month))
+
+	var count int64
+	require.NoError(t, db.Table(partitionName(month)).Where("id = ?", item.ID).Count(&count).Error)
+	require.EqualValues(t, 1, count)
+	require.NoError(t, db.Table(defaultPartition).Where("id = ?", item.ID).Count(&count).Error)
+	require.Zero(t, count)
+
+	stored, err := repo.GetByID(item.ID)
+	require.NoError(t, err)
+	require.Equal(t, "proposal", stored.ProposalID)
+}
+
+func testFiltersConformance(t *testing.T, repo DataProvider) {
+	var (
+		daoID      = uuid.New()
+		otherDaoID = uuid.New()
+		now        = time.Now()
+		future     = now.Add(24 * time.Hour).Unix()
+		past       = now.Add(-24 * time.Hour).Unix()
+		items      = make(map[string]*FeedItem)
+	)
+
+	add := func(name string, fType Type, dao uuid.UUID, snapshot string, modify ...func(item *FeedItem)) {
+		item := testItem(fType, dao, name, snapshot)
+		item.CreatedAt = now.Add(time.Duration(len(items)) * time.Minute)
+		item.TriggeredAt = now.Add(-time.Duration(len(items)) * time.Minute)
+		for _, m := range modify {
+			m(item)
+		}
+
+		require.NoError(t, repo.Save(item, ""))
+		items[name] = item
+	}
+
+	add("active", TypeProposal, daoID, fmt.Sprintf(`{"state":"active","spam":false,"end":%d}`, future))
+	add("pending", TypeProposal, daoID, fmt.Sprintf(`{"state":"pending","spam":false,"end":%d}`, future))
+	add("succeeded", TypeProposal, daoID, fmt.Sprintf(`{"state":"succeeded","spam":false,"end":%d}`, past))
+	add("canceled", TypeProposal, daoID, fmt.Sprintf(`{"state":"canceled","spam":false,"end":%d}`, past))
+	add("spam", TypeProposal, otherDaoID, fmt.Sprintf(`{"state":"active","spam":true,"end":%d}`, future))
+	add("delegate", TypeDelegate, daoID, `{}`, func(item *FeedItem) { item.Action = DelegateCreated })
+	add("vote", TypeVote, otherDaoID, `{}`, func(item *FeedItem) { item.Voter = "0xvoter" })
+
+	for name, tc := range map[string]struct {
+		filters  []Filter
+		expected []string
+		total    int64
+	}{
+		"dao": {
+			filters:  []Filter{DaoIDFilter{IDs: []string{otherDaoID.String()}}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"spam", "vote"},
+		},
+		"type": {
+			filters:  []Filter{TypeFilter{Types: []string{string(TypeDelegate), string(TypeVote)}}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"delegate", "vote"},
+		},
+		"action": {
+			filters:  []Filter{ActionFilter{Actions: []string{string(DelegateCreated)}}},
+			expected: []string{"delegate"},
+		},
+		"active": {
+			filters:  []Filter{ActiveFilter{IsActive: true}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"active", "pending", "spam"},
+		},
+		"inactive": {
+			filters:  []Filter{ActiveFilter{IsActive: false}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"succeeded", "canceled"},
+		},
+		"skip spammed and canceled": {
+			filters:  []Filter{SkipSpammed{}, SkipCanceled{}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"active", "pending", "succeeded"},
+		},
+		"skip delegates and votes": {
+			filters:  []Filter{SkipDelegates{}, SkipVotes{}, SortedByCreated{Direction: DirectionDesc}},
+			expected: []string{"spam", "canceled", "succeeded", "pending", "active"},
+		},
+		"voter": {
+			filters:  []Filter{VoterFilter{Voters: []string{"0xvoter", "0xother"}}},
+			expected: []string{"vote"},
+		},
+		"actuality": {
+			filters:  []Filter{TypeFilter{Types: []string{string(TypeProposal)}}, SortedByActuality{}},
+			expected: []string{"spam", "active", "pending", "succeeded", "canceled"},
+		},
+		"triggered": {
+			filters:  []Filter{SkipVotes{}, OrderByTriggeredFilter{}},
+			expected: []string{"active", "pending", "succeeded", "canceled", "spam", "delegate"},
+		},
+		"page": {
+			filters:  []Filter{PageFilter{Offset: 1, Limit: 2}, SortedByCreated{Direction: DirectionAsc}},
+			expected: []string{"pending", "succeeded"},
+			total:    7,
+		},
+		"page out of range": {
+			filters: []Filter{PageFilter{Offset: 10, Limit: 2}, SortedByCreated{Direction: DirectionAsc}},
+			total:   7,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			list, err := repo.GetByFilters(tc.filters)
+			require.NoError(t, err)
+
+			expected := make([]uuid.UUID, 0, len(tc.expected))
+			for _, n := range tc.expected {
+				expected = append(expected, items[n].ID)
+			}
+
+			total := tc.total
+			if total == 0 {
+				total = int64(len(tc.expected))
+			}
+
+			require.Equal(t, expected, itemIDs(list.Items))
+			require.Equal(t, total, list.TotalCount)
+		})
+	}
+}
+
+func testItem(fType Type, daoID uuid.UUID, proposalID, snapshot string) *FeedItem {
+	action := TimelineAction(fmt.Sprintf("%s.created", fType))
+
+	return &FeedItem{
+		DaoID:       daoID,
+		ProposalID:  proposalID,
+		Type:        fType,
+		Action:      action,
+		TriggeredAt: time.Now(),
+		Snapshot:    []byte(snapshot),
+		Timeline: Timeline{{
+			CreatedAt: time.Now(),
+			Action:    action,
+		}},
+	}
+}
+
+func itemIDs(list []FeedItem) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+
+	return ids
+}
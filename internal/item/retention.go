@@ -0,0 +1,142 @@
+package item
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+type RetentionMode string
+
+const (
+	RetentionModeDelete     RetentionMode = "delete"
+	RetentionModeSoftDelete RetentionMode = "soft_delete"
+	RetentionModeArchive    RetentionMode = "archive"
+)
+
+// RetentionPolicy removes feed items of the type without any activity longer than the max age
+type RetentionPolicy struct {
+	Type   Type
+	MaxAge time.Duration
+	Mode   RetentionMode
+}
+
+// ApplyRetention processes expired feed items of the policy in batches and returns count of processed items.
+// In the dry run mode expired items are only counted.
+func (s *Service) ApplyRetention(policy RetentionPolicy, batchSize int, dryRun bool) (int64, error) {
+	before := time.Now().Add(-policy.MaxAge)
+
+	if dryRun {
+		return s.repo.CountExpired(policy.Type, before, policy.Mode == RetentionModeSoftDelete)
+	}
+
+	var total int64
+	for {
+		var (
+			processed int64
+			err       error
+		)
+
+		switch policy.Mode {
+		case RetentionModeDelete:
+			processed, err = s.repo.DeleteExpired(policy.Type, before, batchSize, false)
+		case RetentionModeSoftDelete:
+			processed, err = s.repo.DeleteExpired(policy.Type, before, batchSize, true)
+		case RetentionModeArchive:
+			processed, err = s.repo.ArchiveExpired(policy.Type, before, batchSize)
+		default:
+			return total, fmt.Errorf("unknown retention mode: %s", policy.Mode)
+		}
+
+		if err != nil {
+			return total, fmt.Errorf("%s %s items: %w", policy.Mode, policy.Type, err)
+		}
+
+		total += processed
+		metricRetentionCounter.WithLabelValues(string(policy.Type), string(policy.Mode)).Add(float64(processed))
+
+		if processed < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// WithRetentionLock runs fn if no other replica applies retention policies at the moment
+func (s *Service) WithRetentionLock(fn func() error) (bool, error) {
+	return s.repo.WithRetentionLock(fn)
+}
+
+// RetentionWorker periodically applies retention policies to feed items
+type RetentionWorker struct {
+	service   *Service
+	policies  []RetentionPolicy
+	interval  time.Duration
+	batchSize int
+	dryRun    bool
+}
+
+func NewRetentionWorker(s *Service, policies []RetentionPolicy, interval time.Duration, batchSize int, dryRun bool) *RetentionWorker {
+	return &RetentionWorker{
+		service:   s,
+		policies:  policies,
+		interval:  interval,
+		batchSize: batchSize,
+		dryRun:    dryRun,
+	}
+}
+
+func (w *RetentionWorker) Start(ctx context.Context) error {
+	for {
+		locked, err := w.service.WithRetentionLock(func() error {
+			for _, policy := range w.policies {
+				w.apply(policy)
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("feed items retention lock")
+		} else if !locked {
+			log.Debug().Msg("feed items retention is run by another replica")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.interval):
+		}
+	}
+}
+
+func (w *RetentionWorker) apply(policy RetentionPolicy) {
+	var err error
+	defer func(start time.Time) {
+		metricRetentionHistogram.
+			WithLabelValues(string(policy.Type), metrics.ErrLabelValue(err)).
+			Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	processed, err := w.service.ApplyRetention(policy, w.batchSize, w.dryRun)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("type", string(policy.Type)).
+			Str("mode", string(policy.Mode)).
+			Int64("processed", processed).
+			Msg("feed items retention failed")
+
+		return
+	}
+
+	log.Info().
+		Str("type", string(policy.Type)).
+		Str("mode", string(policy.Mode)).
+		Dur("max_age", policy.MaxAge).
+		Bool("dry_run", w.dryRun).
+		Int64("processed", processed).
+		Msg("feed items retention finished")
+}
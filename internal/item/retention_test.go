@@ -0,0 +1,77 @@
+package item
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type retentionRepo struct {
+	DataProvider
+
+	expired  int64
+	calls    []RetentionMode
+	archived int64
+}
+
+func (r *retentionRepo) CountExpired(_ Type, _ time.Time, _ bool) (int64, error) {
+	return r.expired, nil
+}
+
+func (r *retentionRepo) DeleteExpired(_ Type, _ time.Time, limit int, soft bool) (int64, error) {
+	mode := RetentionModeDelete
+	if soft {
+		mode = RetentionModeSoftDelete
+	}
+
+	return r.take(mode, limit), nil
+}
+
+func (r *retentionRepo) ArchiveExpired(_ Type, _ time.Time, limit int) (int64, error) {
+	n := r.take(RetentionModeArchive, limit)
+	r.archived += n
+
+	return n, nil
+}
+
+func (r *retentionRepo) take(mode RetentionMode, limit int) int64 {
+	r.calls = append(r.calls, mode)
+
+	n := min(r.expired, int64(limit))
+	r.expired -= n
+
+	return n
+}
+
+func TestUnitApplyRetentionInBatches(t *testing.T) {
+	for _, mode := range []RetentionMode{RetentionModeDelete, RetentionModeSoftDelete, RetentionModeArchive} {
+		t.Run(string(mode), func(t *testing.T) {
+			repo := &retentionRepo{expired: 25}
+			s := newTestService(t, repo)
+
+			processed, err := s.ApplyRetention(RetentionPolicy{Type: TypeProposal, MaxAge: time.Hour, Mode: mode}, 10, false)
+			require.NoError(t, err)
+			require.EqualValues(t, 25, processed)
+			require.Equal(t, []RetentionMode{mode, mode, mode}, repo.calls)
+		})
+	}
+}
+
+func TestUnitApplyRetentionDryRun(t *testing.T) {
+	repo := &retentionRepo{expired: 25}
+	s := newTestService(t, repo)
+
+	processed, err := s.ApplyRetention(RetentionPolicy{Type: TypeProposal, MaxAge: time.Hour, Mode: RetentionModeArchive}, 10, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 25, processed)
+	require.Empty(t, repo.calls)
+	require.Zero(t, repo.archived)
+}
+
+func TestUnitApplyRetentionUnknownMode(t *testing.T) {
+	s := newTestService(t, &retentionRepo{expired: 1})
+
+	_, err := s.ApplyRetention(RetentionPolicy{Type: TypeProposal, Mode: "truncate"}, 10, false)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package item
 
 import (
 	"context"
+	"slices"
 
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
@@ -9,6 +10,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
 	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
 )
 
@@ -18,24 +20,33 @@ const (
 )
 
 var feedItemTypeMap = map[Type]feedpb.FeedInfo_Type{
-	TypeDao:      feedpb.FeedInfo_DAO,
-	TypeProposal: feedpb.FeedInfo_Proposal,
-	TypeDelegate: feedpb.FeedInfo_Delegate,
+	TypeDao:        feedpb.FeedInfo_DAO,
+	TypeProposal:   feedpb.FeedInfo_Proposal,
+	TypeDelegate:   feedpb.FeedInfo_Delegate,
+	TypeDiscussion: feedpb.FeedInfo_Discussion,
+	TypeVote:       feedpb.FeedInfo_Vote,
 }
 
 var timelineActionsMap = map[TimelineAction]feedpb.FeedTimelineItem_TimelineAction{
-	DaoCreated:                  feedpb.FeedTimelineItem_DaoCreated,
-	DaoUpdated:                  feedpb.FeedTimelineItem_DaoUpdated,
-	ProposalCreated:             feedpb.FeedTimelineItem_ProposalCreated,
-	ProposalUpdated:             feedpb.FeedTimelineItem_ProposalUpdated,
-	ProposalVotingStartsSoon:    feedpb.FeedTimelineItem_ProposalVotingStartsSoon,
-	ProposalVotingEndsSoon:      feedpb.FeedTimelineItem_ProposalVotingEndsSoon,
-	ProposalVotingStarted:       feedpb.FeedTimelineItem_ProposalVotingStarted,
-	ProposalVotingQuorumReached: feedpb.FeedTimelineItem_ProposalVotingQuorumReached,
-	ProposalVotingEnded:         feedpb.FeedTimelineItem_ProposalVotingEnded,
-	DelegateCreateProposal:      feedpb.FeedTimelineItem_DelegateCreateProposal,
-	DelegateVotingVoted:         feedpb.FeedTimelineItem_DelegateVotingVoted,
-	DelegateVotingSkipVote:      feedpb.FeedTimelineItem_DelegateVotingSkipVote,
+	DaoCreated:                     feedpb.FeedTimelineItem_DaoCreated,
+	DaoUpdated:                     feedpb.FeedTimelineItem_DaoUpdated,
+	ProposalCreated:                feedpb.FeedTimelineItem_ProposalCreated,
+	ProposalUpdated:                feedpb.FeedTimelineItem_ProposalUpdated,
+	ProposalVotingStartsSoon:       feedpb.FeedTimelineItem_ProposalVotingStartsSoon,
+	ProposalVotingEndsSoon:         feedpb.FeedTimelineItem_ProposalVotingEndsSoon,
+	ProposalVotingStarted:          feedpb.FeedTimelineItem_ProposalVotingStarted,
+	ProposalVotingQuorumReached:    feedpb.FeedTimelineItem_ProposalVotingQuorumReached,
+	ProposalVotingEnded:            feedpb.FeedTimelineItem_ProposalVotingEnded,
+	DelegateCreateProposal:         feedpb.FeedTimelineItem_DelegateCreateProposal,
+	DelegateVotingVoted:            feedpb.FeedTimelineItem_DelegateVotingVoted,
+	DelegateVotingSkipVote:         feedpb.FeedTimelineItem_DelegateVotingSkipVote,
+	DelegateCreated:                feedpb.FeedTimelineItem_DelegateCreated,
+	DelegateDelegationExpired:      feedpb.FeedTimelineItem_DelegateDelegationExpired,
+	DelegateDelegationExpiringSoon: feedpb.FeedTimelineItem_DelegateDelegationExpiringSoon,
+	DiscussionCreated:              feedpb.FeedTimelineItem_DiscussionCreated,
+	DiscussionReplied:              feedpb.FeedTimelineItem_DiscussionReplied,
+	DiscussionLinkedToProposal:     feedpb.FeedTimelineItem_DiscussionLinkedToProposal,
+	VoteCreated:                    feedpb.FeedTimelineItem_VoteCreated,
 }
 
 type Server struct {
@@ -84,6 +95,18 @@ func (s *Server) GetByFilter(_ context.Context, req *feedpb.FeedByFilterRequest)
 		filters = append(filters, TypeFilter{Types: req.GetTypes()})
 	}
 
+	// votes are shown only if they are requested explicitly
+	if len(req.GetVoters()) != 0 {
+		voters := make([]string, len(req.GetVoters()))
+		for i, voter := range req.GetVoters() {
+			voters[i] = subscription.NormalizeAddress(voter)
+		}
+
+		filters = append(filters, VoterFilter{Voters: voters})
+	} else if !slices.Contains(req.GetTypes(), string(TypeVote)) {
+		filters = append(filters, SkipVotes{})
+	}
+
 	if req.IsActive != nil {
 		filters = append(filters, ActiveFilter{IsActive: req.GetIsActive()})
 	}
@@ -0,0 +1,73 @@
+package item
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+// collectTimelineActions parses models.go and returns all declared timeline actions except None,
+// so a new action can't be added without being mapped in every converter.
+func collectTimelineActions(t *testing.T) []TimelineAction {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "models.go", nil, 0)
+	require.NoError(t, err)
+
+	var actions []TimelineAction
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+
+		ident, ok := spec.Type.(*ast.Ident)
+		if !ok || ident.Name != "TimelineAction" {
+			return true
+		}
+
+		for _, value := range spec.Values {
+			lit, ok := value.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING || lit.Value == `""` {
+				continue
+			}
+
+			actions = append(actions, TimelineAction(lit.Value[1:len(lit.Value)-1]))
+		}
+
+		return true
+	})
+
+	require.NotEmpty(t, actions)
+
+	return actions
+}
+
+func TestUnitTimelineActionsMapped(t *testing.T) {
+	for _, action := range collectTimelineActions(t) {
+		t.Run(string(action), func(t *testing.T) {
+			converted, ok := timelineActionsMap[action]
+			require.True(t, ok, "missing proto mapping")
+			require.NotEqual(t, feedpb.FeedTimelineItem_Unspecified, converted)
+
+			external, ok := inboxTimelineActionMap[action]
+			require.True(t, ok, "missing inbox mapping")
+			require.NotEmpty(t, external)
+		})
+	}
+}
+
+func TestUnitTimelineActionsMapUnique(t *testing.T) {
+	seen := make(map[feedpb.FeedTimelineItem_TimelineAction]TimelineAction)
+	for action, converted := range timelineActionsMap {
+		prev, exists := seen[converted]
+		require.False(t, exists, "%s and %s are mapped to the same value", prev, action)
+
+		seen[converted] = action
+	}
+}
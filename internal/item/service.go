@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,56 +14,105 @@ import (
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
 	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
 	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
 )
 
-//go:generate mockgen -destination=mocks_test.go -package=item . DataProvider,Publisher
-
-type Publisher interface {
-	PublishJSON(ctx context.Context, subject string, obj any) error
-}
+//go:generate mockgen -destination=mocks_test.go -package=item . DataProvider
 
 type DataProvider interface {
-	Save(item *FeedItem) error
+	Save(item *FeedItem, eventKey string, messages ...outbox.Message) error
+	GetByID(id uuid.UUID) (*FeedItem, error)
 	GetDaoItem(id uuid.UUID) (*FeedItem, error)
 	GetProposalItem(id string) (*FeedItem, error)
+	GetDiscussionItem(id string) (*FeedItem, error)
+	GetProposalDiscussionItem(proposalID string) (*FeedItem, error)
 	GetByFilters(filters []Filter) (FeedList, error)
 	GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt time.Time, limit int) ([]FeedItem, error)
+	CountExpired(fType Type, before time.Time, onlyActive bool) (int64, error)
+	DeleteExpired(fType Type, before time.Time, limit int, soft bool) (int64, error)
+	ArchiveExpired(fType Type, before time.Time, limit int) (int64, error)
+	WithRetentionLock(fn func() error) (bool, error)
+	CreateMonthPartition(month time.Time) error
+	IsEventProcessed(key string) (bool, error)
+	DeleteProcessedEventsBefore(before time.Time, limit int) (int64, error)
+}
+
+// Notifier wakes up watchers of the feed items updates
+type Notifier interface {
+	PublishNoWait(msg string)
 }
 
 type SubscriberProvider interface {
 	GetByID(_ context.Context, id uuid.UUID) (*subscriber.Subscriber, error)
 }
 
+// WebhookValidator checks addresses of webhooks again before callbacks are published for the delivery
+// by another service, the hostname could be resolved to another address after the registration
+type WebhookValidator interface {
+	ValidateAddress(_ context.Context, url string) error
+}
+
+type WebhookEndpointsProvider interface {
+	List(_ context.Context, subscriberID uuid.UUID) ([]subscriber.WebhookEndpoint, error)
+}
+
 type SubscriptionProvider interface {
 	GetSubscribers(_ context.Context, daoID uuid.UUID) ([]uuid.UUID, error)
+	GetAddressFollowers(_ context.Context, address string) ([]uuid.UUID, error)
+}
+
+// anyDaoTag marks cached results which could contain items of any DAO
+const anyDaoTag = "dao:*"
+
+// FiltersCache keeps feed lists by the filters they were requested with
+type FiltersCache = cache.Cache[string, FeedList]
+
+func NewFiltersCache(opts cache.Options) *FiltersCache {
+	return cache.New[string, FeedList]("feed_item_filters", opts)
 }
 
 type Service struct {
-	cacheMu sync.RWMutex
-	cache   map[string]FeedList
+	cache *FiltersCache
 
 	repo          DataProvider
-	events        Publisher
 	subscribers   SubscriberProvider
+	endpoints     WebhookEndpointsProvider
 	subscriptions SubscriptionProvider
+	webhooks      WebhookValidator
+
+	notifier Notifier
 
-	notifier *pubsub.PubSub[string]
+	// replay mode settings
+	updatesDisabled bool
+	ignoreProcessed bool
 }
 
-func NewService(r DataProvider, p Publisher, sub SubscriberProvider, sp SubscriptionProvider, notifier *pubsub.PubSub[string]) (*Service, error) {
+// NewService creates the service, the webhook validator is nil if callbacks are delivered by the service
+// itself and addresses are checked when they are dialed
+func NewService(r DataProvider, sub SubscriberProvider, ep WebhookEndpointsProvider, sp SubscriptionProvider, wv WebhookValidator, notifier Notifier, filtersCache *FiltersCache) (*Service, error) {
 	return &Service{
 		repo:          r,
-		events:        p,
 		subscribers:   sub,
+		endpoints:     ep,
 		subscriptions: sp,
+		webhooks:      wv,
 		notifier:      notifier,
-		cache:         make(map[string]FeedList),
-		cacheMu:       sync.RWMutex{},
+		cache:         filtersCache,
 	}, nil
 }
 
+// EnableReplayMode disables timeline updates and subscriber callbacks for replayed events.
+// With ignoreProcessed the events are applied again even if they have already been processed.
+// It has to be called before handling any event.
+func (s *Service) EnableReplayMode(ignoreProcessed bool) {
+	s.updatesDisabled = true
+	s.ignoreProcessed = ignoreProcessed
+}
+
 func (s *Service) GetDaoItem(_ context.Context, id uuid.UUID) (*FeedItem, error) {
 	item, err := s.repo.GetDaoItem(id)
 
@@ -92,11 +141,121 @@ func (s *Service) GetProposalItem(_ context.Context, id string) (*FeedItem, erro
 	return item, nil
 }
 
+func (s *Service) GetDiscussionItem(_ context.Context, id string) (*FeedItem, error) {
+	item, err := s.repo.GetDiscussionItem(id)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// LinkDiscussion stores discussion identifier in the proposal feed item, so the proposal references its discussion
+func (s *Service) LinkDiscussion(ctx context.Context, proposalID, discussionID string) error {
+	item, err := s.GetProposalItem(ctx, proposalID)
+	if err != nil {
+		return fmt.Errorf("get proposal item: %w", err)
+	}
+
+	// the proposal consumer links the discussion on proposal item creation
+	if item == nil || item.DiscussionID == discussionID {
+		return nil
+	}
+
+	item.DiscussionID = discussionID
+
+	// the link doesn't change the timeline, so updates and callbacks are not sent
+	return s.HandleItem(ctx, item, false)
+}
+
+// GetLinkedDiscussionID returns identifier of the discussion linked to the proposal or empty string
+func (s *Service) GetLinkedDiscussionID(_ context.Context, proposalID string) (string, error) {
+	item, err := s.repo.GetProposalDiscussionItem(proposalID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return item.DiscussionID, nil
+}
+
+// IsAddressFollowed reports whether at least one subscriber follows the address
+func (s *Service) IsAddressFollowed(ctx context.Context, address string) (bool, error) {
+	subs, err := s.subscriptions.GetAddressFollowers(ctx, address)
+	if err != nil {
+		return false, err
+	}
+
+	return len(subs) > 0, nil
+}
+
+// DeleteProcessedEventsBefore removes keys of events processed before the time in batches and returns count of removed keys
+func (s *Service) DeleteProcessedEventsBefore(before time.Time, batchSize int) (int64, error) {
+	var total int64
+	for {
+		deleted, err := s.repo.DeleteProcessedEventsBefore(before, batchSize)
+		if err != nil {
+			return total, fmt.Errorf("delete processed events: %w", err)
+		}
+
+		total += deleted
+		if deleted < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// checkEvent returns the key of the event and reports whether the event has already been processed
+func (s *Service) checkEvent(subject string, payload any) (string, bool, error) {
+	// the key is not recorded, so the event can be applied on every replay
+	if s.ignoreProcessed {
+		return "", false, nil
+	}
+
+	key, err := eventKey(subject, payload)
+	if err != nil {
+		return "", false, fmt.Errorf("event key: %w", err)
+	}
+
+	processed, err := s.repo.IsEventProcessed(key)
+	if err != nil {
+		return "", false, fmt.Errorf("check processed event: %w", err)
+	}
+
+	return key, processed, nil
+}
+
 func (s *Service) GetLastItems(subscriberID string, fTypes []Type, lastUpdatedAt time.Time, limit int) ([]FeedItem, error) {
 	return s.repo.GetLastItems(subscriberID, fTypes, lastUpdatedAt, limit)
 }
 
 func (s *Service) HandleItem(ctx context.Context, item *FeedItem, sendUpdates bool) error {
+	return s.handleItem(ctx, item, sendUpdates, "")
+}
+
+// HandleEvent handles the feed item changed by the event and records the event key in the same transaction,
+// so the redelivered event is applied only once
+func (s *Service) HandleEvent(ctx context.Context, eventKey string, item *FeedItem, sendUpdates bool) error {
+	err := s.handleItem(ctx, item, sendUpdates, eventKey)
+	if errors.Is(err, errEventProcessed) {
+		skipEvent(item.Type, skipReasonDuplicate)
+
+		return nil
+	}
+
+	return err
+}
+
+func (s *Service) handleItem(ctx context.Context, item *FeedItem, sendUpdates bool, eventKey string) error {
 	defer func() {
 		s.invalidateCache(item)
 	}()
@@ -107,60 +266,213 @@ func (s *Service) HandleItem(ctx context.Context, item *FeedItem, sendUpdates bo
 		item.TriggeredAt = item.Timeline[len(item.Timeline)-1].CreatedAt
 	}
 
-	if err := s.repo.Save(item); err != nil {
-		return fmt.Errorf("can't save feed item: %w", err)
+	// the identifier is a part of the callback payload, so it has to be known before saving
+	if item.ID == uuid.Nil {
+		item.ID = uuid.New()
 	}
 
-	s.notifier.PublishNoWait(item.DaoID.String())
+	var messages []outbox.Message
+	if sendUpdates && !s.updatesDisabled {
+		var err error
+		// the item isn't saved without its updates, so the event is redelivered and updates aren't lost
+		messages, err = s.prepareUpdates(ctx, item)
+		if err != nil {
+			return fmt.Errorf("prepare updates of %s: %w", item.ID, err)
+		}
+	}
 
-	if !sendUpdates {
-		return nil
+	if err := s.repo.Save(item, eventKey, messages...); err != nil {
+		return fmt.Errorf("can't save feed item: %w", err)
 	}
 
+	s.notifier.PublishNoWait(notificationTopic(item))
+
+	return nil
+}
+
+// prepareUpdates builds outbox messages with the timeline update and subscriber callbacks of the feed item
+func (s *Service) prepareUpdates(ctx context.Context, item *FeedItem) ([]outbox.Message, error) {
+	messages := make([]outbox.Message, 0, 1)
+
 	// send timeline update only for proposals
 	if item.Type == TypeProposal {
-		err := s.events.PublishJSON(ctx, core.SubjectTimelineUpdate, core.TimelinePayload{
+		msg, err := outbox.NewMessage(item.DaoID, core.SubjectTimelineUpdate, core.TimelinePayload{
 			DaoID:        item.DaoID,
 			ProposalID:   item.ProposalID,
 			DiscussionID: item.DiscussionID,
 			Timeline:     convertTimelineToCore(item.Timeline),
 		})
 		if err != nil {
-			log.Error().Err(err).Msg("send timeline update")
+			return nil, fmt.Errorf("prepare timeline update: %w", err)
 		}
+
+		messages = append(messages, msg)
 	}
 
-	subs, err := s.subscriptions.GetSubscribers(ctx, item.DaoID)
+	subs, err := s.getItemSubscribers(ctx, item)
 	if err != nil {
-		log.Error().Err(err).Msg("get subscribers")
-		return nil
+		return nil, fmt.Errorf("get subscribers: %w", err)
 	}
 
-	data, err := json.Marshal(convertToExternalFeed(item))
+	callbacks, err := s.prepareCallbacks(ctx, item, subs)
 	if err != nil {
-		log.Error().Err(err).Msgf("marshal feed: %d", item.ID)
+		return nil, fmt.Errorf("prepare callbacks: %w", err)
+	}
 
-		// Suppress an error for the consumer for avoiding duplicated events
-		return nil
+	for _, cb := range callbacks {
+		msg, err := outbox.NewMessage(item.DaoID, core.SubjectCallback, cb)
+		if err != nil {
+			return nil, fmt.Errorf("prepare callback to %s: %w", cb.WebhookURL, err)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// GetCallbacks returns callbacks of the feed item to webhooks of the subscriber for the redelivery
+func (s *Service) GetCallbacks(ctx context.Context, subscriberID, itemID uuid.UUID) ([]webhook.Callback, error) {
+	item, err := s.repo.GetByID(itemID)
+	if err != nil {
+		return nil, fmt.Errorf("get feed item: %w", err)
+	}
+
+	callbacks, err := s.prepareCallbacks(ctx, item, []uuid.UUID{subscriberID})
+	if err != nil {
+		return nil, err
 	}
 
+	for i := range callbacks {
+		callbacks[i].Redelivery = true
+	}
+
+	return callbacks, nil
+}
+
+// prepareCallbacks returns callbacks to safe webhooks of subscribers routing the feed item. Addresses of webhooks
+// are checked here only if the validator is set, otherwise the delivery checks them when they are dialed.
+func (s *Service) prepareCallbacks(ctx context.Context, item *FeedItem, subs []uuid.UUID) ([]webhook.Callback, error) {
+	feed := convertToExternalFeed(item)
+	data, err := json.Marshal(feed)
+	if err != nil {
+		return nil, fmt.Errorf("marshal feed: %w", err)
+	}
+
+	// the compact format is sent to endpoints which don't need the snapshot and the timeline
+	compact := feed
+	compact.Snapshot = nil
+	compact.Timeline = nil
+	compactData, err := json.Marshal(compact)
+	if err != nil {
+		return nil, fmt.Errorf("marshal compact feed: %w", err)
+	}
+
+	bodies := map[subscriber.WebhookFormat][]byte{
+		subscriber.WebhookFormatFull:    data,
+		subscriber.WebhookFormatCompact: compactData,
+	}
+
+	var callbacks []webhook.Callback
 	for _, sub := range subs {
-		info, err := s.subscribers.GetByID(ctx, sub)
+		subCallbacks, err := s.subscriberCallbacks(ctx, sub, feed)
 		if err != nil {
-			log.Error().Str("subscriber", sub.String()).Err(err).Msgf("get details for subscriber")
-			continue
+			return nil, err
 		}
 
-		err = s.events.PublishJSON(ctx, core.SubjectCallback, core.CallbackPayload{
-			WebhookURL: info.WebhookURL,
-			Body:       data,
-		})
-		if err != nil {
-			log.Error().Str("subscriber", sub.String()).Str("webhook_url", info.WebhookURL).Err(err).Msgf("publish callback")
+		for _, cb := range subCallbacks {
+			if s.webhooks != nil {
+				if err := s.webhooks.ValidateAddress(ctx, cb.url); err != nil {
+					metricBlockedCallbacksCounter.Inc()
+					log.Warn().Str("subscriber", sub.String()).Str("webhook_url", cb.url).Err(err).Msg("callback is blocked")
+
+					continue
+				}
+			}
+
+			callbacks = append(callbacks, webhook.Callback{
+				CallbackPayload: core.CallbackPayload{
+					WebhookURL: cb.url,
+					Body:       bodies[cb.format],
+				},
+				SubscriberID: sub,
+				DaoID:        item.DaoID,
+				FeedItemID:   item.ID,
+				Action:       string(feed.Action),
+				BatchMaxSize: cb.batchMaxSize,
+				BatchMaxWait: cb.batchMaxWait,
+			})
 		}
 	}
 
-	return nil
+	return callbacks, nil
+}
+
+type callback struct {
+	url    string
+	format subscriber.WebhookFormat
+
+	batchMaxSize int
+	batchMaxWait time.Duration
+}
+
+// subscriberCallbacks returns the webhook of the subscriber and enabled endpoints routing the feed item,
+// the subscriber removed after subscribing doesn't receive callbacks
+func (s *Service) subscriberCallbacks(ctx context.Context, sub uuid.UUID, feed inbox.FeedPayload) ([]callback, error) {
+	info, err := s.subscribers.GetByID(ctx, sub)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Warn().Str("subscriber", sub.String()).Msg("subscriber of the feed item is not found")
+
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get subscriber %s: %w", sub, err)
+	}
+
+	// batching options of the subscriber are applied to all its webhooks
+	newCallback := func(url string, format subscriber.WebhookFormat) callback {
+		return callback{
+			url:          url,
+			format:       format,
+			batchMaxSize: info.BatchMaxSize,
+			batchMaxWait: info.BatchMaxWait(),
+		}
+	}
+
+	var callbacks []callback
+	if info.WebhookURL != "" {
+		callbacks = append(callbacks, newCallback(info.WebhookURL, subscriber.WebhookFormatFull))
+	}
+
+	endpoints, err := s.endpoints.List(ctx, sub)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook endpoints of %s: %w", sub, err)
+	}
+
+	for i := range endpoints {
+		if endpoints[i].Matches(string(feed.Type), string(feed.Action), feed.DaoID) {
+			callbacks = append(callbacks, newCallback(endpoints[i].URL, endpoints[i].Format))
+		}
+	}
+
+	return callbacks, nil
+}
+
+func notificationTopic(item *FeedItem) string {
+	if item.Type == TypeVote {
+		return pubsub.AddressTopic(item.Voter)
+	}
+
+	return pubsub.DaoTopic(item.DaoID)
+}
+
+func (s *Service) getItemSubscribers(ctx context.Context, item *FeedItem) ([]uuid.UUID, error) {
+	// votes are delivered to the voter followers only
+	if item.Type == TypeVote {
+		return s.subscriptions.GetAddressFollowers(ctx, item.Voter)
+	}
+
+	return s.subscriptions.GetSubscribers(ctx, item.DaoID)
 }
 
 func convertTimelineToCore(pl Timeline) []core.TimelineItem {
@@ -187,6 +499,9 @@ func convertFeedType(ftype Type) inbox.Type {
 		return inbox.TypeProposal
 	case TypeDelegate:
 		return inbox.TypeDelegate
+	case TypeDiscussion, TypeVote:
+		// not declared in platform events yet
+		return inbox.Type(ftype)
 	default:
 		return inbox.TypeDao
 	}
@@ -225,18 +540,26 @@ func convertToExternalTimeline(timeline Timeline) []inbox.TimelineItem {
 }
 
 var inboxTimelineActionMap = map[TimelineAction]inbox.TimelineAction{
-	DaoCreated:                  inbox.DaoCreated,
-	DaoUpdated:                  inbox.DaoUpdated,
-	ProposalCreated:             inbox.ProposalCreated,
-	ProposalUpdated:             inbox.ProposalUpdated,
-	ProposalVotingStartsSoon:    inbox.ProposalVotingStartsSoon,
-	ProposalVotingEndsSoon:      inbox.ProposalVotingEndsSoon,
-	ProposalVotingStarted:       inbox.ProposalVotingStarted,
-	ProposalVotingQuorumReached: inbox.ProposalVotingQuorumReached,
-	ProposalVotingEnded:         inbox.ProposalVotingEnded,
-	DelegateCreateProposal:      inbox.DelegateCreateProposal,
-	DelegateVotingVoted:         inbox.DelegateVotingVoted,
-	DelegateVotingSkipVote:      inbox.DelegateVotingSkipVote,
+	DaoCreated:                     inbox.DaoCreated,
+	DaoUpdated:                     inbox.DaoUpdated,
+	ProposalCreated:                inbox.ProposalCreated,
+	ProposalUpdated:                inbox.ProposalUpdated,
+	ProposalVotingStartsSoon:       inbox.ProposalVotingStartsSoon,
+	ProposalVotingEndsSoon:         inbox.ProposalVotingEndsSoon,
+	ProposalVotingStarted:          inbox.ProposalVotingStarted,
+	ProposalVotingQuorumReached:    inbox.ProposalVotingQuorumReached,
+	ProposalVotingEnded:            inbox.ProposalVotingEnded,
+	DelegateCreateProposal:         inbox.DelegateCreateProposal,
+	DelegateVotingVoted:            inbox.DelegateVotingVoted,
+	DelegateVotingSkipVote:         inbox.DelegateVotingSkipVote,
+	DelegateCreated:                inbox.DelegateCreated,
+	DelegateDelegationExpired:      inbox.DelegateDelegationExpired,
+	DelegateDelegationExpiringSoon: inbox.DelegateDelegationExpiringSoon,
+	// discussion and vote actions are not declared in platform events yet
+	DiscussionCreated:          inbox.TimelineAction(DiscussionCreated),
+	DiscussionReplied:          inbox.TimelineAction(DiscussionReplied),
+	DiscussionLinkedToProposal: inbox.TimelineAction(DiscussionLinkedToProposal),
+	VoteCreated:                inbox.TimelineAction(VoteCreated),
 }
 
 func convertActionToExternal(action TimelineAction) inbox.TimelineAction {
@@ -245,35 +568,73 @@ func convertActionToExternal(action TimelineAction) inbox.TimelineAction {
 
 func (s *Service) GetByFilters(filters []Filter) (FeedList, error) {
 	key := fmt.Sprintf("%v", filters)
-	s.cacheMu.RLock()
-	list, ok := s.cache[key]
-	s.cacheMu.RUnlock()
-	if ok && len(list.Items) > 0 {
+	if list, ok := s.cache.Get(key); ok {
 		return list, nil
 	}
 
+	// the version of requested DAOs is taken before the read, so the result isn't cached if their items are invalidated meanwhile.
+	// Items of proposals are invalidated with their DAOs, so the proposal tags aren't needed here.
+	daoTags := filtersDaoTags(filters)
+	version := s.cache.Version(daoTags...)
 	list, err := s.repo.GetByFilters(filters)
 	if err != nil {
 		return FeedList{}, fmt.Errorf("get by filters: %w", err)
 	}
 
-	s.cacheMu.Lock()
-	s.cache[key] = list
-	s.cacheMu.Unlock()
+	tags := filtersCacheTags(daoTags, list)
+	if len(list.Items) == 0 {
+		s.cache.SetNegativeIfVersion(version, key, list, tags...)
+	} else {
+		s.cache.SetIfVersion(version, key, list, tags...)
+	}
 
 	return list, nil
 }
 
-func (s *Service) invalidateCache(item *FeedItem) {
-	s.cacheMu.Lock()
-	defer s.cacheMu.Unlock()
-
-	for key, list := range s.cache {
-		for idx := range list.Items {
-			if list.Items[idx].ProposalID == item.ProposalID ||
-				list.Items[idx].DaoID == item.DaoID {
-				delete(s.cache, key)
+// filtersDaoTags returns tags of DAOs the result depends on,
+// results requested without DAO filter depend on all DAOs
+func filtersDaoTags(filters []Filter) []string {
+	var tags []string
+	for _, f := range filters {
+		if df, ok := f.(DaoIDFilter); ok {
+			for _, id := range df.IDs {
+				tags = append(tags, daoTag(strings.ToLower(id)))
 			}
 		}
 	}
+
+	if len(tags) == 0 {
+		tags = append(tags, anyDaoTag)
+	}
+
+	return tags
+}
+
+// filtersCacheTags returns tags of DAOs and proposals the result depends on
+func filtersCacheTags(daoTags []string, list FeedList) []string {
+	tags := append([]string(nil), daoTags...)
+	for _, item := range list.Items {
+		if item.ProposalID != "" {
+			tags = append(tags, proposalTag(item.ProposalID))
+		}
+	}
+
+	return tags
+}
+
+func (s *Service) invalidateCache(item *FeedItem) {
+	tags := []string{anyDaoTag, daoTag(item.DaoID.String())}
+	if item.ProposalID != "" {
+		tags = append(tags, proposalTag(item.ProposalID))
+	}
+
+	s.cache.Invalidate(tags...)
+}
+
+func daoTag(id string) string {
+	return "dao:" + id
+}
+
+func proposalTag(id string) string {
+	return "proposal:" + id
 }
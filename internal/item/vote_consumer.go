@@ -0,0 +1,158 @@
+package item
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscription"
+)
+
+const (
+	voteMaxPendingElements = 100
+	voteRateLimit          = 500 * client.KiB
+	voteExecutionTtl       = time.Minute
+)
+
+// Vote events are published by the core storage in batches, they are not declared in platform events yet
+const (
+	SubjectVoteCreated = "core.vote.created"
+)
+
+type VotePayload struct {
+	ID         string          `json:"id"`
+	DaoID      uuid.UUID       `json:"dao_id"`
+	ProposalID string          `json:"proposal_id"`
+	Voter      string          `json:"voter"`
+	Choice     json.RawMessage `json:"choice"`
+	Reason     string          `json:"reason"`
+	Vp         float64         `json:"vp"`
+	Created    int             `json:"created"`
+}
+
+type VotesPayload []VotePayload
+
+type VotesHandler func(payload VotesPayload) error
+
+type VoteConsumer struct {
+	conn      *nats.Conn
+	service   *Service
+	consumers []*client.Consumer[VotesPayload]
+}
+
+func NewVoteConsumer(nc *nats.Conn, s *Service) (*VoteConsumer, error) {
+	c := &VoteConsumer{
+		conn:      nc,
+		service:   s,
+		consumers: make([]*client.Consumer[VotesPayload], 0),
+	}
+
+	return c, nil
+}
+
+func (c *VoteConsumer) handler() VotesHandler {
+	return func(payload VotesPayload) error {
+		var err error
+		defer func(start time.Time) {
+			metricHandleHistogram.
+				WithLabelValues("vote", metrics.ErrLabelValue(err)).
+				Observe(time.Since(start).Seconds())
+		}(time.Now())
+
+		for _, vote := range payload {
+			vote.Voter = subscription.NormalizeAddress(vote.Voter)
+
+			var followed bool
+			followed, err = c.service.IsAddressFollowed(context.TODO(), vote.Voter)
+			if err != nil {
+				return fmt.Errorf("check followers: %w", err)
+			}
+
+			// we store votes only for addresses somebody is interested in
+			if !followed {
+				continue
+			}
+
+			var item *FeedItem
+			item, err = c.convertToFeedItem(vote)
+			if err != nil {
+				return err
+			}
+
+			err = c.service.HandleItem(context.TODO(), item, true)
+			if err != nil {
+				log.Error().Str("vote_id", vote.ID).Err(err).Msg("process vote")
+				return err
+			}
+
+			log.Debug().Msgf("vote was processed: %s", vote.ID)
+		}
+
+		return nil
+	}
+}
+
+func (c *VoteConsumer) convertToFeedItem(pl VotePayload) (*FeedItem, error) {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return nil, fmt.Errorf("cant marshal payload: %w", err)
+	}
+
+	createdAt := time.Unix(int64(pl.Created), 0).UTC()
+	if pl.Created == 0 {
+		createdAt = time.Now().UTC()
+	}
+
+	return &FeedItem{
+		DaoID:      pl.DaoID,
+		ProposalID: pl.ProposalID,
+		Voter:      pl.Voter,
+		Type:       TypeVote,
+		Action:     VoteCreated,
+		Snapshot:   b,
+		Timeline: Timeline{
+			{CreatedAt: createdAt, Action: VoteCreated},
+		},
+	}, nil
+}
+
+func (c *VoteConsumer) Start(ctx context.Context) error {
+	group := config.GenerateGroupName("item_vote")
+
+	opts := []client.ConsumerOpt{
+		client.WithRateLimit(voteRateLimit),
+		client.WithMaxAckPending(voteMaxPendingElements),
+		client.WithAckWait(voteExecutionTtl),
+	}
+
+	consumer, err := client.NewConsumer(ctx, c.conn, group, SubjectVoteCreated, c.handler(), opts...)
+	if err != nil {
+		return fmt.Errorf("consume for %s/%s: %w", group, SubjectVoteCreated, err)
+	}
+
+	c.consumers = append(c.consumers, consumer)
+
+	log.Info().Msg("feed item vote consumers is started")
+
+	// todo: handle correct stopping the consumer by context
+	<-ctx.Done()
+	return c.stop()
+}
+
+func (c *VoteConsumer) stop() error {
+	for _, cs := range c.consumers {
+		if err := cs.Close(); err != nil {
+			log.Error().Err(err).Msg("unable to close vote consumer")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package item
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	pevents "github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/outbox"
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+// fanOutSubscriptions separates DAO subscribers from address followers
+type fanOutSubscriptions struct {
+	daoSubscribers []uuid.UUID
+	followers      map[string][]uuid.UUID
+}
+
+func (s fanOutSubscriptions) GetSubscribers(_ context.Context, _ uuid.UUID) ([]uuid.UUID, error) {
+	return s.daoSubscribers, nil
+}
+
+func (s fanOutSubscriptions) GetAddressFollowers(_ context.Context, address string) ([]uuid.UUID, error) {
+	return s.followers[address], nil
+}
+
+func TestUnitVoteConsumerFanOut(t *testing.T) {
+	ctx := context.Background()
+	subRepo := subscriber.NewMemoryRepo()
+
+	subs, err := subscriber.NewService(subRepo, subscriber.NewCache(), nopInvalidator{})
+	require.NoError(t, err)
+	daoSubscriber, err := subs.Create(ctx, "https://example.com/dao")
+	require.NoError(t, err)
+	follower, err := subs.Create(ctx, "https://example.com/follower")
+	require.NoError(t, err)
+
+	messages := outbox.NewMemoryRepo()
+	repo := NewMemoryRepo(nil, messages)
+	endpoints := subscriber.NewWebhookEndpoints(subRepo, subscriber.NewEndpointsCache(cache.Options{}), nopInvalidator{})
+	subscriptions := fanOutSubscriptions{
+		daoSubscribers: []uuid.UUID{daoSubscriber.ID},
+		followers:      map[string][]uuid.UUID{"0xfollowed": {follower.ID}},
+	}
+
+	s, err := NewService(repo, subs, endpoints, subscriptions, nil, nopNotifier{}, NewFiltersCache(cache.Options{}))
+	require.NoError(t, err)
+
+	c := &VoteConsumer{service: s}
+	daoID := uuid.New()
+	require.NoError(t, c.handler()(VotesPayload{
+		{ID: "followed", DaoID: daoID, ProposalID: "proposal", Voter: "0xFOLLOWED", Choice: json.RawMessage(`1`)},
+		{ID: "unfollowed", DaoID: daoID, ProposalID: "proposal", Voter: "0xunfollowed", Choice: json.RawMessage(`2`)},
+	}))
+
+	// only votes of followed addresses are stored
+	votes := func(voter string) func(item *FeedItem) bool {
+		return func(item *FeedItem) bool { return item.Type == TypeVote && item.Voter == voter }
+	}
+	_, err = repo.first(votes("0xunfollowed"))
+	require.Error(t, err)
+	_, err = repo.first(votes("0xfollowed"))
+	require.NoError(t, err)
+
+	// votes are delivered to followers of the voter only
+	pending, err := messages.GetPending(10)
+	require.NoError(t, err)
+
+	var urls []string
+	for _, msg := range pending {
+		if msg.Subject != pevents.SubjectCallback {
+			continue
+		}
+
+		var payload pevents.CallbackPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		urls = append(urls, payload.WebhookURL)
+	}
+	require.Equal(t, []string{"https://example.com/follower"}, urls)
+}
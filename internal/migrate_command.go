@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/internal/migration"
+	"github.com/goverland-labs/goverland-core-feed/resources"
+)
+
+const (
+	MigrateUp       = "up"
+	MigrateDown     = "down"
+	MigrateStatus   = "status"
+	MigrateBaseline = "baseline"
+)
+
+type MigrateOptions struct {
+	Command string
+	Steps   int
+	Version string
+}
+
+// Migrate runs the migration command against the configured database and writes the result to the output
+func Migrate(cfg config.App, opts MigrateOptions, out io.Writer) error {
+	a := &Application{cfg: cfg}
+	if err := a.initDB(); err != nil {
+		return err
+	}
+
+	m, err := migration.NewMigrator(a.db, resources.Migrations)
+	if err != nil {
+		return err
+	}
+
+	var done []migration.Migration
+	switch opts.Command {
+	case MigrateUp:
+		done, err = m.Up()
+	case MigrateDown:
+		done, err = m.Down(opts.Steps)
+	case MigrateBaseline:
+		done, err = m.Baseline(opts.Version)
+	case MigrateStatus:
+		return writeStatus(m, out)
+	default:
+		return fmt.Errorf("unknown migrate command: %s", opts.Command)
+	}
+
+	for _, mg := range done {
+		fmt.Fprintf(out, "%s %s: %s\n", opts.Command, mg.Version, mg.Description)
+	}
+
+	return err
+}
+
+func writeStatus(m *migration.Migrator, out io.Writer) error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED AT\tREVERTIBLE")
+	for _, st := range statuses {
+		appliedAt := "pending"
+		if st.AppliedAt != nil {
+			appliedAt = st.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", st.Version, st.Description, appliedAt, st.Down != "")
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,107 @@
+package migration
+
+import (
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var fileNameRegexp = regexp.MustCompile(`^([VU])([0-9][0-9._]*)__(.+)\.sql$`)
+
+// Migration is the versioned schema change, Down is empty if the migration can't be reverted
+type Migration struct {
+	Version     string
+	Description string
+	Up          string
+	Down        string
+}
+
+// Load reads migrations from the file system root sorted by version.
+// Apply files are named V<version>__<description>.sql, revert files are named U<version>__<description>.sql.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		kind, version, description := matches[1], matches[2], strings.ReplaceAll(matches[3], "_", " ")
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version}
+			byVersion[version] = m
+		}
+
+		switch kind {
+		case "V":
+			m.Description = description
+			m.Up = string(data)
+		case "U":
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %s has no apply file", m.Version)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return compareVersions(migrations[i].Version, migrations[j].Version) < 0
+	})
+
+	return migrations, nil
+}
+
+// compareVersions compares versions like 1_2024.12.10 by their numeric parts
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return len(pa) - len(pb)
+}
+
+func versionParts(version string) []int {
+	fields := strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '_'
+	})
+
+	parts := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, _ := strconv.Atoi(f)
+		parts = append(parts, n)
+	}
+
+	return parts
+}
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/resources"
+)
+
+func TestUnitLoadSortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"V1_2025.03.12__second.sql":       {Data: []byte("select 2")},
+		"V1_2024.12.10__first.sql":        {Data: []byte("select 1")},
+		"U1_2024.12.10__first.sql":        {Data: []byte("select -1")},
+		"V1_2024.12.9__earlier_day.sql":   {Data: []byte("select 0")},
+		"V0__initial_schema.sql":          {Data: []byte("select")},
+		"resources.go":                    {Data: []byte("package resources")},
+		"V1_2026.01.01__missing_down.txt": {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+
+	versions := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+
+	require.Equal(t, []string{"0", "1_2024.12.9", "1_2024.12.10", "1_2025.03.12"}, versions)
+	require.Equal(t, "initial schema", migrations[0].Description)
+	require.Equal(t, "select -1", migrations[2].Down)
+	require.Empty(t, migrations[3].Down)
+}
+
+func TestUnitLoadRequiresApplyFile(t *testing.T) {
+	_, err := Load(fstest.MapFS{
+		"U1_2024.12.10__first.sql": {Data: []byte("select -1")},
+	})
+	require.Error(t, err)
+}
+
+func TestUnitEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(resources.Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	require.Equal(t, "0", migrations[0].Version)
+
+	// every migration except the initial schema could be reverted
+	for _, m := range migrations[1:] {
+		require.NotEmpty(t, m.Down, m.Version)
+	}
+}
@@ -0,0 +1,264 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pglock"
+)
+
+// ErrOutdatedSchema is returned when the database schema misses migrations of the application
+var ErrOutdatedSchema = errors.New("database schema is outdated")
+
+// AppliedMigration is the record about the applied migration
+type AppliedMigration struct {
+	Version     string `gorm:"primarykey"`
+	Description string
+	AppliedAt   time.Time
+}
+
+func (AppliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("load migrations: %w", err)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Status returns all known migrations with the time they were applied
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		st := Status{Migration: mg}
+		if a, ok := applied[mg.Version]; ok {
+			st.AppliedAt = &a.AppliedAt
+		}
+
+		statuses = append(statuses, st)
+	}
+
+	return statuses, nil
+}
+
+// Pending returns migrations which are not applied yet
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, mg := range m.migrations {
+		if _, ok := applied[mg.Version]; !ok {
+			pending = append(pending, mg)
+		}
+	}
+
+	return pending, nil
+}
+
+// Check returns ErrOutdatedSchema if there are pending migrations
+func (m *Migrator) Check() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migrations, the first one is %s", ErrOutdatedSchema, len(pending), pending[0].Version)
+	}
+
+	return nil
+}
+
+// Up applies all pending migrations in order, each migration is applied in its own transaction
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mg := range pending {
+		applied, err := m.apply(mg)
+		if err != nil {
+			return done, fmt.Errorf("apply %s: %w", mg.Version, err)
+		}
+
+		if applied {
+			done = append(done, mg)
+		}
+	}
+
+	return done, nil
+}
+
+// Down reverts the last applied migrations, steps limits the number of reverted migrations
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return compareVersions(statuses[i].Version, statuses[j].Version) > 0
+	})
+
+	var done []Migration
+	for _, st := range statuses {
+		if len(done) == steps {
+			break
+		}
+
+		if st.AppliedAt == nil {
+			continue
+		}
+
+		if st.Down == "" {
+			return done, fmt.Errorf("migration %s can't be reverted", st.Version)
+		}
+
+		if err = m.revert(st.Migration); err != nil {
+			return done, fmt.Errorf("revert %s: %w", st.Version, err)
+		}
+
+		done = append(done, st.Migration)
+	}
+
+	return done, nil
+}
+
+// Baseline marks migrations up to the version as applied without running them,
+// it's used for databases where the schema was applied by hand
+func (m *Migrator) Baseline(version string) ([]Migration, error) {
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mg := range pending {
+		if compareVersions(mg.Version, version) > 0 {
+			break
+		}
+
+		err = m.db.Create(&AppliedMigration{
+			Version:     mg.Version,
+			Description: mg.Description,
+			AppliedAt:   time.Now(),
+		}).Error
+		if err != nil {
+			return done, fmt.Errorf("baseline %s: %w", mg.Version, err)
+		}
+
+		done = append(done, mg)
+	}
+
+	return done, nil
+}
+
+func (m *Migrator) init() error {
+	return m.db.Exec(`create table if not exists schema_migrations
+(
+    version     text primary key,
+    description text,
+    applied_at  timestamp with time zone
+)`).Error
+}
+
+func (m *Migrator) applied() (map[string]AppliedMigration, error) {
+	if err := m.init(); err != nil {
+		return nil, fmt.Errorf("init migrations table: %w", err)
+	}
+
+	var list []AppliedMigration
+	if err := m.db.Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get applied migrations: %w", err)
+	}
+
+	applied := make(map[string]AppliedMigration, len(list))
+	for _, a := range list {
+		applied[a.Version] = a
+	}
+
+	return applied, nil
+}
+
+// apply runs the migration unless it has been applied by another replica while waiting for the lock
+func (m *Migrator) apply(mg Migration) (bool, error) {
+	var applied bool
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("select pg_advisory_xact_lock(?)", pglock.MigrationsKey).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&AppliedMigration{}).Where("version = ?", mg.Version).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(mg.Up).Error; err != nil {
+			return err
+		}
+
+		applied = true
+
+		return tx.Create(&AppliedMigration{
+			Version:     mg.Version,
+			Description: mg.Description,
+			AppliedAt:   time.Now(),
+		}).Error
+	})
+
+	return applied, err
+}
+
+func (m *Migrator) revert(mg Migration) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("select pg_advisory_xact_lock(?)", pglock.MigrationsKey).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(mg.Down).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("version = ?", mg.Version).Delete(&AppliedMigration{}).Error
+	})
+}
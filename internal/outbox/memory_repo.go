@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryRepo keeps messages in memory, it's used for running the application and tests without the database
+type MemoryRepo struct {
+	mu       sync.Mutex
+	lastID   uint64
+	messages []Message
+}
+
+func NewMemoryRepo() *MemoryRepo {
+	return &MemoryRepo{}
+}
+
+// Add stores messages assigning identifiers in the order of adding
+func (r *MemoryRepo) Add(messages ...Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range messages {
+		r.lastID++
+		msg.ID = r.lastID
+		if msg.CreatedAt.IsZero() {
+			msg.CreatedAt = time.Now()
+		}
+
+		r.messages = append(r.messages, msg)
+	}
+}
+
+// WithLock runs fn, there is the only relay per memory repository
+func (r *MemoryRepo) WithLock(fn func(DataProvider) error) (bool, error) {
+	return true, fn(r)
+}
+
+func (r *MemoryRepo) GetPending(limit int) ([]Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var list []Message
+	for _, msg := range r.messages {
+		if limit >= 0 && len(list) >= limit {
+			break
+		}
+
+		if msg.PublishedAt == nil && msg.ParkedAt == nil {
+			list = append(list, msg)
+		}
+	}
+
+	return list, nil
+}
+
+func (r *MemoryRepo) MarkPublished(ids []uint64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	published := make(map[uint64]struct{}, len(ids))
+	for _, id := range ids {
+		published[id] = struct{}{}
+	}
+
+	for i := range r.messages {
+		if _, ok := published[r.messages[i].ID]; ok {
+			r.messages[i].PublishedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) MarkFailed(id uint64, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].Attempts++
+			r.messages[i].LastError = reason
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) Park(id uint64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].ParkedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) CountParked() (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, msg := range r.messages {
+		if msg.ParkedAt != nil {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *MemoryRepo) GetOldestPendingCreatedAt() (*time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range r.messages {
+		if msg.PublishedAt == nil && msg.ParkedAt == nil {
+			return &msg.CreatedAt, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *MemoryRepo) DeletePublishedBefore(before time.Time, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		deleted int64
+		kept    = r.messages[:0]
+	)
+
+	for _, msg := range r.messages {
+		if (limit < 0 || deleted < int64(limit)) && msg.PublishedAt != nil && msg.PublishedAt.Before(before) {
+			deleted++
+			continue
+		}
+
+		kept = append(kept, msg)
+	}
+
+	r.messages = kept
+
+	return deleted, nil
+}
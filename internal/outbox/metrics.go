@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+var metricRelayLag = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "outbox",
+		Name:      "relay_lag_seconds",
+		Help:      "Age of the oldest not published outbox message",
+	},
+)
+
+var metricPublishedCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "outbox",
+		Name:      "published_total",
+		Help:      "Count of relayed outbox messages",
+	}, []string{"subject", "error"},
+)
+
+var metricRelayHistogram = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "outbox",
+		Name:      "relay_duration_seconds",
+		Help:      "Relay outbox batch duration seconds",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .5, 1, 2.5, 5, 10},
+	}, []string{"error"},
+)
+
+var metricParkedGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "outbox",
+		Name:      "parked_messages",
+		Help:      "Count of outbox messages parked after too many failed attempts",
+	},
+)
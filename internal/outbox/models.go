@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a side effect of the feed item change which has to be published to NATS
+type Message struct {
+	ID          uint64 `gorm:"primarykey"`
+	CreatedAt   time.Time
+	DaoID       uuid.UUID
+	Subject     string
+	Payload     json.RawMessage
+	PublishedAt *time.Time
+	Attempts    int
+	LastError   string
+	// ParkedAt is set when the message has failed too many times, parked messages are not relayed anymore
+	ParkedAt *time.Time
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// NewMessage marshals the payload and creates the message for the subject
+func NewMessage(daoID uuid.UUID, subject string, payload any) (Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		DaoID:   daoID,
+		Subject: subject,
+		Payload: data,
+	}, nil
+}
@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+const (
+	cleanupInterval  = time.Hour
+	cleanupBatchSize = 1000
+)
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+type DataProvider interface {
+	WithLock(fn func(DataProvider) error) (bool, error)
+	GetPending(limit int) ([]Message, error)
+	MarkPublished(ids []uint64, at time.Time) error
+	MarkFailed(id uint64, reason string) error
+	Park(id uint64, at time.Time) error
+	CountParked() (int64, error)
+	GetOldestPendingCreatedAt() (*time.Time, error)
+	DeletePublishedBefore(before time.Time, limit int) (int64, error)
+}
+
+// Relay publishes outbox messages with at-least-once guarantee keeping the order of messages per DAO
+type Relay struct {
+	repo      DataProvider
+	publisher Publisher
+
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+	// maxAttempts is the count of failed attempts after which the message is parked, zero means no limit
+	maxAttempts int
+}
+
+func NewRelay(repo DataProvider, publisher Publisher, interval time.Duration, batchSize int, retention time.Duration, maxAttempts int) *Relay {
+	return &Relay{
+		repo:        repo,
+		publisher:   publisher,
+		interval:    interval,
+		batchSize:   batchSize,
+		retention:   retention,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) error {
+	log.Info().Msg("outbox relay is started")
+
+	var lastCleanup time.Time
+	for {
+		for {
+			published, err := r.relayBatch(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("relay outbox messages")
+			}
+
+			// the batch is not full or some messages are postponed, wait for the next tick
+			if err != nil || published < r.batchSize {
+				break
+			}
+		}
+
+		r.updateLag()
+		r.updateParked()
+
+		if time.Since(lastCleanup) > cleanupInterval {
+			r.cleanup()
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// relayBatch publishes the batch of pending messages in order of creation.
+// If some message of the DAO can't be published, the next messages of the DAO are postponed to keep the order.
+func (r *Relay) relayBatch(ctx context.Context) (published int, err error) {
+	defer func(start time.Time) {
+		metricRelayHistogram.
+			WithLabelValues(metrics.ErrLabelValue(err)).
+			Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	_, err = r.repo.WithLock(func(tx DataProvider) error {
+		list, err := tx.GetPending(r.batchSize)
+		if err != nil {
+			return fmt.Errorf("get pending: %w", err)
+		}
+
+		failed := make(map[uuid.UUID]struct{})
+		ids := make([]uint64, 0, len(list))
+		for _, msg := range list {
+			if _, ok := failed[msg.DaoID]; ok {
+				continue
+			}
+
+			err := r.publisher.PublishJSON(ctx, msg.Subject, msg.Payload)
+			metricPublishedCounter.WithLabelValues(msg.Subject, metrics.ErrLabelValue(err)).Inc()
+			if err != nil {
+				failed[msg.DaoID] = struct{}{}
+
+				log.Error().
+					Err(err).
+					Uint64("message_id", msg.ID).
+					Str("subject", msg.Subject).
+					Msg("publish outbox message")
+
+				if err = tx.MarkFailed(msg.ID, err.Error()); err != nil {
+					return fmt.Errorf("mark failed: %w", err)
+				}
+
+				if r.maxAttempts > 0 && msg.Attempts+1 >= r.maxAttempts {
+					log.Warn().
+						Uint64("message_id", msg.ID).
+						Str("subject", msg.Subject).
+						Int("attempts", msg.Attempts+1).
+						Msg("outbox message is parked")
+
+					// the parked message doesn't block next messages of the DAO anymore
+					if err = tx.Park(msg.ID, time.Now().UTC()); err != nil {
+						return fmt.Errorf("park: %w", err)
+					}
+				}
+
+				continue
+			}
+
+			ids = append(ids, msg.ID)
+		}
+
+		if err := tx.MarkPublished(ids, time.Now().UTC()); err != nil {
+			return fmt.Errorf("mark published: %w", err)
+		}
+
+		published = len(ids)
+		if len(failed) > 0 {
+			// don't fetch the next batch immediately
+			published = 0
+		}
+
+		return nil
+	})
+
+	return published, err
+}
+
+func (r *Relay) updateLag() {
+	oldest, err := r.repo.GetOldestPendingCreatedAt()
+	if err != nil {
+		log.Error().Err(err).Msg("get oldest pending outbox message")
+		return
+	}
+
+	if oldest == nil {
+		metricRelayLag.Set(0)
+		return
+	}
+
+	metricRelayLag.Set(time.Since(*oldest).Seconds())
+}
+
+func (r *Relay) updateParked() {
+	count, err := r.repo.CountParked()
+	if err != nil {
+		log.Error().Err(err).Msg("count parked outbox messages")
+		return
+	}
+
+	metricParkedGauge.Set(float64(count))
+}
+
+func (r *Relay) cleanup() {
+	before := time.Now().Add(-r.retention)
+	for {
+		deleted, err := r.repo.DeletePublishedBefore(before, cleanupBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("cleanup outbox messages")
+			return
+		}
+
+		if deleted < cleanupBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRepo struct {
+	messages []Message
+	locked   bool
+}
+
+func (r *memoryRepo) WithLock(fn func(DataProvider) error) (bool, error) {
+	if r.locked {
+		return false, nil
+	}
+
+	return true, fn(r)
+}
+
+func (r *memoryRepo) GetPending(limit int) ([]Message, error) {
+	var res []Message
+	for _, msg := range r.messages {
+		if msg.PublishedAt == nil && msg.ParkedAt == nil && len(res) < limit {
+			res = append(res, msg)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *memoryRepo) MarkPublished(ids []uint64, at time.Time) error {
+	for _, id := range ids {
+		for i := range r.messages {
+			if r.messages[i].ID == id {
+				r.messages[i].PublishedAt = &at
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRepo) MarkFailed(id uint64, reason string) error {
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].Attempts++
+			r.messages[i].LastError = reason
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRepo) Park(id uint64, at time.Time) error {
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			r.messages[i].ParkedAt = &at
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryRepo) CountParked() (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryRepo) GetOldestPendingCreatedAt() (*time.Time, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) DeletePublishedBefore(_ time.Time, _ int) (int64, error) {
+	return 0, nil
+}
+
+type flakyPublisher struct {
+	failSubjects map[string]bool
+	published    []string
+}
+
+func (p *flakyPublisher) PublishJSON(_ context.Context, subject string, _ any) error {
+	if p.failSubjects[subject] {
+		return errors.New("publish error")
+	}
+
+	p.published = append(p.published, subject)
+
+	return nil
+}
+
+func TestUnitRelayKeepsOrderPerDao(t *testing.T) {
+	daoA, daoB := uuid.New(), uuid.New()
+	repo := &memoryRepo{messages: []Message{
+		{ID: 1, DaoID: daoA, Subject: "a1"},
+		{ID: 2, DaoID: daoB, Subject: "b1"},
+		{ID: 3, DaoID: daoA, Subject: "a2"},
+		{ID: 4, DaoID: daoB, Subject: "b2"},
+	}}
+	publisher := &flakyPublisher{failSubjects: map[string]bool{"a1": true}}
+	relay := NewRelay(repo, publisher, time.Second, 10, time.Hour, 0)
+
+	published, err := relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+	require.Equal(t, []string{"b1", "b2"}, publisher.published)
+	require.Equal(t, 1, repo.messages[0].Attempts)
+	require.Nil(t, repo.messages[2].PublishedAt, "message a2 has to wait for a1")
+
+	publisher.failSubjects = nil
+	published, err = relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+	require.Equal(t, []string{"b1", "b2", "a1", "a2"}, publisher.published)
+}
+
+func TestUnitRelaySkipsWithoutLock(t *testing.T) {
+	repo := &memoryRepo{
+		messages: []Message{{ID: 1, Subject: "a1"}},
+		locked:   true,
+	}
+	publisher := &flakyPublisher{}
+	relay := NewRelay(repo, publisher, time.Second, 10, time.Hour, 0)
+
+	published, err := relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, published)
+	require.Empty(t, publisher.published)
+}
+
+func TestUnitRelayParksMessageAfterMaxAttempts(t *testing.T) {
+	daoID := uuid.New()
+	repo := &memoryRepo{messages: []Message{
+		{ID: 1, DaoID: daoID, Subject: "a1"},
+		{ID: 2, DaoID: daoID, Subject: "a2"},
+	}}
+	publisher := &flakyPublisher{failSubjects: map[string]bool{"a1": true}}
+	relay := NewRelay(repo, publisher, time.Second, 10, time.Hour, 2)
+
+	_, err := relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, repo.messages[0].ParkedAt)
+	require.Empty(t, publisher.published)
+
+	_, err = relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, repo.messages[0].Attempts)
+	require.NotNil(t, repo.messages[0].ParkedAt)
+
+	// the next message of the DAO is not blocked by the parked one
+	_, err = relay.relayBatch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"a2"}, publisher.published)
+	require.Nil(t, repo.messages[0].PublishedAt)
+}
@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pglock"
+)
+
+type Repo struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// Create stores messages, pass the transaction connection to store them atomically with the related changes
+func Create(db *gorm.DB, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return db.Create(&messages).Error
+}
+
+// WithLock runs fn in the transaction holding the relay lock.
+// It returns false without calling fn if the lock is held by another replica.
+func (r *Repo) WithLock(fn func(DataProvider) error) (bool, error) {
+	var locked bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("select pg_try_advisory_xact_lock(?)", pglock.OutboxRelayKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		return fn(&Repo{db: tx})
+	})
+
+	return locked, err
+}
+
+func (r *Repo) GetPending(limit int) ([]Message, error) {
+	var (
+		list  []Message
+		dummy Message
+		_     = dummy.PublishedAt
+	)
+
+	err := r.db.
+		Where("published_at is null and parked_at is null").
+		Order("id asc").
+		Limit(limit).
+		Find(&list).
+		Error
+
+	return list, err
+}
+
+func (r *Repo) MarkPublished(ids []uint64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.
+		Model(&Message{}).
+		Where("id in ?", ids).
+		Update("published_at", at).
+		Error
+}
+
+func (r *Repo) MarkFailed(id uint64, reason string) error {
+	return r.db.
+		Model(&Message{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": reason,
+		}).
+		Error
+}
+
+// Park stops relaying of the message, it's kept for the manual investigation
+func (r *Repo) Park(id uint64, at time.Time) error {
+	return r.db.
+		Model(&Message{}).
+		Where("id = ?", id).
+		Update("parked_at", at).
+		Error
+}
+
+func (r *Repo) CountParked() (int64, error) {
+	var count int64
+	err := r.db.
+		Model(&Message{}).
+		Where("parked_at is not null").
+		Count(&count).
+		Error
+
+	return count, err
+}
+
+// GetOldestPendingCreatedAt returns creation time of the oldest not published message or nil if there are no one
+func (r *Repo) GetOldestPendingCreatedAt() (*time.Time, error) {
+	var list []Message
+	err := r.db.
+		Where("published_at is null and parked_at is null").
+		Order("id asc").
+		Limit(1).
+		Find(&list).
+		Error
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+
+	return &list[0].CreatedAt, nil
+}
+
+func (r *Repo) DeletePublishedBefore(before time.Time, limit int) (int64, error) {
+	ids := r.db.
+		Model(&Message{}).
+		Select("id").
+		Where("published_at < ?", before).
+		Limit(limit)
+
+	res := r.db.
+		Where("id in (?)", ids).
+		Delete(&Message{})
+
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,76 @@
+// Package pglock runs jobs under postgres advisory locks, so replicas take turns instead of running them concurrently
+package pglock
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Keys of advisory locks are kept together, so different jobs don't share the key by mistake
+const (
+	// OutboxRelayKey allows only one relay at a time to keep the order of messages
+	OutboxRelayKey int64 = 7_340_032_001
+	// MigrationsKey serializes migrations of the replicas started at the same time
+	MigrationsKey int64 = 7_340_032_002
+	// DeliveriesRetentionKey allows only one replica at a time to remove old webhook deliveries
+	DeliveriesRetentionKey int64 = 7_340_032_003
+	// ItemsRetentionKey allows only one replica at a time to apply retention policies of feed items
+	ItemsRetentionKey int64 = 7_340_032_004
+)
+
+// Classes of transaction locks of objects. Locks of two keys don't conflict with locks of one key above.
+const (
+	// BatchesClass allows only one replica at a time to claim the webhook batch
+	BatchesClass int32 = 7_340_032
+)
+
+// WithSessionLock runs fn if the advisory lock of the key is acquired and returns false without calling fn otherwise.
+// The lock is held by the dedicated connection, so fn could run several transactions by the pool connections.
+func WithSessionLock(ctx context.Context, db *gorm.DB, key int64, fn func() error) (bool, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return false, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, "select pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, fmt.Errorf("lock: %w", err)
+	}
+
+	if !locked {
+		return false, nil
+	}
+
+	fnErr := fn()
+
+	if _, err = conn.ExecContext(context.Background(), "select pg_advisory_unlock($1)", key); err != nil {
+		// the connection still holds the lock, so it mustn't be returned to the pool
+		_ = conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+
+		return true, fmt.Errorf("unlock: %w", err)
+	}
+
+	return true, fnErr
+}
+
+// TryXactLock acquires the advisory lock of the object of the class by the transaction, the object is hashed to the key.
+// It returns false if the lock is held by another transaction, the acquired lock is released when the transaction ends.
+func TryXactLock(tx *gorm.DB, class int32, object string) (bool, error) {
+	var locked bool
+	if err := tx.Raw("select pg_try_advisory_xact_lock(?, hashtext(?))", class, object).Scan(&locked).Error; err != nil {
+		return false, fmt.Errorf("lock %s: %w", object, err)
+	}
+
+	return locked, nil
+}
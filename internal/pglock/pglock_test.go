@@ -0,0 +1,81 @@
+// the test is in the external package because testdb applies migrations which use lock keys of this package
+package pglock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pglock"
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+const testLockKey = 7_340_032_999
+
+func TestIntegrationWithSessionLock(t *testing.T) {
+	db := testdb.Open(t)
+	ctx := context.Background()
+
+	locked, err := pglock.WithSessionLock(ctx, db, testLockKey, func() error {
+		// another replica doesn't get the lock while it's held
+		locked, err := pglock.WithSessionLock(ctx, db, testLockKey, func() error {
+			t.Fatal("fn is called without the lock")
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		return errors.New("job error")
+	})
+	require.True(t, locked)
+	require.EqualError(t, err, "job error")
+
+	// the lock is released even if the job is failed
+	called := false
+	locked, err = pglock.WithSessionLock(ctx, db, testLockKey, func() error {
+		called = true
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, locked)
+	require.True(t, called)
+}
+
+func TestIntegrationTryXactLock(t *testing.T) {
+	db := testdb.Open(t)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		locked, err := pglock.TryXactLock(tx, pglock.BatchesClass, "object")
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		// another transaction doesn't get the lock of the same object
+		return db.Transaction(func(other *gorm.DB) error {
+			locked, err := pglock.TryXactLock(other, pglock.BatchesClass, "object")
+			require.NoError(t, err)
+			require.False(t, locked)
+
+			locked, err = pglock.TryXactLock(other, pglock.BatchesClass, "another object")
+			require.NoError(t, err)
+			require.True(t, locked)
+
+			return nil
+		})
+	})
+	require.NoError(t, err)
+
+	// the lock is released by the end of the transaction
+	err = db.Transaction(func(tx *gorm.DB) error {
+		locked, err := pglock.TryXactLock(tx, pglock.BatchesClass, "object")
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,90 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// NatsBroadcaster delivers messages published on any replica to subscribers of the local PubSub
+// through the NATS core subject.
+type NatsBroadcaster struct {
+	conn    *nats.Conn
+	subject string
+	local   *PubSub[string]
+
+	sub *nats.Subscription
+}
+
+func NewNatsBroadcaster(nc *nats.Conn, subject string, local *PubSub[string]) *NatsBroadcaster {
+	return &NatsBroadcaster{
+		conn:    nc,
+		subject: subject,
+		local:   local,
+	}
+}
+
+func (b *NatsBroadcaster) Subscribe(topics ...string) chan string {
+	return b.local.Subscribe(topics...)
+}
+
+func (b *NatsBroadcaster) UpdateTopics(ch chan string, topics ...string) {
+	b.local.UpdateTopics(ch, topics...)
+}
+
+func (b *NatsBroadcaster) Unsubscribe(ch chan string) {
+	b.local.Unsubscribe(ch)
+}
+
+func (b *NatsBroadcaster) PublishNoWait(msg string) {
+	if err := b.conn.Publish(b.subject, []byte(msg)); err != nil {
+		log.Error().Err(err).Str("subject", b.subject).Msg("broadcast notification")
+
+		// at least watchers of the current replica will be notified
+		b.local.PublishNoWait(msg)
+	}
+}
+
+// Listen subscribes to the subject, messages are delivered to the local subscribers since then.
+// It's called by Start if it's not called before.
+func (b *NatsBroadcaster) Listen() error {
+	if b.sub != nil {
+		return nil
+	}
+
+	sub, err := b.conn.Subscribe(b.subject, func(msg *nats.Msg) {
+		b.local.PublishNoWait(string(msg.Data))
+	})
+	if err != nil {
+		return fmt.Errorf("subscribe to %s: %w", b.subject, err)
+	}
+
+	b.sub = sub
+
+	return nil
+}
+
+func (b *NatsBroadcaster) Start(ctx context.Context) error {
+	// the channel is not closed, the connection could send the status to it concurrently
+	reconnected := b.conn.StatusChanged(nats.CONNECTED)
+
+	if err := b.Listen(); err != nil {
+		return err
+	}
+
+	log.Info().Str("subject", b.subject).Msg("nats broadcaster is started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return b.sub.Unsubscribe()
+		case <-reconnected:
+			log.Warn().Str("subject", b.subject).Msg("nats broadcaster is reconnected")
+
+			// messages might be lost while we were disconnected, so wake up all watchers
+			b.local.BroadcastNoWait("")
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const pgReconnectTimeout = time.Second
+
+// PgBroadcaster delivers messages published on any replica to subscribers of the local PubSub
+// through the Postgres LISTEN/NOTIFY channel.
+type PgBroadcaster struct {
+	db      *gorm.DB
+	dsn     string
+	channel string
+	local   *PubSub[string]
+
+	// conn is the listening connection established by Listen before Start
+	conn *pgx.Conn
+}
+
+func NewPgBroadcaster(db *gorm.DB, dsn, channel string, local *PubSub[string]) *PgBroadcaster {
+	return &PgBroadcaster{
+		db:      db,
+		dsn:     dsn,
+		channel: channel,
+		local:   local,
+	}
+}
+
+func (b *PgBroadcaster) Subscribe(topics ...string) chan string {
+	return b.local.Subscribe(topics...)
+}
+
+func (b *PgBroadcaster) UpdateTopics(ch chan string, topics ...string) {
+	b.local.UpdateTopics(ch, topics...)
+}
+
+func (b *PgBroadcaster) Unsubscribe(ch chan string) {
+	b.local.Unsubscribe(ch)
+}
+
+func (b *PgBroadcaster) PublishNoWait(msg string) {
+	if err := b.db.Exec("select pg_notify(?, ?)", b.channel, msg).Error; err != nil {
+		log.Error().Err(err).Str("channel", b.channel).Msg("broadcast notification")
+
+		// at least watchers of the current replica will be notified
+		b.local.PublishNoWait(msg)
+	}
+}
+
+// Listen starts listening of the channel, notifications are delivered to the local subscribers after Start is called.
+// Notifications received before Start are kept by the connection.
+func (b *PgBroadcaster) Listen(ctx context.Context) error {
+	if b.conn != nil {
+		return nil
+	}
+
+	conn, err := b.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.conn = conn
+
+	return nil
+}
+
+func (b *PgBroadcaster) Start(ctx context.Context) error {
+	log.Info().Str("channel", b.channel).Msg("postgres broadcaster is started")
+
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Error().Err(err).Str("channel", b.channel).Msg("listen notifications")
+
+		// notifications might be lost while we are reconnecting, so wake up all watchers after reconnect
+		b.local.BroadcastNoWait("")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pgReconnectTimeout):
+		}
+	}
+}
+
+func (b *PgBroadcaster) listen(ctx context.Context) error {
+	conn := b.conn
+	b.conn = nil
+
+	if conn == nil {
+		var err error
+		if conn, err = b.connect(ctx); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		b.local.PublishNoWait(notification.Payload)
+	}
+}
+
+func (b *PgBroadcaster) connect(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	if _, err = conn.Exec(ctx, "listen "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		_ = conn.Close(context.Background())
+
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+
+	return conn, nil
+}
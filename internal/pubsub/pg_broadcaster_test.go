@@ -0,0 +1,72 @@
+package pubsub
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+const testBroadcastTimeout = 5 * time.Second
+
+func TestIntegrationPgBroadcaster(t *testing.T) {
+	db := testdb.Open(t)
+	dsn := os.Getenv(testdb.DSNEnv)
+	channel := "test_pg_broadcaster"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sender := NewPgBroadcaster(db, dsn, channel, NewPubSub[string](10))
+	receiver := NewPgBroadcaster(db, dsn, channel, NewPubSub[string](10))
+	go func() { _ = receiver.Start(ctx) }()
+
+	ch := receiver.Subscribe("dao")
+	waitListeners(t, db, channel, 1)
+
+	sender.PublishNoWait("other")
+	sender.PublishNoWait("dao")
+	require.Equal(t, "dao", receive(t, ch))
+
+	// notifications sent while the listener is reconnecting are lost, so all watchers are woken up
+	require.NoError(t, db.Exec("select pg_terminate_backend(pid) from pg_stat_activity where query = ?", listenQuery(channel)).Error)
+	require.Equal(t, "", receive(t, ch))
+
+	waitListeners(t, db, channel, 1)
+	sender.PublishNoWait("dao")
+	require.Equal(t, "dao", receive(t, ch))
+}
+
+func listenQuery(channel string) string {
+	return `listen "` + channel + `"`
+}
+
+// waitListeners waits for the listening connection, notifications sent before it are not delivered
+func waitListeners(t *testing.T, db *gorm.DB, channel string, count int64) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		var n int64
+		err := db.Raw("select count(*) from pg_stat_activity where query = ? and state = 'idle'", listenQuery(channel)).Scan(&n).Error
+
+		return err == nil && n == count
+	}, testBroadcastTimeout, 10*time.Millisecond)
+}
+
+func receive(t *testing.T, ch chan string) string {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(testBroadcastTimeout):
+		t.Fatal("message is not received")
+
+		return ""
+	}
+}
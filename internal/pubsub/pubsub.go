@@ -4,30 +4,53 @@ import (
 	"sync"
 )
 
-type PubSub[T any] struct {
-	subs       map[chan T]bool
+// PubSub delivers published messages to subscribers. The message itself is used as a topic:
+// subscribers with topics receive only messages equal to one of their topics,
+// subscribers without topics receive all messages.
+type PubSub[T comparable] struct {
+	subs       map[chan T]map[T]struct{}
+	topics     map[T]map[chan T]struct{}
+	wildcard   map[chan T]struct{}
 	bufferSize int
 	closed     bool
 
 	m sync.RWMutex
 }
 
-func NewPubSub[T any](bufferSize int) *PubSub[T] {
+func NewPubSub[T comparable](bufferSize int) *PubSub[T] {
 	return &PubSub[T]{
-		subs:       make(map[chan T]bool),
+		subs:       make(map[chan T]map[T]struct{}),
+		topics:     make(map[T]map[chan T]struct{}),
+		wildcard:   make(map[chan T]struct{}),
 		bufferSize: bufferSize,
 	}
 }
 
-func (p *PubSub[T]) Subscribe() chan T {
+// Subscribe creates a subscription for the topics, if there are no topics the subscription receives all messages
+func (p *PubSub[T]) Subscribe(topics ...T) chan T {
 	p.m.Lock()
 	defer p.m.Unlock()
 
 	ch := make(chan T, p.bufferSize)
-	p.subs[ch] = true
+	p.subs[ch] = nil
+	p.bind(ch, topics)
+
 	return ch
 }
 
+// UpdateTopics replaces topics of the existing subscription
+func (p *PubSub[T]) UpdateTopics(ch chan T, topics ...T) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if _, ok := p.subs[ch]; !ok {
+		return
+	}
+
+	p.unbind(ch)
+	p.bind(ch, topics)
+}
+
 func (p *PubSub[T]) Publish(msg T) {
 	p.m.RLock()
 	defer p.m.RUnlock()
@@ -35,7 +58,11 @@ func (p *PubSub[T]) Publish(msg T) {
 	if p.closed {
 		return
 	}
-	for ch := range p.subs {
+
+	for ch := range p.wildcard {
+		ch <- msg
+	}
+	for ch := range p.topics[msg] {
 		ch <- msg
 	}
 }
@@ -48,11 +75,25 @@ func (p *PubSub[T]) PublishNoWait(msg T) {
 		return
 	}
 
+	for ch := range p.wildcard {
+		sendNoWait(ch, msg)
+	}
+	for ch := range p.topics[msg] {
+		sendNoWait(ch, msg)
+	}
+}
+
+// BroadcastNoWait delivers the message to all subscribers regardless of their topics
+func (p *PubSub[T]) BroadcastNoWait(msg T) {
+	p.m.RLock()
+	defer p.m.RUnlock()
+
+	if p.closed {
+		return
+	}
+
 	for ch := range p.subs {
-		select {
-		case ch <- msg:
-		default:
-		}
+		sendNoWait(ch, msg)
 	}
 }
 
@@ -64,6 +105,7 @@ func (p *PubSub[T]) Unsubscribe(ch chan T) {
 		return
 	}
 
+	p.unbind(ch)
 	delete(p.subs, ch)
 }
 
@@ -74,8 +116,52 @@ func (p *PubSub[T]) Close() {
 	if !p.closed {
 		p.closed = true
 		for ch := range p.subs {
+			p.unbind(ch)
 			delete(p.subs, ch)
 			close(ch)
 		}
 	}
 }
+
+func (p *PubSub[T]) bind(ch chan T, topics []T) {
+	if len(topics) == 0 {
+		p.wildcard[ch] = struct{}{}
+
+		return
+	}
+
+	subTopics := make(map[T]struct{}, len(topics))
+	for _, topic := range topics {
+		subTopics[topic] = struct{}{}
+
+		chans, ok := p.topics[topic]
+		if !ok {
+			chans = make(map[chan T]struct{})
+			p.topics[topic] = chans
+		}
+		chans[ch] = struct{}{}
+	}
+
+	p.subs[ch] = subTopics
+}
+
+func (p *PubSub[T]) unbind(ch chan T) {
+	delete(p.wildcard, ch)
+
+	for topic := range p.subs[ch] {
+		chans := p.topics[topic]
+		delete(chans, ch)
+		if len(chans) == 0 {
+			delete(p.topics, topic)
+		}
+	}
+
+	p.subs[ch] = nil
+}
+
+func sendNoWait[T any](ch chan T, msg T) {
+	select {
+	case ch <- msg:
+	default:
+	}
+}
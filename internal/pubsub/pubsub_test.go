@@ -1,6 +1,7 @@
 package pubsub
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/smartystreets/goconvey/convey"
@@ -41,5 +42,80 @@ func TestPubSub(t *testing.T) {
 			convey.So(<-subCh, convey.ShouldEqual, "test")
 			convey.So(<-subCh2, convey.ShouldEqual, "test")
 		})
+
+		convey.Convey("Subscribe for topics", func() {
+			subA := ps.Subscribe("a")
+			subAB := ps.Subscribe("a", "b")
+			subAll := ps.Subscribe()
+
+			ps.PublishNoWait("b")
+
+			convey.So(len(subA), convey.ShouldEqual, 0)
+			convey.So(<-subAB, convey.ShouldEqual, "b")
+			convey.So(<-subAll, convey.ShouldEqual, "b")
+
+			ps.PublishNoWait("c")
+
+			convey.So(len(subA), convey.ShouldEqual, 0)
+			convey.So(len(subAB), convey.ShouldEqual, 0)
+			convey.So(<-subAll, convey.ShouldEqual, "c")
+		})
+
+		convey.Convey("Update topics", func() {
+			sub := ps.Subscribe("a")
+			ps.UpdateTopics(sub, "b")
+
+			ps.PublishNoWait("a")
+			convey.So(len(sub), convey.ShouldEqual, 0)
+
+			ps.PublishNoWait("b")
+			convey.So(<-sub, convey.ShouldEqual, "b")
+
+			ps.Unsubscribe(sub)
+			ps.PublishNoWait("b")
+			convey.So(len(sub), convey.ShouldEqual, 0)
+			convey.So(ps.topics, convey.ShouldBeEmpty)
+		})
+
+		convey.Convey("Broadcast ignores topics", func() {
+			subA := ps.Subscribe("a")
+			subB := ps.Subscribe("b")
+
+			ps.BroadcastNoWait("c")
+
+			convey.So(<-subA, convey.ShouldEqual, "c")
+			convey.So(<-subB, convey.ShouldEqual, "c")
+		})
+
+		convey.Convey("Coalesce messages over the buffer", func() {
+			sub := ps.Subscribe("a")
+			for i := 0; i < 10; i++ {
+				ps.PublishNoWait("a")
+			}
+
+			convey.So(len(sub), convey.ShouldEqual, 1)
+		})
 	})
 }
+
+func BenchmarkPublishNoWait(b *testing.B) {
+	const subscribers = 5000
+
+	for _, withTopics := range []bool{false, true} {
+		b.Run(fmt.Sprintf("topics=%t", withTopics), func(b *testing.B) {
+			ps := NewPubSub[string](1)
+			for i := 0; i < subscribers; i++ {
+				if withTopics {
+					ps.Subscribe(fmt.Sprintf("dao-%d", i))
+				} else {
+					ps.Subscribe()
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ps.PublishNoWait(fmt.Sprintf("dao-%d", i%subscribers))
+			}
+		})
+	}
+}
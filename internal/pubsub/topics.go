@@ -0,0 +1,25 @@
+package pubsub
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	addressTopicPrefix    = "address:"
+	subscriberTopicPrefix = "subscriber:"
+)
+
+// DaoTopic is a topic of the DAO related feed items
+func DaoTopic(id uuid.UUID) string {
+	return id.String()
+}
+
+// AddressTopic is a topic of the address activity feed items
+func AddressTopic(address string) string {
+	return addressTopicPrefix + address
+}
+
+// SubscriberTopic is a personal topic of the subscriber
+func SubscriberTopic(id uuid.UUID) string {
+	return subscriberTopicPrefix + id.String()
+}
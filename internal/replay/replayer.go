@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Handler handles raw events of its subjects, it's implemented by feed item consumers
+type Handler interface {
+	Subjects() []string
+	HandleMessage(subject string, data []byte) error
+}
+
+// Stats contains counters of the replay
+type Stats struct {
+	Read    int `json:"read"`
+	Handled int `json:"handled"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// Replayer feeds source events to the handlers of their subjects one by one keeping the source order
+type Replayer struct {
+	handlers      map[string]Handler
+	progressEvery int
+}
+
+func NewReplayer(progressEvery int, handlers ...Handler) *Replayer {
+	r := &Replayer{
+		handlers:      make(map[string]Handler),
+		progressEvery: progressEvery,
+	}
+
+	for _, h := range handlers {
+		for _, subject := range h.Subjects() {
+			r.handlers[subject] = h
+		}
+	}
+
+	return r
+}
+
+// Limit keeps only handlers of the subjects, events of other subjects are skipped
+func (r *Replayer) Limit(subjects []string) {
+	allowed := make(map[string]Handler, len(subjects))
+	for _, subject := range subjects {
+		if h, ok := r.handlers[subject]; ok {
+			allowed[subject] = h
+		}
+	}
+
+	r.handlers = allowed
+}
+
+// Subjects returns all subjects which could be replayed
+func (r *Replayer) Subjects() []string {
+	subjects := make([]string, 0, len(r.handlers))
+	for subject := range r.handlers {
+		subjects = append(subjects, subject)
+	}
+
+	sort.Strings(subjects)
+
+	return subjects
+}
+
+// Run reads all events from the source, events of unknown subjects are skipped,
+// failed events are logged and don't stop the replay
+func (r *Replayer) Run(ctx context.Context, src Source) (Stats, error) {
+	var (
+		stats Stats
+		start = time.Now()
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		event, err := src.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read event: %w", err)
+		}
+
+		stats.Read++
+
+		handler, ok := r.handlers[event.Subject]
+		if !ok {
+			stats.Skipped++
+		} else if err = handler.HandleMessage(event.Subject, event.Data); err != nil {
+			stats.Failed++
+			log.Error().Err(err).Str("subject", event.Subject).Msg("replay event")
+		} else {
+			stats.Handled++
+		}
+
+		if r.progressEvery > 0 && stats.Read%r.progressEvery == 0 {
+			log.Info().
+				Int("read", stats.Read).
+				Int("handled", stats.Handled).
+				Int("skipped", stats.Skipped).
+				Int("failed", stats.Failed).
+				Dur("elapsed", time.Since(start)).
+				Msg("replay progress")
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	subjects []string
+	handled  []string
+}
+
+func (h *recordingHandler) Subjects() []string {
+	return h.subjects
+}
+
+func (h *recordingHandler) HandleMessage(subject string, data []byte) error {
+	if string(data) == `"broken"` {
+		return errors.New("broken payload")
+	}
+
+	h.handled = append(h.handled, subject+" "+string(data))
+
+	return nil
+}
+
+func writeDump(t *testing.T, lines string) string {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(lines), 0o600))
+
+	return path
+}
+
+func TestUnitReplayerKeepsOrderAndCountsEvents(t *testing.T) {
+	path := writeDump(t, `{"subject":"core.dao.created","data":{"id":1}}
+{"subject":"core.unknown","data":{}}
+
+{"subject":"core.dao.updated","data":"broken"}
+{"subject":"core.dao.updated","data":{"id":2}}
+`)
+
+	src, err := NewFileSource(path)
+	require.NoError(t, err)
+	defer src.Close()
+
+	h := &recordingHandler{subjects: []string{"core.dao.created", "core.dao.updated"}}
+	stats, err := NewReplayer(2, h).Run(context.Background(), src)
+	require.NoError(t, err)
+
+	require.Equal(t, Stats{Read: 4, Handled: 2, Skipped: 1, Failed: 1}, stats)
+	require.Equal(t, []string{`core.dao.created {"id":1}`, `core.dao.updated {"id":2}`}, h.handled)
+}
+
+func TestUnitReplayerLimitsSubjects(t *testing.T) {
+	path := writeDump(t, `{"subject":"core.dao.created","data":{"id":1}}
+{"subject":"core.dao.updated","data":{"id":2}}
+`)
+
+	src, err := NewFileSource(path)
+	require.NoError(t, err)
+	defer src.Close()
+
+	h := &recordingHandler{subjects: []string{"core.dao.created", "core.dao.updated"}}
+	r := NewReplayer(0, h)
+	r.Limit([]string{"core.dao.updated", "core.unknown"})
+
+	require.Equal(t, []string{"core.dao.updated"}, r.Subjects())
+
+	stats, err := r.Run(context.Background(), src)
+	require.NoError(t, err)
+	require.Equal(t, Stats{Read: 2, Handled: 1, Skipped: 1}, stats)
+}
+
+func TestUnitFileSourceReportsBrokenLine(t *testing.T) {
+	src, err := NewFileSource(writeDump(t, "{\"subject\":\"core.dao.created\",\"data\":{}}\nnot json\n"))
+	require.NoError(t, err)
+	defer src.Close()
+
+	_, err = NewReplayer(0).Run(context.Background(), src)
+	require.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,185 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	maxLineSize  = 16 * 1024 * 1024
+	fetchMaxWait = 5 * time.Second
+)
+
+// Event is the raw source event
+type Event struct {
+	Subject string          `json:"subject"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Source returns events in the order they were published, io.EOF is returned when there are no more events
+type Source interface {
+	Next(ctx context.Context) (Event, error)
+	Close() error
+}
+
+// FileSource reads events from the newline-delimited JSON dump, each line is {"subject": "...", "data": {...}}
+type FileSource struct {
+	file    *os.File
+	scanner *bufio.Scanner
+	line    int
+}
+
+func NewFileSource(path string) (*FileSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &FileSource{
+		file:    file,
+		scanner: scanner,
+	}, nil
+}
+
+func (s *FileSource) Next(_ context.Context) (Event, error) {
+	for s.scanner.Scan() {
+		s.line++
+
+		line := s.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return Event{}, fmt.Errorf("line %d: %w", s.line, err)
+		}
+
+		return event, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return Event{}, err
+	}
+
+	return Event{}, io.EOF
+}
+
+func (s *FileSource) Close() error {
+	return s.file.Close()
+}
+
+// JetStreamRange limits events read from the stream, zero values mean no limits
+type JetStreamRange struct {
+	FromSeq  uint64
+	ToSeq    uint64
+	FromTime time.Time
+	Subjects []string
+}
+
+// JetStreamSource reads events from the stream by the ephemeral ordered consumer, it owns the connection
+type JetStreamSource struct {
+	conn     *nats.Conn
+	consumer jetstream.Consumer
+	lastSeq  uint64
+	done     bool
+}
+
+func NewJetStreamSource(ctx context.Context, nc *nats.Conn, stream string, rng JetStreamRange) (*JetStreamSource, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := js.Stream(ctx, stream)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %s: %w", stream, err)
+	}
+
+	info, err := st.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get stream %s info: %w", stream, err)
+	}
+
+	// the stream could grow during the replay, so we stop on the last sequence known at the start
+	lastSeq := info.State.LastSeq
+	if rng.ToSeq > 0 && rng.ToSeq < lastSeq {
+		lastSeq = rng.ToSeq
+	}
+
+	cfg := jetstream.OrderedConsumerConfig{
+		FilterSubjects: rng.Subjects,
+		DeliverPolicy:  jetstream.DeliverAllPolicy,
+	}
+
+	switch {
+	case rng.FromSeq > 0:
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = rng.FromSeq
+	case !rng.FromTime.IsZero():
+		cfg.DeliverPolicy = jetstream.DeliverByStartTimePolicy
+		cfg.OptStartTime = &rng.FromTime
+	}
+
+	consumer, err := js.OrderedConsumer(ctx, stream, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create ordered consumer: %w", err)
+	}
+
+	return &JetStreamSource{
+		conn:     nc,
+		consumer: consumer,
+		lastSeq:  lastSeq,
+		done:     lastSeq == 0,
+	}, nil
+}
+
+func (s *JetStreamSource) Next(_ context.Context) (Event, error) {
+	if s.done {
+		return Event{}, io.EOF
+	}
+
+	msg, err := s.consumer.Next(jetstream.FetchMaxWait(fetchMaxWait))
+	if errors.Is(err, nats.ErrTimeout) {
+		// there are no more messages matched the filter
+		return Event{}, io.EOF
+	}
+	if err != nil {
+		return Event{}, err
+	}
+
+	meta, err := msg.Metadata()
+	if err != nil {
+		return Event{}, fmt.Errorf("message metadata: %w", err)
+	}
+
+	if meta.Sequence.Stream > s.lastSeq {
+		s.done = true
+		return Event{}, io.EOF
+	}
+
+	if meta.Sequence.Stream == s.lastSeq {
+		s.done = true
+	}
+
+	return Event{
+		Subject: msg.Subject(),
+		Data:    msg.Data(),
+	}, nil
+}
+
+func (s *JetStreamSource) Close() error {
+	return s.conn.Drain()
+}
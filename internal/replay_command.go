@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+	"github.com/goverland-labs/goverland-core-feed/internal/item"
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
+	"github.com/goverland-labs/goverland-core-feed/internal/replay"
+)
+
+const (
+	ReplaySourceFile      = "file"
+	ReplaySourceJetStream = "jetstream"
+)
+
+type ReplayOptions struct {
+	Source   string
+	File     string
+	Stream   string
+	Subjects []string
+	FromSeq  uint64
+	ToSeq    uint64
+	FromTime time.Time
+
+	DryRun          bool
+	IgnoreProcessed bool
+	ProgressEvery   int
+}
+
+// Replay rebuilds feed items from the source events by the feed item consumers handlers.
+// Timeline updates and subscriber callbacks are not sent for replayed events.
+// In the dry run mode nothing is stored and the diff of feed items is written to the output.
+func Replay(ctx context.Context, cfg config.App, opts ReplayOptions, out io.Writer) error {
+	a := &Application{cfg: cfg}
+
+	// subscriptions are not changed by the replay, so invalidations are not delivered to other replicas
+	a.cacheInvalidator = cache.NewInvalidator(pubsub.NewPubSub[string](0))
+
+	initializers := []func() error{
+		a.initStorage,
+		a.initSubscribers,
+		a.initSubscription,
+	}
+
+	for _, initializer := range initializers {
+		if err := initializer(); err != nil {
+			return err
+		}
+	}
+
+	var (
+		repo    = a.itemRepo
+		dryRepo *item.DryRunRepo
+	)
+
+	if opts.DryRun {
+		dryRepo = item.NewDryRunRepo(repo)
+		repo = dryRepo
+	}
+
+	// there are no feed events watchers in the replay process
+	service, err := item.NewService(repo, a.subscribers, a.webhookEndpoints, a.subscriptions, a.newCallbacksValidator(), pubsub.NewPubSub[string](0), a.newFiltersCache())
+	if err != nil {
+		return fmt.Errorf("item service: %w", err)
+	}
+
+	service.EnableReplayMode(opts.IgnoreProcessed)
+
+	dc, err := item.NewDaoConsumer(nil, service)
+	if err != nil {
+		return fmt.Errorf("item dao consumer: %w", err)
+	}
+
+	pc, err := item.NewProposalConsumer(nil, service)
+	if err != nil {
+		return fmt.Errorf("item proposal consumer: %w", err)
+	}
+
+	dlc, err := item.NewDelegatesConsumer(nil, service)
+	if err != nil {
+		return fmt.Errorf("item delegate consumer: %w", err)
+	}
+
+	dsc, err := item.NewDiscussionConsumer(nil, service)
+	if err != nil {
+		return fmt.Errorf("item discussion consumer: %w", err)
+	}
+
+	replayer := replay.NewReplayer(opts.ProgressEvery, dc, pc, dlc, dsc)
+	if len(opts.Subjects) > 0 {
+		replayer.Limit(opts.Subjects)
+	}
+
+	src, err := a.initReplaySource(ctx, opts, replayer.Subjects())
+	if err != nil {
+		return fmt.Errorf("replay source: %w", err)
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.Error().Err(err).Msg("close replay source")
+		}
+	}()
+
+	stats, err := replayer.Run(ctx, src)
+
+	log.Info().
+		Int("read", stats.Read).
+		Int("handled", stats.Handled).
+		Int("skipped", stats.Skipped).
+		Int("failed", stats.Failed).
+		Bool("dry_run", opts.DryRun).
+		Msg("replay finished")
+
+	if err != nil {
+		return err
+	}
+
+	if dryRepo == nil {
+		return nil
+	}
+
+	enc := json.NewEncoder(out)
+	for _, diff := range dryRepo.Diff() {
+		if err = enc.Encode(diff); err != nil {
+			return fmt.Errorf("write diff: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Application) initReplaySource(ctx context.Context, opts ReplayOptions, subjects []string) (replay.Source, error) {
+	switch opts.Source {
+	case ReplaySourceFile:
+		return replay.NewFileSource(opts.File)
+	case ReplaySourceJetStream:
+		nc, err := nats.Connect(
+			a.cfg.Nats.URL,
+			nats.MaxReconnects(a.cfg.Nats.MaxReconnects),
+			nats.ReconnectWait(a.cfg.Nats.ReconnectTimeout),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		src, err := replay.NewJetStreamSource(ctx, nc, opts.Stream, replay.JetStreamRange{
+			FromSeq:  opts.FromSeq,
+			ToSeq:    opts.ToSeq,
+			FromTime: opts.FromTime,
+			Subjects: subjects,
+		})
+		if err != nil {
+			nc.Close()
+			return nil, err
+		}
+
+		return src, nil
+	default:
+		return nil, fmt.Errorf("unknown replay source: %s", opts.Source)
+	}
+}
@@ -0,0 +1,100 @@
+package subscriber
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+)
+
+const (
+	apiKeyPrefix = "fk_"
+	apiKeyLength = 32
+)
+
+type APIKeyProvider interface {
+	CreateAPIKey(*APIKey) error
+	GetAPIKeyByHash(hash string) (*APIKey, error)
+	RevokeAPIKey(id uuid.UUID, revokedAt time.Time) error
+}
+
+// APIKeys issues and checks API keys of subscribers.
+// Checked keys are cached for the TTL, so the revoked key is accepted until the cached one is expired.
+type APIKeys struct {
+	repo  APIKeyProvider
+	subs  *Service
+	cache *cache.Cache[string, *APIKey]
+}
+
+func NewAPIKeys(repo APIKeyProvider, subs *Service, ttl time.Duration) *APIKeys {
+	return &APIKeys{
+		repo:  repo,
+		subs:  subs,
+		cache: cache.New[string, *APIKey]("api_keys", cache.Options{TTL: ttl}),
+	}
+}
+
+// Create issues the new key of the subscriber, the key itself is returned only once
+func (k *APIKeys) Create(ctx context.Context, subscriberID uuid.UUID, scopes []string) (string, *APIKey, error) {
+	if _, err := k.subs.GetByID(ctx, subscriberID); err != nil {
+		return "", nil, fmt.Errorf("get subscriber: %w", err)
+	}
+
+	raw := make([]byte, apiKeyLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("generate api key: %w", err)
+	}
+
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	item := &APIKey{
+		ID:           uuid.New(),
+		SubscriberID: subscriberID,
+		Hash:         hashAPIKey(key),
+		Scopes:       strings.Join(scopes, " "),
+	}
+
+	if err := k.repo.CreateAPIKey(item); err != nil {
+		return "", nil, fmt.Errorf("create api key: %w", err)
+	}
+
+	return key, item, nil
+}
+
+// Authenticate returns the not revoked key of the existing subscriber
+func (k *APIKeys) Authenticate(ctx context.Context, key string) (*APIKey, error) {
+	hash := hashAPIKey(key)
+	if item, ok := k.cache.Get(hash); ok {
+		return item, nil
+	}
+
+	item, err := k.repo.GetAPIKeyByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := k.subs.GetByID(ctx, item.SubscriberID); err != nil {
+		return nil, fmt.Errorf("get subscriber: %w", err)
+	}
+
+	k.cache.Set(hash, item)
+
+	return item, nil
+}
+
+func (k *APIKeys) Revoke(_ context.Context, id uuid.UUID) error {
+	return k.repo.RevokeAPIKey(id, time.Now())
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
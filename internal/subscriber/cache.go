@@ -1,41 +1,60 @@
 package subscriber
 
 import (
-	"sync"
-
 	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
 )
 
-type Cache struct {
-	mu sync.RWMutex
+// CacheName is the name of the cache in metrics and invalidations
+const CacheName = "subscribers"
 
-	data map[uuid.UUID]*Subscriber
+type Cache struct {
+	data *cache.Cache[uuid.UUID, *Subscriber]
 }
 
+// NewCache creates the cache without size and time limits
 func NewCache() *Cache {
+	return NewCacheWithOptions(cache.Options{})
+}
+
+func NewCacheWithOptions(opts cache.Options) *Cache {
 	return &Cache{
-		data: make(map[uuid.UUID]*Subscriber),
+		data: cache.New[uuid.UUID, *Subscriber](CacheName, opts),
 	}
 }
 
 func (c *Cache) UpsertItem(key uuid.UUID, value *Subscriber) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.data.Set(key, value)
+}
 
-	c.data[key] = value
+// UpsertItemIfVersion stores the loaded subscriber if the cache is not changed since the version was taken
+func (c *Cache) UpsertItemIfVersion(version cache.Version, key uuid.UUID, value *Subscriber) bool {
+	return c.data.SetIfVersion(version, key, value)
 }
 
-func (c *Cache) GetItem(key uuid.UUID) (*Subscriber, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+func (c *Cache) Version() cache.Version {
+	return c.data.Version()
+}
 
-	data, ok := c.data[key]
-	return data, ok
+func (c *Cache) GetItem(key uuid.UUID) (*Subscriber, bool) {
+	return c.data.Get(key)
 }
 
 func (c *Cache) RemoveItem(key uuid.UUID) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.data.Delete(key)
+}
+
+// InvalidateKey removes the subscriber changed by another replica
+func (c *Cache) InvalidateKey(key string) {
+	id, err := uuid.Parse(key)
+	if err != nil {
+		return
+	}
+
+	c.RemoveItem(id)
+}
 
-	delete(c.data, key)
+func (c *Cache) Purge() {
+	c.data.Purge()
 }
@@ -0,0 +1,193 @@
+package subscriber
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MemoryRepo keeps subscribers in memory, it's used for running the application and tests without the database
+type MemoryRepo struct {
+	mu      sync.RWMutex
+	items   map[uuid.UUID]Subscriber
+	apiKeys map[uuid.UUID]APIKey
+
+	endpoints map[uuid.UUID]WebhookEndpoint
+}
+
+func NewMemoryRepo() *MemoryRepo {
+	return &MemoryRepo{
+		items:   make(map[uuid.UUID]Subscriber),
+		apiKeys: make(map[uuid.UUID]APIKey),
+
+		endpoints: make(map[uuid.UUID]WebhookEndpoint),
+	}
+}
+
+func (r *MemoryRepo) Create(item *Subscriber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[item.ID]; ok {
+		return fmt.Errorf("subscriber #%s: %w", item.ID, gorm.ErrDuplicatedKey)
+	}
+
+	now := time.Now()
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	}
+	item.UpdatedAt = now
+
+	r.items[item.ID] = *item
+
+	return nil
+}
+
+func (r *MemoryRepo) Update(item *Subscriber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+	item.UpdatedAt = time.Now()
+
+	r.items[item.ID] = *item
+
+	return nil
+}
+
+func (r *MemoryRepo) GetByID(id uuid.UUID) (*Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.items[id]
+	if !ok || sub.DeletedAt.Valid {
+		return nil, fmt.Errorf("get subscriber by id #%s: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	return &sub, nil
+}
+
+func (r *MemoryRepo) CreateAPIKey(item *APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range r.apiKeys {
+		if key.ID == item.ID || key.Hash == item.Hash {
+			return fmt.Errorf("api key #%s: %w", item.ID, gorm.ErrDuplicatedKey)
+		}
+	}
+
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	r.apiKeys[item.ID] = *item
+
+	return nil
+}
+
+func (r *MemoryRepo) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.apiKeys {
+		if key.Hash == hash && key.RevokedAt == nil {
+			return &key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("get api key by hash: %w", gorm.ErrRecordNotFound)
+}
+
+func (r *MemoryRepo) RevokeAPIKey(id uuid.UUID, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.apiKeys[id]
+	if !ok || key.RevokedAt != nil {
+		return fmt.Errorf("revoke api key #%s: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	key.RevokedAt = &revokedAt
+	r.apiKeys[id] = key
+
+	return nil
+}
+
+func (r *MemoryRepo) CreateWebhookEndpoint(item *WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.endpoints[item.ID]; ok {
+		return fmt.Errorf("webhook endpoint #%s: %w", item.ID, gorm.ErrDuplicatedKey)
+	}
+
+	now := time.Now()
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now
+	}
+	item.UpdatedAt = now
+
+	r.endpoints[item.ID] = *item
+
+	return nil
+}
+
+func (r *MemoryRepo) UpdateWebhookEndpoint(item *WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.endpoints[item.ID]
+	if !ok || stored.SubscriberID != item.SubscriberID {
+		return fmt.Errorf("update webhook endpoint #%s: %w", item.ID, gorm.ErrRecordNotFound)
+	}
+
+	item.CreatedAt = stored.CreatedAt
+	item.UpdatedAt = time.Now()
+	r.endpoints[item.ID] = *item
+
+	return nil
+}
+
+func (r *MemoryRepo) DeleteWebhookEndpoint(subscriberID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.endpoints[id]
+	if !ok || stored.SubscriberID != subscriberID {
+		return fmt.Errorf("delete webhook endpoint #%s: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	delete(r.endpoints, id)
+
+	return nil
+}
+
+func (r *MemoryRepo) GetWebhookEndpoints(subscriberID uuid.UUID) ([]WebhookEndpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []WebhookEndpoint
+	for _, e := range r.endpoints {
+		if e.SubscriberID == subscriberID {
+			list = append(list, e)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+
+		return bytes.Compare(list[i].ID[:], list[j].ID[:]) < 0
+	})
+
+	return list, nil
+}
@@ -13,4 +13,72 @@ type Subscriber struct {
 	UpdatedAt  time.Time
 	DeletedAt  gorm.DeletedAt `gorm:"index"`
 	WebhookURL string
+
+	// BatchMaxSize enables batched callbacks of the http delivery if it's greater than one
+	BatchMaxSize int
+	// BatchMaxWaitMs is the longest time the callback waits for the batch in milliseconds
+	BatchMaxWaitMs int
+}
+
+func (s *Subscriber) BatchMaxWait() time.Duration {
+	return time.Duration(s.BatchMaxWaitMs) * time.Millisecond
+}
+
+// APIKey is the credential of the subscriber, only the hash of the key is stored
+type APIKey struct {
+	ID           uuid.UUID `gorm:"primary_key"`
+	CreatedAt    time.Time
+	RevokedAt    *time.Time
+	SubscriberID uuid.UUID
+	Hash         string
+	// Scopes are separated by spaces
+	Scopes string
+}
+
+// WebhookFormat is the format of callbacks sent to the webhook endpoint
+type WebhookFormat string
+
+const (
+	// WebhookFormatFull contains the feed item with the snapshot and the timeline
+	WebhookFormatFull WebhookFormat = "full"
+	// WebhookFormatCompact contains identifiers, the type and the action of the feed item only
+	WebhookFormatCompact WebhookFormat = "compact"
+)
+
+// WebhookEndpoint receives callbacks of the subscriber matching routing rules.
+// Empty rules match any value.
+type WebhookEndpoint struct {
+	ID           uuid.UUID `gorm:"primary_key"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	SubscriberID uuid.UUID
+	URL          string
+	Enabled      bool
+	Format       WebhookFormat
+
+	Types   []string    `gorm:"serializer:json"`
+	Actions []string    `gorm:"serializer:json"`
+	DaoIDs  []uuid.UUID `gorm:"serializer:json"`
+}
+
+// Matches returns true if the enabled endpoint routes feed items of the type, the action and the DAO
+func (e *WebhookEndpoint) Matches(itemType, action string, daoID uuid.UUID) bool {
+	return e.Enabled &&
+		matchesRule(e.Types, itemType) &&
+		matchesRule(e.Actions, action) &&
+		matchesRule(e.DaoIDs, daoID)
+}
+
+func matchesRule[T comparable](rule []T, value T) bool {
+	if len(rule) == 0 {
+		return true
+	}
+
+	for _, v := range rule {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
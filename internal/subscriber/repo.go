@@ -2,6 +2,7 @@ package subscriber
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -37,3 +38,95 @@ func (r *Repo) GetByID(id uuid.UUID) (*Subscriber, error) {
 
 	return &sub, nil
 }
+
+func (r *Repo) CreateAPIKey(item *APIKey) error {
+	return r.conn.Create(item).Error
+}
+
+// GetAPIKeyByHash returns the not revoked key by the hash
+func (r *Repo) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	var key APIKey
+
+	err := r.conn.
+		Where(APIKey{Hash: hash}).
+		Where("revoked_at is null").
+		First(&key).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get api key by hash: %w", err)
+	}
+
+	return &key, nil
+}
+
+func (r *Repo) RevokeAPIKey(id uuid.UUID, revokedAt time.Time) error {
+	res := r.conn.
+		Model(&APIKey{}).
+		Where(APIKey{ID: id}).
+		Where("revoked_at is null").
+		Update("revoked_at", revokedAt)
+
+	if res.Error != nil {
+		return fmt.Errorf("revoke api key #%s: %w", id, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("revoke api key #%s: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repo) CreateWebhookEndpoint(item *WebhookEndpoint) error {
+	return r.conn.Create(item).Error
+}
+
+// UpdateWebhookEndpoint replaces the endpoint of the same subscriber
+func (r *Repo) UpdateWebhookEndpoint(item *WebhookEndpoint) error {
+	res := r.conn.
+		Model(&WebhookEndpoint{}).
+		Where(WebhookEndpoint{ID: item.ID, SubscriberID: item.SubscriberID}).
+		Select("url", "enabled", "format", "types", "actions", "dao_ids", "updated_at").
+		Updates(item)
+
+	if res.Error != nil {
+		return fmt.Errorf("update webhook endpoint #%s: %w", item.ID, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("update webhook endpoint #%s: %w", item.ID, gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteWebhookEndpoint(subscriberID, id uuid.UUID) error {
+	res := r.conn.
+		Where(WebhookEndpoint{ID: id, SubscriberID: subscriberID}).
+		Delete(&WebhookEndpoint{})
+
+	if res.Error != nil {
+		return fmt.Errorf("delete webhook endpoint #%s: %w", id, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("delete webhook endpoint #%s: %w", id, gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// GetWebhookEndpoints returns endpoints of the subscriber in the order of creation
+func (r *Repo) GetWebhookEndpoints(subscriberID uuid.UUID) ([]WebhookEndpoint, error) {
+	var list []WebhookEndpoint
+
+	err := r.conn.
+		Where(WebhookEndpoint{SubscriberID: subscriberID}).
+		Order("created_at, id").
+		Find(&list).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get webhook endpoints of subscriber #%s: %w", subscriberID, err)
+	}
+
+	return list, nil
+}
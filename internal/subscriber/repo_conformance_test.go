@@ -0,0 +1,146 @@
+package subscriber
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+type repository interface {
+	DataProvider
+	APIKeyProvider
+	WebhookEndpointProvider
+}
+
+func TestUnitMemoryRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(_ *testing.T) repository {
+		return NewMemoryRepo()
+	})
+}
+
+func TestIntegrationRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(t *testing.T) repository {
+		return NewRepo(testdb.Open(t, "subscribers", "api_keys", "webhook_endpoints"))
+	})
+}
+
+// testRepoConformance checks the behaviour every subscribers repository has to follow
+func testRepoConformance(t *testing.T, factory func(t *testing.T) repository) {
+	t.Run("create and get", func(t *testing.T) {
+		repo := factory(t)
+
+		_, err := repo.GetByID(uuid.New())
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		sub := &Subscriber{ID: uuid.New(), WebhookURL: "https://example.com/hook"}
+		require.NoError(t, repo.Create(sub))
+		require.False(t, sub.CreatedAt.IsZero())
+
+		stored, err := repo.GetByID(sub.ID)
+		require.NoError(t, err)
+		require.Equal(t, sub.ID, stored.ID)
+		require.Equal(t, "https://example.com/hook", stored.WebhookURL)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := factory(t)
+
+		sub := &Subscriber{ID: uuid.New(), WebhookURL: "https://example.com/hook"}
+		require.NoError(t, repo.Create(sub))
+
+		sub.WebhookURL = "https://example.com/updated"
+		sub.BatchMaxSize = 50
+		sub.BatchMaxWaitMs = 2000
+		require.NoError(t, repo.Update(sub))
+
+		stored, err := repo.GetByID(sub.ID)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/updated", stored.WebhookURL)
+		require.Equal(t, 50, stored.BatchMaxSize)
+		require.Equal(t, 2*time.Second, stored.BatchMaxWait())
+	})
+
+	t.Run("api keys", func(t *testing.T) {
+		repo := factory(t)
+
+		_, err := repo.GetAPIKeyByHash("hash")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		key := &APIKey{ID: uuid.New(), SubscriberID: uuid.New(), Hash: "hash", Scopes: "feed:read"}
+		require.NoError(t, repo.CreateAPIKey(key))
+
+		stored, err := repo.GetAPIKeyByHash("hash")
+		require.NoError(t, err)
+		require.Equal(t, key.ID, stored.ID)
+		require.Equal(t, key.SubscriberID, stored.SubscriberID)
+		require.Equal(t, "feed:read", stored.Scopes)
+
+		require.NoError(t, repo.RevokeAPIKey(key.ID, time.Now()))
+		require.ErrorIs(t, repo.RevokeAPIKey(key.ID, time.Now()), gorm.ErrRecordNotFound)
+
+		_, err = repo.GetAPIKeyByHash("hash")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("webhook endpoints", func(t *testing.T) {
+		repo := factory(t)
+		subscriberID := uuid.New()
+
+		list, err := repo.GetWebhookEndpoints(subscriberID)
+		require.NoError(t, err)
+		require.Empty(t, list)
+
+		first := &WebhookEndpoint{
+			ID:           uuid.New(),
+			SubscriberID: subscriberID,
+			URL:          "https://example.com/first",
+			Enabled:      true,
+			Format:       WebhookFormatFull,
+			Types:        []string{"proposal"},
+			DaoIDs:       []uuid.UUID{uuid.New()},
+		}
+		require.NoError(t, repo.CreateWebhookEndpoint(first))
+		require.NoError(t, repo.CreateWebhookEndpoint(&WebhookEndpoint{
+			ID:           uuid.New(),
+			SubscriberID: subscriberID,
+			URL:          "https://example.com/second",
+			Format:       WebhookFormatCompact,
+		}))
+		require.NoError(t, repo.CreateWebhookEndpoint(&WebhookEndpoint{ID: uuid.New(), SubscriberID: uuid.New(), URL: "https://example.com/other"}))
+
+		list, err = repo.GetWebhookEndpoints(subscriberID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		require.Equal(t, "https://example.com/first", list[0].URL)
+		require.Equal(t, first.Types, list[0].Types)
+		require.Equal(t, first.DaoIDs, list[0].DaoIDs)
+		require.True(t, list[0].Enabled)
+		require.Equal(t, WebhookFormatCompact, list[1].Format)
+
+		first.Enabled = false
+		first.Types = nil
+		require.NoError(t, repo.UpdateWebhookEndpoint(first))
+
+		other := *first
+		other.SubscriberID = uuid.New()
+		require.ErrorIs(t, repo.UpdateWebhookEndpoint(&other), gorm.ErrRecordNotFound)
+
+		list, err = repo.GetWebhookEndpoints(subscriberID)
+		require.NoError(t, err)
+		require.False(t, list[0].Enabled)
+		require.Empty(t, list[0].Types)
+
+		require.ErrorIs(t, repo.DeleteWebhookEndpoint(uuid.New(), first.ID), gorm.ErrRecordNotFound)
+		require.NoError(t, repo.DeleteWebhookEndpoint(subscriberID, first.ID))
+		require.ErrorIs(t, repo.DeleteWebhookEndpoint(subscriberID, first.ID), gorm.ErrRecordNotFound)
+
+		list, err = repo.GetWebhookEndpoints(subscriberID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+	})
+}
@@ -3,15 +3,17 @@ package subscriber
 import (
 	"context"
 	"errors"
-	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 
+	"github.com/goverland-labs/goverland-core-feed/internal/webhook"
 	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
 )
 
@@ -19,25 +21,49 @@ type SubscriberProvider interface {
 	GetByID(_ context.Context, id uuid.UUID) (*Subscriber, error)
 	Create(_ context.Context, webhookURL string) (*Subscriber, error)
 	Update(_ context.Context, item Subscriber) error
+	UpdateBatching(_ context.Context, id uuid.UUID, size int, wait time.Duration) error
+}
+
+type WebhookEndpointsProvider interface {
+	Create(_ context.Context, item WebhookEndpoint) (*WebhookEndpoint, error)
+	Update(_ context.Context, item WebhookEndpoint) error
+	Delete(_ context.Context, subscriberID, id uuid.UUID) error
+	List(_ context.Context, subscriberID uuid.UUID) ([]WebhookEndpoint, error)
+}
+
+type WebhookHealthProvider interface {
+	GetHealthByURLs(urls []string) ([]webhook.EndpointHealth, error)
+}
+
+// WebhookChecker validates the webhook url and verifies the ownership of the endpoint
+type WebhookChecker interface {
+	Check(_ context.Context, url string) error
 }
 
 type Server struct {
 	feedpb.UnimplementedSubscriberServer
 
-	sp SubscriberProvider
+	sp        SubscriberProvider
+	endpoints WebhookEndpointsProvider
+	webhooks  WebhookChecker
+	health    WebhookHealthProvider
+	// batching is supported only by the http delivery, callbacks published to NATS are never batched
+	batching bool
 }
 
-func NewServer(sp SubscriberProvider) *Server {
+func NewServer(sp SubscriberProvider, endpoints WebhookEndpointsProvider, webhooks WebhookChecker, health WebhookHealthProvider, batching bool) *Server {
 	return &Server{
-		sp: sp,
+		sp:        sp,
+		endpoints: endpoints,
+		webhooks:  webhooks,
+		health:    health,
+		batching:  batching,
 	}
 }
 
 func (s *Server) Create(ctx context.Context, req *feedpb.CreateSubscriberRequest) (*feedpb.CreateSubscriberResponse, error) {
-	if req.GetWebhookUrl() != "" {
-		if _, err := url.ParseRequestURI(req.GetWebhookUrl()); err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid webhook url")
-		}
+	if err := s.checkWebhook(ctx, req.GetWebhookUrl()); err != nil {
+		return nil, err
 	}
 
 	sub, err := s.sp.Create(ctx, req.GetWebhookUrl())
@@ -54,10 +80,8 @@ func (s *Server) Create(ctx context.Context, req *feedpb.CreateSubscriberRequest
 
 func (s *Server) Update(ctx context.Context, req *feedpb.UpdateSubscriberRequest) (*emptypb.Empty, error) {
 	subID := GetSubscriberID(ctx)
-	if req.GetWebhookUrl() != "" {
-		if _, err := url.ParseRequestURI(req.GetWebhookUrl()); err != nil {
-			return nil, status.Error(codes.InvalidArgument, "invalid webhook url")
-		}
+	if err := s.checkWebhook(ctx, req.GetWebhookUrl()); err != nil {
+		return nil, err
 	}
 
 	err := s.sp.Update(ctx, Subscriber{
@@ -77,3 +101,301 @@ func (s *Server) Update(ctx context.Context, req *feedpb.UpdateSubscriberRequest
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *Server) UpdateBatching(ctx context.Context, req *feedpb.UpdateBatchingRequest) (*emptypb.Empty, error) {
+	subID := GetSubscriberID(ctx)
+	if !s.batching && req.GetMaxSize() > 1 {
+		return nil, status.Error(codes.FailedPrecondition, "batching is supported only by the http delivery")
+	}
+
+	err := s.sp.UpdateBatching(ctx, subID, int(req.GetMaxSize()), time.Duration(req.GetMaxWaitMs())*time.Millisecond)
+	if errors.Is(err, ErrInvalidBatching) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "invalid subscriber ID")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("update batching of subscriber: %s", subID)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log.Debug().Msgf("update batching of subscriber: %s", subID)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) CreateWebhookEndpoint(ctx context.Context, req *feedpb.CreateWebhookEndpointRequest) (*feedpb.WebhookEndpoint, error) {
+	subID := GetSubscriberID(ctx)
+	if req.GetUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "webhook url is required")
+	}
+
+	if err := s.checkWebhook(ctx, req.GetUrl()); err != nil {
+		return nil, err
+	}
+
+	daoIDs, err := parseDaoIDs(req.GetDaoIds())
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.endpoints.Create(ctx, WebhookEndpoint{
+		SubscriberID: subID,
+		URL:          req.GetUrl(),
+		Enabled:      req.GetEnabled(),
+		Format:       WebhookFormat(req.GetFormat()),
+		Types:        req.GetTypes(),
+		Actions:      req.GetActions(),
+		DaoIDs:       daoIDs,
+	})
+	if err != nil {
+		return nil, endpointError(err, "create webhook endpoint", subID)
+	}
+
+	log.Debug().Msgf("create webhook endpoint %s of subscriber %s", endpoint.ID, subID)
+
+	return convertEndpointToAPI(endpoint), nil
+}
+
+func (s *Server) UpdateWebhookEndpoint(ctx context.Context, req *feedpb.WebhookEndpoint) (*emptypb.Empty, error) {
+	subID := GetSubscriberID(ctx)
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook endpoint ID")
+	}
+
+	if req.GetUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "webhook url is required")
+	}
+
+	if err := s.checkWebhook(ctx, req.GetUrl()); err != nil {
+		return nil, err
+	}
+
+	daoIDs, err := parseDaoIDs(req.GetDaoIds())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.endpoints.Update(ctx, WebhookEndpoint{
+		ID:           id,
+		SubscriberID: subID,
+		URL:          req.GetUrl(),
+		Enabled:      req.GetEnabled(),
+		Format:       WebhookFormat(req.GetFormat()),
+		Types:        req.GetTypes(),
+		Actions:      req.GetActions(),
+		DaoIDs:       daoIDs,
+	})
+	if err != nil {
+		return nil, endpointError(err, "update webhook endpoint", subID)
+	}
+
+	log.Debug().Msgf("update webhook endpoint %s of subscriber %s", id, subID)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteWebhookEndpoint(ctx context.Context, req *feedpb.DeleteWebhookEndpointRequest) (*emptypb.Empty, error) {
+	subID := GetSubscriberID(ctx)
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid webhook endpoint ID")
+	}
+
+	if err := s.endpoints.Delete(ctx, subID, id); err != nil {
+		return nil, endpointError(err, "delete webhook endpoint", subID)
+	}
+
+	log.Debug().Msgf("delete webhook endpoint %s of subscriber %s", id, subID)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListWebhookEndpoints(ctx context.Context, _ *feedpb.ListWebhookEndpointsRequest) (*feedpb.ListWebhookEndpointsResponse, error) {
+	subID := GetSubscriberID(ctx)
+
+	list, err := s.endpoints.List(ctx, subID)
+	if err != nil {
+		return nil, endpointError(err, "list webhook endpoints", subID)
+	}
+
+	res := &feedpb.ListWebhookEndpointsResponse{
+		Endpoints: make([]*feedpb.WebhookEndpoint, 0, len(list)),
+	}
+	for i := range list {
+		res.Endpoints = append(res.Endpoints, convertEndpointToAPI(&list[i]))
+	}
+
+	return res, nil
+}
+
+func (s *Server) GetWebhookHealth(ctx context.Context, _ *feedpb.GetWebhookHealthRequest) (*feedpb.GetWebhookHealthResponse, error) {
+	subID := GetSubscriberID(ctx)
+
+	urls, err := s.webhookURLs(ctx, subID)
+	if err != nil {
+		log.Error().Err(err).Msgf("get webhooks of subscriber: %s", subID)
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	list, err := s.health.GetHealthByURLs(urls)
+	if err != nil {
+		log.Error().Err(err).Msgf("get webhooks health of subscriber: %s", subID)
+
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	byURL := make(map[string]webhook.EndpointHealth, len(list))
+	for _, h := range list {
+		byURL[h.URL] = h
+	}
+
+	now := time.Now()
+	res := &feedpb.GetWebhookHealthResponse{
+		Webhooks: make([]*feedpb.WebhookHealth, 0, len(urls)),
+	}
+	for _, url := range urls {
+		h, ok := byURL[url]
+		if !ok {
+			// nothing is delivered to the webhook yet
+			h = webhook.EndpointHealth{URL: url}
+		}
+
+		res.Webhooks = append(res.Webhooks, convertHealthToAPI(&h, now))
+	}
+
+	return res, nil
+}
+
+// webhookURLs returns unique urls of the subscriber webhook and webhook endpoints
+func (s *Server) webhookURLs(ctx context.Context, subID uuid.UUID) ([]string, error) {
+	sub, err := s.sp.GetByID(ctx, subID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.endpoints.List(ctx, subID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(endpoints)+1)
+	urls := make([]string, 0, len(endpoints)+1)
+	for _, url := range append([]string{sub.WebhookURL}, endpointURLs(endpoints)...) {
+		if _, ok := seen[url]; ok || url == "" {
+			continue
+		}
+
+		seen[url] = struct{}{}
+		urls = append(urls, url)
+	}
+
+	return urls, nil
+}
+
+func endpointURLs(endpoints []WebhookEndpoint) []string {
+	urls := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		urls = append(urls, e.URL)
+	}
+
+	return urls
+}
+
+func convertHealthToAPI(h *webhook.EndpointHealth, now time.Time) *feedpb.WebhookHealth {
+	return &feedpb.WebhookHealth{
+		Url:                 h.URL,
+		Disabled:            h.Disabled(now),
+		Successes:           h.Successes,
+		Failures:            h.Failures,
+		SuccessRate:         h.SuccessRate(),
+		ConsecutiveFailures: int32(h.ConsecutiveFailures),
+		LastStatus:          int32(h.LastStatus),
+		LastError:           h.LastError,
+		LastSuccessAt:       convertTimeToAPI(h.LastSuccessAt),
+		LastFailureAt:       convertTimeToAPI(h.LastFailureAt),
+		DisabledUntil:       convertTimeToAPI(h.DisabledUntil),
+	}
+}
+
+func convertTimeToAPI(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func endpointError(err error, action string, subID uuid.UUID) error {
+	switch {
+	case errors.Is(err, ErrInvalidWebhookEndpoint):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "webhook endpoint is not found")
+	default:
+		log.Error().Err(err).Msgf("%s: %s", action, subID)
+
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func parseDaoIDs(ids []string) ([]uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	res := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		daoID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid DAO ID: %s", id)
+		}
+
+		res = append(res, daoID)
+	}
+
+	return res, nil
+}
+
+func convertEndpointToAPI(e *WebhookEndpoint) *feedpb.WebhookEndpoint {
+	daoIDs := make([]string, 0, len(e.DaoIDs))
+	for _, id := range e.DaoIDs {
+		daoIDs = append(daoIDs, id.String())
+	}
+
+	return &feedpb.WebhookEndpoint{
+		Id:      e.ID.String(),
+		Url:     e.URL,
+		Enabled: e.Enabled,
+		Format:  string(e.Format),
+		Types:   e.Types,
+		Actions: e.Actions,
+		DaoIds:  daoIDs,
+	}
+}
+
+func (s *Server) checkWebhook(ctx context.Context, url string) error {
+	if url == "" {
+		return nil
+	}
+
+	err := s.webhooks.Check(ctx, url)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, webhook.ErrInvalidURL):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, webhook.ErrNotVerified):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, webhook.ErrUnresolved):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		log.Error().Err(err).Msgf("check webhook: %s", url)
+
+		return status.Error(codes.Internal, "internal error")
+	}
+}
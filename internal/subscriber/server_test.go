@@ -0,0 +1,38 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+func TestUnitServerUpdateBatching(t *testing.T) {
+	service, err := NewService(NewMemoryRepo(), NewCache(), &recordingInvalidator{})
+	require.NoError(t, err)
+
+	sub, err := service.Create(context.Background(), "https://example.com/hook")
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), IDKey, sub.ID)
+
+	// callbacks published to NATS are never batched
+	s := NewServer(service, nil, nil, nil, false)
+	_, err = s.UpdateBatching(ctx, &feedpb.UpdateBatchingRequest{MaxSize: 10, MaxWaitMs: 1000})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = s.UpdateBatching(ctx, &feedpb.UpdateBatchingRequest{MaxSize: 0})
+	require.NoError(t, err)
+
+	s = NewServer(service, nil, nil, nil, true)
+	_, err = s.UpdateBatching(ctx, &feedpb.UpdateBatchingRequest{MaxSize: 10, MaxWaitMs: 1000})
+	require.NoError(t, err)
+
+	stored, err := service.GetByID(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, 10, stored.BatchMaxSize)
+}
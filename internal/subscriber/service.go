@@ -4,17 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
 )
 
 //go:generate mockgen -destination=mocks_test.go -package=subscriber . DataProvider
 
 const (
 	IDKey ContextKey = "subscriber_id_key"
+
+	MaxBatchSize = 1000
+	MaxBatchWait = time.Minute
 )
 
+var ErrInvalidBatching = errors.New("invalid batching")
+
 type ContextKey string
 
 type DataProvider interface {
@@ -25,18 +33,27 @@ type DataProvider interface {
 
 type Cacher interface {
 	UpsertItem(key uuid.UUID, value *Subscriber)
+	UpsertItemIfVersion(version cache.Version, key uuid.UUID, value *Subscriber) bool
 	GetItem(key uuid.UUID) (*Subscriber, bool)
+	Version() cache.Version
+}
+
+// Invalidator notifies other replicas about changed cache keys
+type Invalidator interface {
+	Publish(cache, key string)
 }
 
 type Service struct {
-	repo  DataProvider
-	cache Cacher
+	repo        DataProvider
+	cache       Cacher
+	invalidator Invalidator
 }
 
-func NewService(r DataProvider, c Cacher) (*Service, error) {
+func NewService(r DataProvider, c Cacher, i Invalidator) (*Service, error) {
 	return &Service{
-		repo:  r,
-		cache: c,
+		repo:        r,
+		cache:       c,
+		invalidator: i,
 	}, nil
 }
 
@@ -55,7 +72,7 @@ func (s *Service) Create(ctx context.Context, webhookURL string) (*Subscriber, e
 		return nil, fmt.Errorf("create subscriber: %w", err)
 	}
 
-	go s.cache.UpsertItem(item.ID, item)
+	s.cache.UpsertItem(item.ID, item)
 
 	return item, err
 }
@@ -75,18 +92,47 @@ func (s *Service) generateSubscriberID(ctx context.Context) (uuid.UUID, error) {
 }
 
 func (s *Service) Update(ctx context.Context, item Subscriber) error {
-	sub, err := s.GetByID(ctx, item.ID)
+	return s.update(ctx, item.ID, func(sub *Subscriber) {
+		sub.WebhookURL = item.WebhookURL
+	})
+}
+
+// UpdateBatching sets batching options of callbacks, the size up to one disables batching
+func (s *Service) UpdateBatching(ctx context.Context, id uuid.UUID, size int, wait time.Duration) error {
+	if size <= 1 {
+		size, wait = 0, 0
+	}
+
+	if size > MaxBatchSize {
+		return fmt.Errorf("%w: max size is greater than %d", ErrInvalidBatching, MaxBatchSize)
+	}
+
+	if size > 0 && (wait < time.Millisecond || wait > MaxBatchWait) {
+		return fmt.Errorf("%w: max wait must be from 1ms to %s", ErrInvalidBatching, MaxBatchWait)
+	}
+
+	return s.update(ctx, id, func(sub *Subscriber) {
+		sub.BatchMaxSize = size
+		sub.BatchMaxWaitMs = int(wait.Milliseconds())
+	})
+}
+
+func (s *Service) update(ctx context.Context, id uuid.UUID, modify func(sub *Subscriber)) error {
+	sub, err := s.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("get subscriber: %w", err)
 	}
 
-	sub.WebhookURL = item.WebhookURL
-	err = s.repo.Update(sub)
+	// the cached subscriber could be read concurrently, so it's not modified in place
+	updated := *sub
+	modify(&updated)
+	err = s.repo.Update(&updated)
 	if err != nil {
 		return fmt.Errorf("update subscriber: %w", err)
 	}
 
-	go s.cache.UpsertItem(item.ID, sub)
+	s.cache.UpsertItem(id, &updated)
+	s.invalidator.Publish(CacheName, id.String())
 
 	return nil
 }
@@ -96,12 +142,13 @@ func (s *Service) GetByID(_ context.Context, id uuid.UUID) (*Subscriber, error)
 		return el, nil
 	}
 
+	version := s.cache.Version()
 	sub, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("get by id: %w", err)
 	}
 
-	go s.cache.UpsertItem(sub.ID, sub)
+	s.cache.UpsertItemIfVersion(version, sub.ID, sub)
 
 	return sub, nil
 }
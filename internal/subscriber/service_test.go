@@ -0,0 +1,46 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitUpdateBatching(t *testing.T) {
+	ctx := context.Background()
+	invalidator := &recordingInvalidator{}
+
+	s, err := NewService(NewMemoryRepo(), NewCache(), invalidator)
+	require.NoError(t, err)
+
+	sub, err := s.Create(ctx, "https://example.com/hook")
+	require.NoError(t, err)
+
+	require.NoError(t, s.UpdateBatching(ctx, sub.ID, 100, 5*time.Second))
+
+	stored, err := s.GetByID(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, 100, stored.BatchMaxSize)
+	require.Equal(t, 5*time.Second, stored.BatchMaxWait())
+	require.Equal(t, "https://example.com/hook", stored.WebhookURL)
+	require.Equal(t, []string{CacheName + ":" + sub.ID.String()}, []string(*invalidator))
+
+	// the webhook update keeps batching options
+	require.NoError(t, s.Update(ctx, Subscriber{ID: sub.ID, WebhookURL: "https://example.com/updated"}))
+	stored, err = s.GetByID(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Equal(t, 100, stored.BatchMaxSize)
+
+	require.ErrorIs(t, s.UpdateBatching(ctx, sub.ID, MaxBatchSize+1, time.Second), ErrInvalidBatching)
+	require.ErrorIs(t, s.UpdateBatching(ctx, sub.ID, 10, 0), ErrInvalidBatching)
+	require.ErrorIs(t, s.UpdateBatching(ctx, sub.ID, 10, 2*MaxBatchWait), ErrInvalidBatching)
+
+	// the size up to one disables batching
+	require.NoError(t, s.UpdateBatching(ctx, sub.ID, 1, time.Hour))
+	stored, err = s.GetByID(ctx, sub.ID)
+	require.NoError(t, err)
+	require.Zero(t, stored.BatchMaxSize)
+	require.Zero(t, stored.BatchMaxWaitMs)
+}
@@ -0,0 +1,141 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+)
+
+// EndpointsCacheName is the name of the webhook endpoints cache in metrics and invalidations
+const EndpointsCacheName = "webhook_endpoints"
+
+var ErrInvalidWebhookEndpoint = errors.New("invalid webhook endpoint")
+
+type WebhookEndpointProvider interface {
+	CreateWebhookEndpoint(*WebhookEndpoint) error
+	UpdateWebhookEndpoint(*WebhookEndpoint) error
+	DeleteWebhookEndpoint(subscriberID, id uuid.UUID) error
+	GetWebhookEndpoints(subscriberID uuid.UUID) ([]WebhookEndpoint, error)
+}
+
+// EndpointsCache keeps webhook endpoints by the subscriber
+type EndpointsCache struct {
+	data *cache.Cache[uuid.UUID, []WebhookEndpoint]
+}
+
+func NewEndpointsCache(opts cache.Options) *EndpointsCache {
+	return &EndpointsCache{
+		data: cache.New[uuid.UUID, []WebhookEndpoint](EndpointsCacheName, opts),
+	}
+}
+
+// InvalidateKey removes endpoints of the subscriber changed by another replica
+func (c *EndpointsCache) InvalidateKey(key string) {
+	id, err := uuid.Parse(key)
+	if err != nil {
+		return
+	}
+
+	c.data.Delete(id)
+}
+
+func (c *EndpointsCache) Purge() {
+	c.data.Purge()
+}
+
+// WebhookEndpoints manages webhook endpoints of subscribers.
+// Cached lists are shared, so they must not be modified by callers.
+type WebhookEndpoints struct {
+	repo        WebhookEndpointProvider
+	cache       *EndpointsCache
+	invalidator Invalidator
+}
+
+func NewWebhookEndpoints(repo WebhookEndpointProvider, c *EndpointsCache, i Invalidator) *WebhookEndpoints {
+	return &WebhookEndpoints{
+		repo:        repo,
+		cache:       c,
+		invalidator: i,
+	}
+}
+
+func (w *WebhookEndpoints) Create(_ context.Context, item WebhookEndpoint) (*WebhookEndpoint, error) {
+	item.ID = uuid.New()
+	if err := normalizeEndpoint(&item); err != nil {
+		return nil, err
+	}
+
+	if err := w.repo.CreateWebhookEndpoint(&item); err != nil {
+		return nil, fmt.Errorf("create webhook endpoint: %w", err)
+	}
+
+	w.invalidate(item.SubscriberID)
+
+	return &item, nil
+}
+
+// Update replaces the url, the state, the format and routing rules of the endpoint
+func (w *WebhookEndpoints) Update(_ context.Context, item WebhookEndpoint) error {
+	if err := normalizeEndpoint(&item); err != nil {
+		return err
+	}
+
+	if err := w.repo.UpdateWebhookEndpoint(&item); err != nil {
+		return fmt.Errorf("update webhook endpoint: %w", err)
+	}
+
+	w.invalidate(item.SubscriberID)
+
+	return nil
+}
+
+func (w *WebhookEndpoints) Delete(_ context.Context, subscriberID, id uuid.UUID) error {
+	if err := w.repo.DeleteWebhookEndpoint(subscriberID, id); err != nil {
+		return fmt.Errorf("delete webhook endpoint: %w", err)
+	}
+
+	w.invalidate(subscriberID)
+
+	return nil
+}
+
+func (w *WebhookEndpoints) List(_ context.Context, subscriberID uuid.UUID) ([]WebhookEndpoint, error) {
+	if list, ok := w.cache.data.Get(subscriberID); ok {
+		return list, nil
+	}
+
+	version := w.cache.data.Version()
+	list, err := w.repo.GetWebhookEndpoints(subscriberID)
+	if err != nil {
+		return nil, fmt.Errorf("get webhook endpoints: %w", err)
+	}
+
+	w.cache.data.SetIfVersion(version, subscriberID, list)
+
+	return list, nil
+}
+
+func (w *WebhookEndpoints) invalidate(subscriberID uuid.UUID) {
+	w.cache.data.Delete(subscriberID)
+	w.invalidator.Publish(EndpointsCacheName, subscriberID.String())
+}
+
+func normalizeEndpoint(item *WebhookEndpoint) error {
+	switch item.Format {
+	case "":
+		item.Format = WebhookFormatFull
+	case WebhookFormatFull, WebhookFormatCompact:
+	default:
+		return fmt.Errorf("%w: unknown format %s", ErrInvalidWebhookEndpoint, item.Format)
+	}
+
+	if item.URL == "" {
+		return fmt.Errorf("%w: url is required", ErrInvalidWebhookEndpoint)
+	}
+
+	return nil
+}
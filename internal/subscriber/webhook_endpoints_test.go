@@ -0,0 +1,88 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+)
+
+type recordingInvalidator []string
+
+func (r *recordingInvalidator) Publish(cache, key string) {
+	*r = append(*r, cache+":"+key)
+}
+
+func TestUnitWebhookEndpointMatches(t *testing.T) {
+	daoID := uuid.New()
+
+	for _, tc := range []struct {
+		name     string
+		endpoint WebhookEndpoint
+		matches  bool
+	}{
+		{name: "empty rules", endpoint: WebhookEndpoint{Enabled: true}, matches: true},
+		{name: "disabled", endpoint: WebhookEndpoint{}},
+		{name: "type", endpoint: WebhookEndpoint{Enabled: true, Types: []string{"delegate", "proposal"}}, matches: true},
+		{name: "other type", endpoint: WebhookEndpoint{Enabled: true, Types: []string{"delegate"}}},
+		{name: "action", endpoint: WebhookEndpoint{Enabled: true, Actions: []string{"proposal.created"}}, matches: true},
+		{name: "other action", endpoint: WebhookEndpoint{Enabled: true, Actions: []string{"proposal.updated"}}},
+		{name: "dao", endpoint: WebhookEndpoint{Enabled: true, DaoIDs: []uuid.UUID{daoID}}, matches: true},
+		{name: "other dao", endpoint: WebhookEndpoint{Enabled: true, DaoIDs: []uuid.UUID{uuid.New()}}},
+		{
+			name:     "all rules",
+			endpoint: WebhookEndpoint{Enabled: true, Types: []string{"proposal"}, Actions: []string{"proposal.created"}, DaoIDs: []uuid.UUID{daoID}},
+			matches:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.matches, tc.endpoint.Matches("proposal", "proposal.created", daoID))
+		})
+	}
+}
+
+func TestUnitWebhookEndpoints(t *testing.T) {
+	ctx := context.Background()
+	invalidator := &recordingInvalidator{}
+	endpoints := NewWebhookEndpoints(NewMemoryRepo(), NewEndpointsCache(cache.Options{}), invalidator)
+	subscriberID := uuid.New()
+
+	_, err := endpoints.Create(ctx, WebhookEndpoint{SubscriberID: subscriberID, URL: "https://example.com", Format: "xml"})
+	require.ErrorIs(t, err, ErrInvalidWebhookEndpoint)
+
+	list, err := endpoints.List(ctx, subscriberID)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	// the cached empty list is replaced after the creation
+	created, err := endpoints.Create(ctx, WebhookEndpoint{SubscriberID: subscriberID, URL: "https://example.com", Enabled: true})
+	require.NoError(t, err)
+	require.Equal(t, WebhookFormatFull, created.Format)
+
+	list, err = endpoints.List(ctx, subscriberID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.True(t, list[0].Enabled)
+
+	created.Enabled = false
+	require.NoError(t, endpoints.Update(ctx, *created))
+
+	list, err = endpoints.List(ctx, subscriberID)
+	require.NoError(t, err)
+	require.False(t, list[0].Enabled)
+
+	// endpoints of other subscribers are not changed
+	require.ErrorIs(t, endpoints.Delete(ctx, uuid.New(), created.ID), gorm.ErrRecordNotFound)
+	require.NoError(t, endpoints.Delete(ctx, subscriberID, created.ID))
+
+	list, err = endpoints.List(ctx, subscriberID)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	key := EndpointsCacheName + ":" + subscriberID.String()
+	require.Equal(t, []string{key, key, key}, []string(*invalidator))
+}
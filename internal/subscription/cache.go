@@ -1,85 +1,117 @@
 package subscription
 
 import (
-	"sync"
-
 	"github.com/google/uuid"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
 )
 
-type Cache struct {
-	mu sync.RWMutex
+// CacheName is the name of the cache in metrics and invalidations
+const CacheName = "subscriptions"
+
+type subscribersSet map[uuid.UUID]struct{}
 
-	data map[string]map[uuid.UUID]struct{}
+// Cache keeps subscribers of DAOs and addresses, cached sets are never modified in place,
+// so they could be read without holding the lock
+type Cache struct {
+	data *cache.Cache[string, subscribersSet]
 }
 
+// NewCache creates the cache without size and time limits
 func NewCache() *Cache {
+	return NewCacheWithOptions(cache.Options{})
+}
+
+func NewCacheWithOptions(opts cache.Options) *Cache {
 	return &Cache{
-		data: make(map[string]map[uuid.UUID]struct{}),
+		data: cache.New[string, subscribersSet](CacheName, opts),
 	}
 }
 
+// AddItems adds values to the key, the key is created if it's not cached
 func (c *Cache) AddItems(key string, values ...uuid.UUID) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	data, ok := c.data[key]
-	if !ok {
-		data = make(map[uuid.UUID]struct{})
+	if c.AddToCached(key, values...) {
+		return
 	}
 
-	for _, val := range values {
-		data[val] = struct{}{}
-	}
+	c.UpdateItems(key, values...)
+}
 
-	c.data[key] = data
+// AddToCached adds values only if the key is cached and returns false otherwise.
+// The missing key is loaded with all values from the storage on the next read.
+func (c *Cache) AddToCached(key string, values ...uuid.UUID) bool {
+	return c.data.Update(key, func(data subscribersSet) subscribersSet {
+		updated := make(subscribersSet, len(data)+len(values))
+		for val := range data {
+			updated[val] = struct{}{}
+		}
+		for _, val := range values {
+			updated[val] = struct{}{}
+		}
+
+		return updated
+	})
 }
 
+// UpdateItems replaces values of the key
 func (c *Cache) UpdateItems(key string, values ...uuid.UUID) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.data.Set(key, newSubscribersSet(values))
+}
+
+// UpdateItemsIfVersion replaces values of the key by loaded ones if the cache is not changed since the version was taken
+func (c *Cache) UpdateItemsIfVersion(version cache.Version, key string, values ...uuid.UUID) bool {
+	return c.data.SetIfVersion(version, key, newSubscribersSet(values))
+}
 
-	data := make(map[uuid.UUID]struct{})
+func (c *Cache) Version() cache.Version {
+	return c.data.Version()
+}
+
+func newSubscribersSet(values []uuid.UUID) subscribersSet {
+	data := make(subscribersSet, len(values))
 	for _, val := range values {
 		data[val] = struct{}{}
 	}
 
-	c.data[key] = data
+	return data
 }
 
 func (c *Cache) GetItems(key string) ([]uuid.UUID, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	data, ok := c.data[key]
+	data, ok := c.data.Get(key)
 	if !ok {
 		return []uuid.UUID{}, false
 	}
 
-	res := make([]uuid.UUID, len(data))
-	idx := 0
+	res := make([]uuid.UUID, 0, len(data))
 	for val := range data {
-		res[idx] = val
-		idx++
+		res = append(res, val)
 	}
 
 	return res, true
 }
 
 func (c *Cache) RemoveItem(key string, value uuid.UUID) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	data, ok := c.data[key]
-	if !ok {
-		return
-	}
-
-	delete(data, value)
+	c.data.Update(key, func(data subscribersSet) subscribersSet {
+		updated := make(subscribersSet, len(data))
+		for val := range data {
+			if val != value {
+				updated[val] = struct{}{}
+			}
+		}
+
+		return updated
+	})
 }
 
 func (c *Cache) RemoveKey(key string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.data.Delete(key)
+}
+
+// InvalidateKey removes subscribers of the key changed by another replica
+func (c *Cache) InvalidateKey(key string) {
+	c.RemoveKey(key)
+}
 
-	delete(c.data, key)
+func (c *Cache) Purge() {
+	c.data.Purge()
 }
@@ -67,3 +67,21 @@ func TestUnitRemoveItem(t *testing.T) {
 	require.Len(t, items, 1)
 	require.Equal(t, items, []uuid.UUID{id1})
 }
+
+func TestUnitAddToCached(t *testing.T) {
+	c := NewCache()
+
+	id1 := uuid.New()
+	id2 := uuid.New()
+
+	require.False(t, c.AddToCached("key-1", id1))
+	_, ok := c.GetItems("key-1")
+	require.False(t, ok)
+
+	c.UpdateItems("key-1", id1)
+	require.True(t, c.AddToCached("key-1", id2))
+
+	items, ok := c.GetItems("key-1")
+	require.True(t, ok)
+	require.ElementsMatch(t, []uuid.UUID{id1, id2}, items)
+}
@@ -0,0 +1,165 @@
+package subscription
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MemoryRepo keeps subscriptions in memory, it's used for running the application and tests without the database
+type MemoryRepo struct {
+	mu        sync.RWMutex
+	daos      []Subscription
+	addresses []AddressSubscription
+}
+
+func NewMemoryRepo() *MemoryRepo {
+	return &MemoryRepo{}
+}
+
+func (r *MemoryRepo) Create(item Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item.ID = uuid.New()
+	item.CreatedAt, item.UpdatedAt = time.Now(), time.Now()
+	r.daos = append(r.daos, item)
+
+	return nil
+}
+
+func (r *MemoryRepo) Delete(item Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.daos {
+		if r.daos[i].ID == item.ID {
+			r.daos[i].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) GetByID(subscriberID, daoID uuid.UUID) (Subscription, error) {
+	list := r.findDaos(func(sub *Subscription) bool {
+		return sub.SubscriberID == subscriberID && sub.DaoID == daoID
+	})
+	if len(list) == 0 {
+		return Subscription{}, gorm.ErrRecordNotFound
+	}
+
+	return list[0], nil
+}
+
+func (r *MemoryRepo) GetBySubscriber(subscriberID uuid.UUID) ([]Subscription, error) {
+	return r.findDaos(func(sub *Subscription) bool { return sub.SubscriberID == subscriberID }), nil
+}
+
+func (r *MemoryRepo) GetSubscribers(daoID uuid.UUID) ([]Subscription, error) {
+	return r.findDaos(func(sub *Subscription) bool { return sub.DaoID == daoID }), nil
+}
+
+func (r *MemoryRepo) findDaos(match func(sub *Subscription) bool) []Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []Subscription
+	for i := range r.daos {
+		if !r.daos[i].DeletedAt.Valid && match(&r.daos[i]) {
+			list = append(list, r.daos[i])
+		}
+	}
+
+	return list
+}
+
+func (r *MemoryRepo) CreateAddress(item AddressSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.addresses {
+		stored := &r.addresses[i]
+		if !stored.DeletedAt.Valid && stored.SubscriberID == item.SubscriberID && stored.Address == item.Address {
+			return nil
+		}
+	}
+
+	item.ID = uuid.New()
+	item.CreatedAt, item.UpdatedAt = time.Now(), time.Now()
+	r.addresses = append(r.addresses, item)
+
+	return nil
+}
+
+func (r *MemoryRepo) DeleteAddress(item AddressSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.addresses {
+		if r.addresses[i].ID == item.ID {
+			r.addresses[i].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) GetAddressByID(subscriberID uuid.UUID, address string) (AddressSubscription, error) {
+	list := r.findAddresses(func(sub *AddressSubscription) bool {
+		return sub.SubscriberID == subscriberID && sub.Address == address
+	})
+	if len(list) == 0 {
+		return AddressSubscription{}, gorm.ErrRecordNotFound
+	}
+
+	return list[0], nil
+}
+
+func (r *MemoryRepo) GetAddressFollowers(address string) ([]AddressSubscription, error) {
+	return r.findAddresses(func(sub *AddressSubscription) bool { return sub.Address == address }), nil
+}
+
+func (r *MemoryRepo) GetAddressesBySubscriber(subscriberID uuid.UUID) ([]AddressSubscription, error) {
+	return r.findAddresses(func(sub *AddressSubscription) bool { return sub.SubscriberID == subscriberID }), nil
+}
+
+func (r *MemoryRepo) findAddresses(match func(sub *AddressSubscription) bool) []AddressSubscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []AddressSubscription
+	for i := range r.addresses {
+		if !r.addresses[i].DeletedAt.Valid && match(&r.addresses[i]) {
+			list = append(list, r.addresses[i])
+		}
+	}
+
+	return list
+}
+
+func (r *MemoryRepo) GetChunk(after uuid.UUID, limit int) ([]Subscription, error) {
+	list := r.findDaos(func(sub *Subscription) bool { return bytes.Compare(sub.ID[:], after[:]) > 0 })
+	sort.Slice(list, func(i, j int) bool { return bytes.Compare(list[i].ID[:], list[j].ID[:]) < 0 })
+
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list, nil
+}
+
+func (r *MemoryRepo) GetAddressChunk(after uuid.UUID, limit int) ([]AddressSubscription, error) {
+	list := r.findAddresses(func(sub *AddressSubscription) bool { return bytes.Compare(sub.ID[:], after[:]) > 0 })
+	sort.Slice(list, func(i, j int) bool { return bytes.Compare(list[i].ID[:], list[j].ID[:]) < 0 })
+
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	return list, nil
+}
@@ -0,0 +1,17 @@
+package subscription
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+var metricQuotaExceededCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "subscription",
+		Name:      "quota_exceeded_total",
+		Help:      "Count of subscriptions rejected by the subscriptions quota",
+	},
+)
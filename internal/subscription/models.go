@@ -15,3 +15,12 @@ type Subscription struct {
 	SubscriberID uuid.UUID
 	DaoID        uuid.UUID
 }
+
+type AddressSubscription struct {
+	ID           uuid.UUID `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	SubscriberID uuid.UUID
+	Address      string
+}
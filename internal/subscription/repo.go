@@ -3,6 +3,7 @@ package subscription
 import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repo struct {
@@ -37,7 +38,17 @@ func (r *Repo) GetByID(subscriberID, daoID uuid.UUID) (Subscription, error) {
 	return res, err
 }
 
-// todo: think about getting this elements by chunks
+func (r *Repo) GetBySubscriber(subscriberID uuid.UUID) ([]Subscription, error) {
+	var res []Subscription
+	err := r.db.
+		Where(&Subscription{
+			SubscriberID: subscriberID,
+		}).
+		Find(&res).
+		Error
+
+	return res, err
+}
 
 func (r *Repo) GetSubscribers(daoID uuid.UUID) ([]Subscription, error) {
 	var res []Subscription
@@ -50,3 +61,92 @@ func (r *Repo) GetSubscribers(daoID uuid.UUID) ([]Subscription, error) {
 
 	return res, err
 }
+
+// CreateAddress does nothing if the subscriber already follows the address
+func (r *Repo) CreateAddress(item AddressSubscription) error {
+	item.ID = uuid.New()
+
+	return r.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "subscriber_id"},
+				{Name: "address"},
+			},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "deleted_at is null"},
+			}},
+			DoNothing: true,
+		}).
+		Create(&item).
+		Error
+}
+
+func (r *Repo) DeleteAddress(item AddressSubscription) error {
+	return r.db.Delete(&item).Error
+}
+
+func (r *Repo) GetAddressByID(subscriberID uuid.UUID, address string) (AddressSubscription, error) {
+	var res AddressSubscription
+
+	err := r.db.
+		Where(&AddressSubscription{
+			SubscriberID: subscriberID,
+			Address:      address,
+		}).
+		First(&res).
+		Error
+
+	return res, err
+}
+
+func (r *Repo) GetAddressFollowers(address string) ([]AddressSubscription, error) {
+	var res []AddressSubscription
+	err := r.db.
+		Where(&AddressSubscription{
+			Address: address,
+		}).
+		Find(&res).
+		Error
+
+	return res, err
+}
+
+func (r *Repo) GetAddressesBySubscriber(subscriberID uuid.UUID) ([]AddressSubscription, error) {
+	var res []AddressSubscription
+	err := r.db.
+		Where(&AddressSubscription{
+			SubscriberID: subscriberID,
+		}).
+		Find(&res).
+		Error
+
+	return res, err
+}
+
+// GetChunk returns the chunk of subscriptions ordered by identifier starting after the passed one
+func (r *Repo) GetChunk(after uuid.UUID, limit int) ([]Subscription, error) {
+	var res []Subscription
+
+	err := r.db.
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+
+	return res, err
+}
+
+// GetAddressChunk returns the chunk of address subscriptions ordered by identifier starting after the passed one
+func (r *Repo) GetAddressChunk(after uuid.UUID, limit int) ([]AddressSubscription, error) {
+	var res []AddressSubscription
+
+	err := r.db.
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+
+	return res, err
+}
@@ -0,0 +1,124 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+func TestUnitMemoryRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(_ *testing.T) DataProvider {
+		return NewMemoryRepo()
+	})
+}
+
+func TestIntegrationRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(t *testing.T) DataProvider {
+		return NewRepo(testdb.Open(t, "subscriptions", "address_subscriptions"))
+	})
+}
+
+// testRepoConformance checks the behaviour every subscriptions repository has to follow
+func testRepoConformance(t *testing.T, factory func(t *testing.T) DataProvider) {
+	t.Run("dao subscriptions", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			subscriberID = uuid.New()
+			otherID      = uuid.New()
+			daoID        = uuid.New()
+		)
+
+		_, err := repo.GetByID(subscriberID, daoID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		require.NoError(t, repo.Create(Subscription{SubscriberID: subscriberID, DaoID: daoID}))
+		require.NoError(t, repo.Create(Subscription{SubscriberID: otherID, DaoID: daoID}))
+		require.NoError(t, repo.Create(Subscription{SubscriberID: subscriberID, DaoID: uuid.New()}))
+
+		sub, err := repo.GetByID(subscriberID, daoID)
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, sub.ID)
+
+		list, err := repo.GetBySubscriber(subscriberID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+
+		list, err = repo.GetSubscribers(daoID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+
+		require.NoError(t, repo.Delete(sub))
+
+		_, err = repo.GetByID(subscriberID, daoID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		list, err = repo.GetSubscribers(daoID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, otherID, list[0].SubscriberID)
+
+		first, err := repo.GetChunk(uuid.Nil, 1)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		rest, err := repo.GetChunk(first[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		require.NotEqual(t, first[0].ID, rest[0].ID)
+	})
+
+	t.Run("address subscriptions", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			subscriberID = uuid.New()
+			otherID      = uuid.New()
+		)
+
+		_, err := repo.GetAddressByID(subscriberID, "0xaddress")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		require.NoError(t, repo.CreateAddress(AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"}))
+		require.NoError(t, repo.CreateAddress(AddressSubscription{SubscriberID: otherID, Address: "0xaddress"}))
+		require.NoError(t, repo.CreateAddress(AddressSubscription{SubscriberID: subscriberID, Address: "0xother"}))
+
+		// the subscriber follows the address once
+		require.NoError(t, repo.CreateAddress(AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"}))
+
+		sub, err := repo.GetAddressByID(subscriberID, "0xaddress")
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, sub.ID)
+
+		list, err := repo.GetAddressesBySubscriber(subscriberID)
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+
+		list, err = repo.GetAddressFollowers("0xaddress")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+
+		require.NoError(t, repo.DeleteAddress(sub))
+
+		_, err = repo.GetAddressByID(subscriberID, "0xaddress")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		list, err = repo.GetAddressFollowers("0xaddress")
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, otherID, list[0].SubscriberID)
+
+		first, err := repo.GetAddressChunk(uuid.Nil, 1)
+		require.NoError(t, err)
+		require.Len(t, first, 1)
+
+		rest, err := repo.GetAddressChunk(first[0].ID, 10)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+		require.NotEqual(t, first[0].ID, rest[0].ID)
+	})
+}
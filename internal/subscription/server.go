@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -19,6 +20,8 @@ import (
 type SubscriptionProvider interface {
 	Subscribe(_ context.Context, item Subscription) (*Subscription, error)
 	Unsubscribe(_ context.Context, item Subscription) error
+	FollowAddress(_ context.Context, item AddressSubscription) (*AddressSubscription, error)
+	UnfollowAddress(_ context.Context, item AddressSubscription) error
 }
 
 type Server struct {
@@ -49,6 +52,9 @@ func (s *Server) Subscribe(ctx context.Context, req *feedpb.SubscribeRequest) (*
 		SubscriberID: subID,
 		DaoID:        daoId,
 	})
+	if errors.Is(err, ErrQuotaExceeded) {
+		return nil, quotaExceeded(subID, err)
+	}
 	if err != nil {
 		log.Error().Err(err).Msgf("subscribe: %s - %s", subID, req.GetDaoId())
 		return nil, status.Error(codes.Internal, "internal error")
@@ -89,3 +95,69 @@ func (s *Server) Unsubscribe(ctx context.Context, req *feedpb.UnsubscribeRequest
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *Server) FollowAddress(ctx context.Context, req *feedpb.FollowAddressRequest) (*emptypb.Empty, error) {
+	subID := subscriber.GetSubscriberID(ctx)
+
+	if NormalizeAddress(req.GetAddress()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	_, err := s.sp.FollowAddress(ctx, AddressSubscription{
+		SubscriberID: subID,
+		Address:      req.GetAddress(),
+	})
+	if errors.Is(err, ErrQuotaExceeded) {
+		return nil, quotaExceeded(subID, err)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("follow address: %s - %s", subID, req.GetAddress())
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log.Debug().Msgf("follow address: %s - %s", subID, req.GetAddress())
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UnfollowAddress(ctx context.Context, req *feedpb.UnfollowAddressRequest) (*emptypb.Empty, error) {
+	subID := subscriber.GetSubscriberID(ctx)
+
+	if NormalizeAddress(req.GetAddress()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	err := s.sp.UnfollowAddress(ctx, AddressSubscription{
+		SubscriberID: subID,
+		Address:      req.GetAddress(),
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.InvalidArgument, "invalid address subscription")
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msgf("unfollow address: %s - %s", subID, req.GetAddress())
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	log.Debug().Msgf("unfollow address: %s - %s", subID, req.GetAddress())
+
+	return &emptypb.Empty{}, nil
+}
+
+// quotaExceeded returns the error with the quota failure, the subscriber has to remove some subscriptions first
+func quotaExceeded(subID uuid.UUID, err error) error {
+	st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).
+		WithDetails(&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     "subscriber:" + subID.String(),
+				Description: err.Error(),
+			}},
+		})
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,46 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+	"github.com/goverland-labs/goverland-core-feed/protocol/feedpb"
+)
+
+func TestUnitServerQuotaFailure(t *testing.T) {
+	service, err := NewService(NewMemoryRepo(), NewCache(), nopInvalidator{}, nopNotifier{}, 1)
+	require.NoError(t, err)
+
+	s := NewServer(service)
+	subscriberID := uuid.New()
+	ctx := context.WithValue(context.Background(), subscriber.IDKey, subscriberID)
+
+	_, err = s.Subscribe(ctx, &feedpb.SubscribeRequest{DaoId: uuid.NewString()})
+	require.NoError(t, err)
+
+	for _, call := range []func() error{
+		func() error {
+			_, err := s.Subscribe(ctx, &feedpb.SubscribeRequest{DaoId: uuid.NewString()})
+			return err
+		},
+		func() error {
+			_, err := s.FollowAddress(ctx, &feedpb.FollowAddressRequest{Address: "0xaddress"})
+			return err
+		},
+	} {
+		st := status.Convert(call())
+		require.Equal(t, codes.ResourceExhausted, st.Code())
+
+		require.Len(t, st.Details(), 1)
+		failure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+		require.True(t, ok)
+		require.Equal(t, "subscriber:"+subscriberID.String(), failure.GetViolations()[0].GetSubject())
+	}
+}
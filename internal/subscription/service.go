@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/cache"
+	"github.com/goverland-labs/goverland-core-feed/internal/pubsub"
 )
 
 //go:generate mockgen -destination=mocks_test.go -package=subscription . DataProvider,Cacher
@@ -16,24 +20,54 @@ type DataProvider interface {
 	Delete(Subscription) error
 	GetByID(uuid.UUID, uuid.UUID) (Subscription, error)
 	GetSubscribers(daoID uuid.UUID) ([]Subscription, error)
+	GetBySubscriber(subscriberID uuid.UUID) ([]Subscription, error)
+	CreateAddress(AddressSubscription) error
+	DeleteAddress(AddressSubscription) error
+	GetAddressByID(uuid.UUID, string) (AddressSubscription, error)
+	GetAddressFollowers(address string) ([]AddressSubscription, error)
+	GetAddressesBySubscriber(subscriberID uuid.UUID) ([]AddressSubscription, error)
+	GetChunk(after uuid.UUID, limit int) ([]Subscription, error)
+	GetAddressChunk(after uuid.UUID, limit int) ([]AddressSubscription, error)
 }
 
 type Cacher interface {
-	AddItems(string, ...uuid.UUID)
+	AddToCached(string, ...uuid.UUID) bool
 	RemoveItem(string, uuid.UUID)
-	UpdateItems(string, ...uuid.UUID)
+	UpdateItemsIfVersion(cache.Version, string, ...uuid.UUID) bool
 	GetItems(string) ([]uuid.UUID, bool)
+	Version() cache.Version
+}
+
+// Invalidator notifies other replicas about changed cache keys
+type Invalidator interface {
+	Publish(cache, key string)
 }
 
+// Notifier notifies watchers of the subscriber feed on all replicas
+type Notifier interface {
+	PublishNoWait(msg string)
+}
+
+// ErrQuotaExceeded is returned if the subscriber has the max count of DAO and address subscriptions
+var ErrQuotaExceeded = errors.New("subscriptions quota is exceeded")
+
 type Service struct {
-	repo  DataProvider
-	cache Cacher
+	repo        DataProvider
+	cache       Cacher
+	invalidator Invalidator
+	notifier    Notifier
+
+	// maxSubscriptions is the limit of DAO and address subscriptions of the subscriber, zero means no limit
+	maxSubscriptions int
 }
 
-func NewService(r DataProvider, c Cacher) (*Service, error) {
+func NewService(r DataProvider, c Cacher, i Invalidator, n Notifier, maxSubscriptions int) (*Service, error) {
 	return &Service{
-		repo:  r,
-		cache: c,
+		repo:             r,
+		cache:            c,
+		invalidator:      i,
+		notifier:         n,
+		maxSubscriptions: maxSubscriptions,
 	}, nil
 }
 
@@ -47,12 +81,18 @@ func (s *Service) Subscribe(_ context.Context, item Subscription) (*Subscription
 		return &sub, nil
 	}
 
+	if err = s.checkQuota(item.SubscriberID); err != nil {
+		return nil, err
+	}
+
 	err = s.repo.Create(item)
 	if err != nil {
 		return nil, fmt.Errorf("create subscription: %w", err)
 	}
 
-	go s.cache.AddItems(item.DaoID.String(), item.SubscriberID)
+	s.cache.AddToCached(item.DaoID.String(), item.SubscriberID)
+	s.invalidator.Publish(CacheName, item.DaoID.String())
+	s.notifySubscriber(item.SubscriberID)
 
 	return &item, err
 }
@@ -68,7 +108,9 @@ func (s *Service) Unsubscribe(_ context.Context, item Subscription) error {
 		return fmt.Errorf("delete scubscription[%s - %s]: %w", item.SubscriberID, item.DaoID, err)
 	}
 
-	go s.cache.RemoveItem(item.DaoID.String(), item.SubscriberID)
+	s.cache.RemoveItem(item.DaoID.String(), item.SubscriberID)
+	s.invalidator.Publish(CacheName, item.DaoID.String())
+	s.notifySubscriber(item.SubscriberID)
 
 	return nil
 }
@@ -78,6 +120,7 @@ func (s *Service) GetSubscribers(_ context.Context, daoID uuid.UUID) ([]uuid.UUI
 		return list, nil
 	}
 
+	version := s.cache.Version()
 	data, err := s.repo.GetSubscribers(daoID)
 	if err != nil {
 		return nil, fmt.Errorf("get subscribers: %w", err)
@@ -88,7 +131,201 @@ func (s *Service) GetSubscribers(_ context.Context, daoID uuid.UUID) ([]uuid.UUI
 		response[i] = sub.SubscriberID
 	}
 
-	go s.cache.UpdateItems(daoID.String(), response...)
+	s.cache.UpdateItemsIfVersion(version, daoID.String(), response...)
+
+	return response, nil
+}
+
+func (s *Service) FollowAddress(_ context.Context, item AddressSubscription) (*AddressSubscription, error) {
+	item.Address = NormalizeAddress(item.Address)
+
+	sub, err := s.repo.GetAddressByID(item.SubscriberID, item.Address)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get address subscription: %w", err)
+	}
+
+	if err == nil {
+		return &sub, nil
+	}
+
+	if err = s.checkQuota(item.SubscriberID); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.CreateAddress(item)
+	if err != nil {
+		return nil, fmt.Errorf("create address subscription: %w", err)
+	}
+
+	// the concurrent call could follow the address first, so the stored subscription is returned
+	sub, err = s.repo.GetAddressByID(item.SubscriberID, item.Address)
+	if err != nil {
+		return nil, fmt.Errorf("get address subscription: %w", err)
+	}
+
+	s.cache.AddToCached(addressCacheKey(item.Address), item.SubscriberID)
+	s.invalidator.Publish(CacheName, addressCacheKey(item.Address))
+	s.notifySubscriber(item.SubscriberID)
+
+	return &sub, nil
+}
+
+func (s *Service) UnfollowAddress(_ context.Context, item AddressSubscription) error {
+	item.Address = NormalizeAddress(item.Address)
+
+	sub, err := s.repo.GetAddressByID(item.SubscriberID, item.Address)
+	if err != nil {
+		return fmt.Errorf("get address subscription: %w", err)
+	}
+
+	err = s.repo.DeleteAddress(sub)
+	if err != nil {
+		return fmt.Errorf("delete address subscription[%s - %s]: %w", item.SubscriberID, item.Address, err)
+	}
+
+	s.cache.RemoveItem(addressCacheKey(item.Address), item.SubscriberID)
+	s.invalidator.Publish(CacheName, addressCacheKey(item.Address))
+	s.notifySubscriber(item.SubscriberID)
+
+	return nil
+}
+
+// GetAddressFollowers returns list of subscribers who follow the address activity
+func (s *Service) GetAddressFollowers(_ context.Context, address string) ([]uuid.UUID, error) {
+	address = NormalizeAddress(address)
+	if list, ok := s.cache.GetItems(addressCacheKey(address)); ok {
+		return list, nil
+	}
+
+	version := s.cache.Version()
+	data, err := s.repo.GetAddressFollowers(address)
+	if err != nil {
+		return nil, fmt.Errorf("get address followers: %w", err)
+	}
+
+	response := make([]uuid.UUID, len(data))
+	for i, sub := range data {
+		response[i] = sub.SubscriberID
+	}
+
+	s.cache.UpdateItemsIfVersion(version, addressCacheKey(address), response...)
 
 	return response, nil
 }
+
+// GetSubscriberTopics returns notification topics of all DAOs and addresses the subscriber follows
+func (s *Service) GetSubscriberTopics(_ context.Context, subscriberID uuid.UUID) ([]string, error) {
+	daos, err := s.repo.GetBySubscriber(subscriberID)
+	if err != nil {
+		return nil, fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	addresses, err := s.repo.GetAddressesBySubscriber(subscriberID)
+	if err != nil {
+		return nil, fmt.Errorf("get address subscriptions: %w", err)
+	}
+
+	topics := make([]string, 0, len(daos)+len(addresses)+1)
+	// personal topic guarantees the subscriber is never subscribed to all messages
+	topics = append(topics, pubsub.SubscriberTopic(subscriberID))
+	for _, sub := range daos {
+		topics = append(topics, pubsub.DaoTopic(sub.DaoID))
+	}
+	for _, sub := range addresses {
+		topics = append(topics, pubsub.AddressTopic(sub.Address))
+	}
+
+	return topics, nil
+}
+
+// notifySubscriber lets open streams of the subscriber refresh their topics
+func (s *Service) notifySubscriber(subscriberID uuid.UUID) {
+	s.notifier.PublishNoWait(pubsub.SubscriberTopic(subscriberID))
+}
+
+// checkQuota checks the subscriber could have one more subscription.
+// Concurrent subscriptions of the same subscriber could exceed the quota a bit, it's acceptable for the limit.
+func (s *Service) checkQuota(subscriberID uuid.UUID) error {
+	if s.maxSubscriptions <= 0 {
+		return nil
+	}
+
+	daos, err := s.repo.GetBySubscriber(subscriberID)
+	if err != nil {
+		return fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	addresses, err := s.repo.GetAddressesBySubscriber(subscriberID)
+	if err != nil {
+		return fmt.Errorf("get address subscriptions: %w", err)
+	}
+
+	if len(daos)+len(addresses) >= s.maxSubscriptions {
+		metricQuotaExceededCounter.Inc()
+
+		return fmt.Errorf("%w: the limit is %d", ErrQuotaExceeded, s.maxSubscriptions)
+	}
+
+	return nil
+}
+
+// WarmUp loads subscribers of all DAOs and addresses to the cache by chunks and returns the count of cached keys.
+// Keys are not cached if the cache is changed or invalidated while they are loaded, they are loaded lazily then.
+func (s *Service) WarmUp(_ context.Context, chunkSize int) (int, error) {
+	version := s.cache.Version()
+	subscribers := make(map[string][]uuid.UUID)
+
+	var after uuid.UUID
+	for {
+		chunk, err := s.repo.GetChunk(after, chunkSize)
+		if err != nil {
+			return 0, fmt.Errorf("get subscriptions chunk: %w", err)
+		}
+
+		for _, sub := range chunk {
+			key := sub.DaoID.String()
+			subscribers[key] = append(subscribers[key], sub.SubscriberID)
+			after = sub.ID
+		}
+
+		if len(chunk) < chunkSize {
+			break
+		}
+	}
+
+	after = uuid.UUID{}
+	for {
+		chunk, err := s.repo.GetAddressChunk(after, chunkSize)
+		if err != nil {
+			return 0, fmt.Errorf("get address subscriptions chunk: %w", err)
+		}
+
+		for _, sub := range chunk {
+			key := addressCacheKey(sub.Address)
+			subscribers[key] = append(subscribers[key], sub.SubscriberID)
+			after = sub.ID
+		}
+
+		if len(chunk) < chunkSize {
+			break
+		}
+	}
+
+	cached := 0
+	for key, list := range subscribers {
+		if s.cache.UpdateItemsIfVersion(version, key, list...) {
+			cached++
+		}
+	}
+
+	return cached, nil
+}
+
+// NormalizeAddress converts address to the form we store it in
+func NormalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+func addressCacheKey(address string) string {
+	return "address:" + address
+}
@@ -0,0 +1,145 @@
+package subscription
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitServiceWarmUp(t *testing.T) {
+	repo := NewMemoryRepo()
+	c := NewCache()
+
+	var (
+		daoID       = uuid.New()
+		subscribers = []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	)
+
+	for _, id := range subscribers {
+		require.NoError(t, repo.Create(Subscription{SubscriberID: id, DaoID: daoID}))
+	}
+	require.NoError(t, repo.CreateAddress(AddressSubscription{SubscriberID: subscribers[0], Address: "0xaddress"}))
+
+	service, err := NewService(repo, c, nopInvalidator{}, nopNotifier{}, 0)
+	require.NoError(t, err)
+
+	keys, err := service.WarmUp(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, keys)
+
+	items, ok := c.GetItems(daoID.String())
+	require.True(t, ok)
+	require.ElementsMatch(t, subscribers, items)
+
+	items, ok = c.GetItems(addressCacheKey("0xaddress"))
+	require.True(t, ok)
+	require.Equal(t, []uuid.UUID{subscribers[0]}, items)
+}
+
+type nopInvalidator struct{}
+
+func (nopInvalidator) Publish(string, string) {}
+
+type nopNotifier struct{}
+
+func (nopNotifier) PublishNoWait(string) {}
+
+func TestUnitServiceQuota(t *testing.T) {
+	service, err := NewService(NewMemoryRepo(), NewCache(), nopInvalidator{}, nopNotifier{}, 2)
+	require.NoError(t, err)
+
+	subscriberID := uuid.New()
+	daoID := uuid.New()
+
+	_, err = service.Subscribe(context.Background(), Subscription{SubscriberID: subscriberID, DaoID: daoID})
+	require.NoError(t, err)
+	_, err = service.FollowAddress(context.Background(), AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"})
+	require.NoError(t, err)
+
+	_, err = service.Subscribe(context.Background(), Subscription{SubscriberID: subscriberID, DaoID: uuid.New()})
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	_, err = service.FollowAddress(context.Background(), AddressSubscription{SubscriberID: subscriberID, Address: "0xother"})
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	// existing subscriptions are not counted twice
+	_, err = service.Subscribe(context.Background(), Subscription{SubscriberID: subscriberID, DaoID: daoID})
+	require.NoError(t, err)
+
+	// quotas are separated by subscribers
+	_, err = service.Subscribe(context.Background(), Subscription{SubscriberID: uuid.New(), DaoID: daoID})
+	require.NoError(t, err)
+}
+
+func TestUnitServiceFollowAddress(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepo()
+
+	service, err := NewService(repo, NewCache(), nopInvalidator{}, nopNotifier{}, 0)
+	require.NoError(t, err)
+
+	subscriberID := uuid.New()
+
+	first, err := service.FollowAddress(ctx, AddressSubscription{SubscriberID: subscriberID, Address: "0xAddress"})
+	require.NoError(t, err)
+	require.Equal(t, "0xaddress", first.Address)
+	require.NotEqual(t, uuid.Nil, first.ID)
+
+	// following again returns the same subscription
+	second, err := service.FollowAddress(ctx, AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"})
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+
+	stored, err := repo.GetAddressFollowers("0xaddress")
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+
+	followers, err := service.GetAddressFollowers(ctx, "0xADDRESS")
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{subscriberID}, followers)
+
+	require.NoError(t, service.UnfollowAddress(ctx, AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"}))
+
+	followers, err = service.GetAddressFollowers(ctx, "0xaddress")
+	require.NoError(t, err)
+	require.Empty(t, followers)
+
+	require.Error(t, service.UnfollowAddress(ctx, AddressSubscription{SubscriberID: subscriberID, Address: "0xaddress"}))
+}
+
+// invalidatingRepo invalidates the key while subscriptions are loaded, like the invalidation of another replica
+type invalidatingRepo struct {
+	*MemoryRepo
+	onChunk func()
+}
+
+func (r *invalidatingRepo) GetChunk(after uuid.UUID, limit int) ([]Subscription, error) {
+	if r.onChunk != nil {
+		r.onChunk()
+	}
+
+	return r.MemoryRepo.GetChunk(after, limit)
+}
+
+func TestUnitServiceWarmUpSkipsInvalidatedCache(t *testing.T) {
+	repo := &invalidatingRepo{MemoryRepo: NewMemoryRepo()}
+	c := NewCache()
+
+	daoID := uuid.New()
+	require.NoError(t, repo.Create(Subscription{SubscriberID: uuid.New(), DaoID: daoID}))
+
+	repo.onChunk = func() {
+		c.InvalidateKey(daoID.String())
+	}
+
+	service, err := NewService(repo, c, nopInvalidator{}, nopNotifier{}, 0)
+	require.NoError(t, err)
+
+	keys, err := service.WarmUp(context.Background(), 10)
+	require.NoError(t, err)
+	require.Zero(t, keys)
+
+	_, ok := c.GetItems(daoID.String())
+	require.False(t, ok)
+}
@@ -0,0 +1,50 @@
+// Package testdb provides the postgres database for tests which have to pass against the real storage
+package testdb
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/migration"
+	"github.com/goverland-labs/goverland-core-feed/resources"
+)
+
+// DSNEnv is the environment variable with DSN of the test database, all data of the database could be removed by tests
+const DSNEnv = "TEST_POSTGRES_DSN"
+
+// Open connects to the test database, applies migrations and truncates the tables.
+// The test is skipped if the test database is not configured.
+func Open(t *testing.T, tables ...string) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", DSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	m, err := migration.NewMigrator(db, resources.Migrations)
+	require.NoError(t, err)
+
+	_, err = m.Up()
+	require.NoError(t, err)
+
+	if len(tables) > 0 {
+		require.NoError(t, db.Exec("truncate "+strings.Join(tables, ", ")).Error)
+	}
+
+	return db
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// batchFlushInterval is the precision of the max wait of batches
+	batchFlushInterval = time.Second
+	// batchClaimTTL is the time the claimed batch isn't sent by other replicas,
+	// callbacks of the batch are sent again if the batch isn't sent and removed until then
+	batchClaimTTL = time.Minute
+	// batchSendTime limits sending of claimed callbacks, the rest is released before the claim expires,
+	// so other replicas don't send it while earlier callbacks are still being sent
+	batchSendTime = batchClaimTTL / 2
+)
+
+// BatchKey identifies the batch of callbacks of the subscriber to the webhook
+type BatchKey struct {
+	SubscriberID uuid.UUID
+	URL          string
+}
+
+// BatchCallback is the callback collected to the batch. Batches are kept in the database,
+// so collected callbacks are not lost on the restart and are sent by any replica.
+type BatchCallback struct {
+	ID           uint64 `gorm:"primarykey"`
+	CreatedAt    time.Time
+	SubscriberID uuid.UUID
+	URL          string
+	// Deadline is the time the batch is sent even if it's not full
+	Deadline time.Time
+	MaxSize  int
+	// Payload is the marshaled Callback
+	Payload json.RawMessage
+	// ClaimedUntil is set while the batch is sent by the replica
+	ClaimedUntil *time.Time
+	// Attempts is the count of failed deliveries of the callback
+	Attempts int
+	// RetryAt postpones the whole batch after the failed delivery, so callbacks are sent in order
+	RetryAt *time.Time
+}
+
+func (BatchCallback) TableName() string {
+	return "webhook_batch_callbacks"
+}
+
+func (c *BatchCallback) Key() BatchKey {
+	return BatchKey{SubscriberID: c.SubscriberID, URL: c.URL}
+}
+
+// claimed returns true if the callback is being sent by some replica
+func (c *BatchCallback) claimed(now time.Time) bool {
+	return c.ClaimedUntil != nil && c.ClaimedUntil.After(now)
+}
+
+// postponed returns true if the batch of the callback waits for the retry
+func (c *BatchCallback) postponed(now time.Time) bool {
+	return c.RetryAt != nil && c.RetryAt.After(now)
+}
+
+type BatchProvider interface {
+	AddBatchCallback(cb *BatchCallback) error
+	// CountBatchCallbacks returns the count of not claimed callbacks of the batch
+	CountBatchCallbacks(key BatchKey, now time.Time) (int64, error)
+	// GetDueBatches returns not postponed batches with not claimed callbacks waiting for the deadline
+	GetDueBatches(now time.Time) ([]BatchKey, error)
+	// ClaimBatch claims callbacks of the batch until the time and returns them in the order of adding,
+	// nothing is claimed while the batch is postponed or its claimed callbacks are sent by some replica
+	ClaimBatch(key BatchKey, now, until time.Time) ([]BatchCallback, error)
+	// RetryBatch releases claimed callbacks of the batch and postpones the batch until the time,
+	// attempts of failed callbacks are counted
+	RetryBatch(key BatchKey, until time.Time, failed []uint64) error
+	DeleteBatchCallbacks(ids []uint64) error
+}
+
+// batchResponse is the optional body of the 207 Multi-Status response to the batch
+type batchResponse struct {
+	// Failed are indexes of callbacks in the batch rejected by the webhook
+	Failed []int `json:"failed"`
+}
+
+func (cb *Callback) batched() bool {
+	return cb.BatchMaxSize > 1 && cb.BatchMaxWait > 0
+}
+
+// chunkSize returns the count of leading callbacks sent by one request.
+// Callbacks parked without batching are sent one by one.
+func chunkSize(claimed []BatchCallback) int {
+	if claimed[0].MaxSize <= 1 {
+		return 1
+	}
+
+	size := 1
+	for size < len(claimed) && size < claimed[0].MaxSize && claimed[size].MaxSize > 1 {
+		size++
+	}
+
+	return size
+}
+
+func batchBody(callbacks []Callback) ([]byte, error) {
+	bodies := make([]json.RawMessage, len(callbacks))
+	for i := range callbacks {
+		bodies[i] = callbacks[i].Body
+	}
+
+	return json.Marshal(bodies)
+}
+
+// rejectedCallbacks returns indexes of rejected callbacks, the malformed response rejects nothing
+func rejectedCallbacks(response string) map[int]struct{} {
+	var resp batchResponse
+	if err := json.Unmarshal([]byte(response), &resp); err != nil {
+		return nil
+	}
+
+	rejected := make(map[int]struct{}, len(resp.Failed))
+	for _, i := range resp.Failed {
+		rejected[i] = struct{}{}
+	}
+
+	return rejected
+}
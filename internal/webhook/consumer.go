@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+	client "github.com/goverland-labs/goverland-platform-events/pkg/natsclient"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/config"
+)
+
+const (
+	deliveryMaxPendingElements = 100
+	deliveryRateLimit          = 500 * client.KiB
+	deliveryExecutionTtl       = time.Minute
+)
+
+// Queue publishes callbacks to the delivery subject instead of the callback subject, so the outbox relay
+// only enqueues them and slow webhooks don't hold back it. Other messages are passed to the next publisher as is.
+type Queue struct {
+	next    Publisher
+	subject string
+}
+
+func NewQueue(next Publisher, subject string) *Queue {
+	return &Queue{
+		next:    next,
+		subject: subject,
+	}
+}
+
+func (q *Queue) PublishJSON(ctx context.Context, subject string, obj any) error {
+	if subject == core.SubjectCallback {
+		subject = q.subject
+	}
+
+	return q.next.PublishJSON(ctx, subject, obj)
+}
+
+// Consumer passes callbacks enqueued to the delivery subject to the dispatcher
+type Consumer struct {
+	conn       *nats.Conn
+	subject    string
+	dispatcher *Dispatcher
+	consumer   *client.Consumer[Callback]
+}
+
+func NewConsumer(nc *nats.Conn, subject string, d *Dispatcher) *Consumer {
+	return &Consumer{
+		conn:       nc,
+		subject:    subject,
+		dispatcher: d,
+	}
+}
+
+func (c *Consumer) handler(ctx context.Context) func(Callback) error {
+	return func(cb Callback) error {
+		return c.dispatcher.Deliver(ctx, cb)
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) error {
+	group := config.GenerateGroupName("webhook_delivery")
+
+	opts := []client.ConsumerOpt{
+		client.WithRateLimit(deliveryRateLimit),
+		client.WithMaxAckPending(deliveryMaxPendingElements),
+		client.WithAckWait(deliveryExecutionTtl),
+	}
+
+	consumer, err := client.NewConsumer(ctx, c.conn, group, c.subject, c.handler(ctx), opts...)
+	if err != nil {
+		return fmt.Errorf("consume for %s/%s: %w", group, c.subject, err)
+	}
+	c.consumer = consumer
+
+	log.Info().Msg("webhook delivery consumer is started")
+
+	<-ctx.Done()
+
+	if err := c.consumer.Close(); err != nil {
+		log.Error().Err(err).Msg("unable to close webhook delivery consumer")
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitQueuePublishesCallbacksToDeliverySubject(t *testing.T) {
+	next := &recordingPublisher{}
+	q := NewQueue(next, "webhook.delivery")
+
+	require.NoError(t, q.PublishJSON(context.Background(), core.SubjectCallback, Callback{}))
+	require.NoError(t, q.PublishJSON(context.Background(), "timeline", json.RawMessage(`{}`)))
+
+	require.Equal(t, []string{"webhook.delivery", "timeline"}, next.subjects)
+}
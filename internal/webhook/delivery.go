@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	deliveriesRetentionBatchSize = 1000
+	deliveriesRetentionInterval  = time.Hour
+)
+
+// Callback is the outbox payload of the callback. It's compatible with core.CallbackPayload
+// and contains identifiers for the deliveries log.
+type Callback struct {
+	core.CallbackPayload
+
+	SubscriberID uuid.UUID `json:"subscriber_id"`
+	DaoID        uuid.UUID `json:"dao_id"`
+	FeedItemID   uuid.UUID `json:"feed_item_id"`
+	Action       string    `json:"action"`
+	Redelivery   bool      `json:"redelivery,omitempty"`
+
+	// BatchMaxSize and BatchMaxWait are batching options of the subscriber used by the http delivery
+	BatchMaxSize int           `json:"batch_max_size,omitempty"`
+	BatchMaxWait time.Duration `json:"batch_max_wait,omitempty"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSuccess DeliveryStatus = "success"
+	DeliveryStatusFailure DeliveryStatus = "failure"
+	// DeliveryStatusPaused means the callback is dropped because the webhook is paused by the circuit breaker
+	DeliveryStatusPaused DeliveryStatus = "paused"
+)
+
+// Delivery is the attempt to deliver the callback to the webhook
+type Delivery struct {
+	ID           uuid.UUID `gorm:"primary_key"`
+	CreatedAt    time.Time
+	SubscriberID uuid.UUID
+	DaoID        uuid.UUID
+	FeedItemID   uuid.UUID
+	Action       string
+	URL          string
+	Status       DeliveryStatus
+	HTTPStatus   int
+	LatencyMs    int64
+	// Response is the truncated response body or the error of the request
+	Response   string
+	Redelivery bool
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// DeliveryFilter selects deliveries, zero fields match any value
+type DeliveryFilter struct {
+	SubscriberID uuid.UUID
+	DaoID        uuid.UUID
+	FeedItemID   uuid.UUID
+	Status       DeliveryStatus
+	From         time.Time
+	To           time.Time
+
+	Limit  int
+	Offset int
+}
+
+func (f DeliveryFilter) matches(d *Delivery) bool {
+	return (f.SubscriberID == uuid.Nil || d.SubscriberID == f.SubscriberID) &&
+		(f.DaoID == uuid.Nil || d.DaoID == f.DaoID) &&
+		(f.FeedItemID == uuid.Nil || d.FeedItemID == f.FeedItemID) &&
+		(f.Status == "" || d.Status == f.Status) &&
+		(f.From.IsZero() || !d.CreatedAt.Before(f.From)) &&
+		(f.To.IsZero() || d.CreatedAt.Before(f.To))
+}
+
+type DeliveryProvider interface {
+	CreateDelivery(d *Delivery) error
+	// GetDeliveries returns deliveries from the newest ones
+	GetDeliveries(filter DeliveryFilter) ([]Delivery, error)
+	DeleteDeliveriesBefore(before time.Time, limit int) (int64, error)
+	WithRetentionLock(fn func() error) (bool, error)
+}
+
+// DeliveriesRetentionWorker periodically removes deliveries older than the retention period
+type DeliveriesRetentionWorker struct {
+	repo      DeliveryProvider
+	retention time.Duration
+}
+
+func NewDeliveriesRetentionWorker(repo DeliveryProvider, retention time.Duration) *DeliveriesRetentionWorker {
+	return &DeliveriesRetentionWorker{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+func (w *DeliveriesRetentionWorker) Start(ctx context.Context) error {
+	for {
+		locked, err := w.repo.WithRetentionLock(w.cleanup)
+		if err != nil {
+			log.Error().Err(err).Msg("webhook deliveries retention")
+		} else if !locked {
+			log.Debug().Msg("webhook deliveries retention is run by another replica")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(deliveriesRetentionInterval):
+		}
+	}
+}
+
+func (w *DeliveriesRetentionWorker) cleanup() error {
+	before := time.Now().Add(-w.retention)
+
+	var total int64
+	for {
+		deleted, err := w.repo.DeleteDeliveriesBefore(before, deliveriesRetentionBatchSize)
+		if err != nil {
+			return fmt.Errorf("delete deliveries before %s: %w", before, err)
+		}
+
+		total += deleted
+		if deleted < deliveriesRetentionBatchSize {
+			break
+		}
+	}
+
+	log.Info().
+		Int64("deleted", total).
+		Time("before", before).
+		Msg("webhook deliveries retention finished")
+
+	return nil
+}
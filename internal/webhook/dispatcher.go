@@ -0,0 +1,558 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type Publisher interface {
+	PublishJSON(ctx context.Context, subject string, obj any) error
+}
+
+type HealthProvider interface {
+	GetHealth(url string) (*EndpointHealth, error)
+	UpdateHealth(url string, fn func(h *EndpointHealth)) (*EndpointHealth, error)
+	ClaimProbe(url string, disabledUntil, until time.Time) (bool, error)
+	GetHealthByURLs(urls []string) ([]EndpointHealth, error)
+}
+
+type DataProvider interface {
+	HealthProvider
+	DeliveryProvider
+	BatchProvider
+}
+
+// DisabledPayload is published when the webhook is paused by the circuit breaker
+type DisabledPayload struct {
+	URL                 string    `json:"url"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error"`
+	DisabledUntil       time.Time `json:"disabled_until"`
+}
+
+// maxRetryBackoff limits the delay between attempts of the failed callback
+const maxRetryBackoff = time.Hour
+
+// RetryOptions limit attempts of failed callbacks including the first one, the delay after the failed attempt
+// starts from Backoff and is doubled after every attempt. MaxAttempts below 2 disables retries.
+type RetryOptions struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// delay returns the delay of the retry after the count of failed attempts
+func (o RetryOptions) delay(attempts int) time.Duration {
+	delay := o.Backoff
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryBackoff)
+}
+
+type DispatcherOptions struct {
+	Breaker BreakerOptions
+	Retry   RetryOptions
+	// DisabledSubject is the subject of DisabledPayload events
+	DisabledSubject string
+}
+
+// Dispatcher delivers callbacks consumed from the delivery subject to webhooks over HTTP. Every attempt is recorded
+// in the deliveries log, outcomes of deliveries are tracked per webhook url and paused webhooks are skipped.
+//
+// Callbacks of subscribers with batching are collected per webhook in the database and sent as the JSON array
+// when the batch is full or its oldest callback waits for the max wait. Batches of the webhook are sent in the order
+// of callbacks, so the order of callbacks of the DAO is kept.
+//
+// Callbacks which are not delivered are parked to the batch of the webhook as well instead of holding back
+// the delivery subject: failed callbacks are retried with the backoff, callbacks to the paused webhook wait
+// for the end of the pause. The failed batch postpones callbacks collected after it, so they are not sent ahead.
+type Dispatcher struct {
+	// events publishes DisabledPayload events
+	events Publisher
+	client *http.Client
+	repo   DataProvider
+	opts   DispatcherOptions
+	now    func() time.Time
+
+	// batchMu serializes batches of the replica, batches of other replicas are serialized by claims
+	batchMu sync.Mutex
+}
+
+func NewDispatcher(events Publisher, client *http.Client, repo DataProvider, opts DispatcherOptions) *Dispatcher {
+	return &Dispatcher{
+		events: events,
+		client: client,
+		repo:   repo,
+		opts:   opts,
+		now:    time.Now,
+	}
+}
+
+// Start sends batches waiting for the max wait. Collected callbacks are kept on the shutdown
+// and sent by other replicas or after the restart.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(batchFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.flush(ctx); err != nil {
+				log.Error().Err(err).Msg("flush webhook batches")
+			}
+		}
+	}
+}
+
+// Deliver sends the callback or adds it to the batch of the webhook
+func (d *Dispatcher) Deliver(ctx context.Context, cb Callback) error {
+	if cb.WebhookURL == "" {
+		return nil
+	}
+
+	// redeliveries are requested manually, so they are sent immediately
+	if !cb.batched() || cb.Redelivery {
+		res, err := d.deliver(ctx, cb.WebhookURL, []Callback{cb}, false)
+		if err != nil {
+			return err
+		}
+
+		return d.park(cb, res)
+	}
+
+	return d.add(ctx, cb)
+}
+
+// park saves the callback which isn't delivered for the retry, it's sent by the flush one by one
+// with batches of the webhook. The error is returned if the callback can't be saved, so it's consumed again.
+func (d *Dispatcher) park(cb Callback, res attempt) error {
+	if res.status == DeliveryStatusSuccess {
+		return nil
+	}
+
+	var attempts int
+	if res.status == DeliveryStatusFailure {
+		attempts = 1
+	}
+
+	retryAt, ok := d.retryAt(res, attempts)
+	if !ok {
+		d.drop(cb.WebhookURL, 1)
+
+		return nil
+	}
+
+	payload, err := json.Marshal(cb)
+	if err != nil {
+		return fmt.Errorf("marshal callback: %w", err)
+	}
+
+	now := d.now()
+	err = d.repo.AddBatchCallback(&BatchCallback{
+		CreatedAt:    now,
+		SubscriberID: cb.SubscriberID,
+		URL:          cb.WebhookURL,
+		Deadline:     now,
+		MaxSize:      1,
+		Payload:      payload,
+		Attempts:     attempts,
+		RetryAt:      &retryAt,
+	})
+	if err != nil {
+		return fmt.Errorf("park callback: %w", err)
+	}
+
+	return nil
+}
+
+// retryAt returns the time of the next attempt, it returns false if the failed callback isn't retried anymore
+func (d *Dispatcher) retryAt(res attempt, attempts int) (time.Time, bool) {
+	if res.status == DeliveryStatusPaused {
+		return res.pausedUntil, true
+	}
+
+	if !res.retryable || attempts >= d.opts.Retry.MaxAttempts {
+		return time.Time{}, false
+	}
+
+	return d.now().Add(d.opts.Retry.delay(attempts)), true
+}
+
+func (d *Dispatcher) drop(url string, count int) {
+	metricDroppedCounter.Add(float64(count))
+	log.Warn().Str("webhook_url", url).Int("callbacks", count).Msg("failed callbacks are dropped")
+}
+
+// add saves the callback to the batch of the webhook and sends the batch if it's full.
+// The saved callback is sent by the flush if the batch can't be sent now, so errors are only logged.
+func (d *Dispatcher) add(ctx context.Context, cb Callback) error {
+	payload, err := json.Marshal(cb)
+	if err != nil {
+		return fmt.Errorf("marshal callback: %w", err)
+	}
+
+	now := d.now()
+	bc := &BatchCallback{
+		CreatedAt:    now,
+		SubscriberID: cb.SubscriberID,
+		URL:          cb.WebhookURL,
+		Deadline:     now.Add(cb.BatchMaxWait),
+		MaxSize:      cb.BatchMaxSize,
+		Payload:      payload,
+	}
+	if err := d.repo.AddBatchCallback(bc); err != nil {
+		return fmt.Errorf("add batch callback: %w", err)
+	}
+
+	count, err := d.repo.CountBatchCallbacks(bc.Key(), now)
+	if err != nil {
+		log.Error().Err(err).Str("webhook_url", cb.WebhookURL).Msg("count batch callbacks")
+
+		return nil
+	}
+
+	if count < int64(cb.BatchMaxSize) {
+		return nil
+	}
+
+	if err := d.sendBatch(ctx, bc.Key()); err != nil {
+		log.Error().Err(err).Str("webhook_url", cb.WebhookURL).Msg("send full batch")
+	}
+
+	return nil
+}
+
+// flush sends batches waiting for the max wait, errors of sending batches are logged
+func (d *Dispatcher) flush(ctx context.Context) error {
+	keys, err := d.repo.GetDueBatches(d.now())
+	if err != nil {
+		return fmt.Errorf("get due batches: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := d.sendBatch(ctx, key); err != nil {
+			log.Error().Err(err).Str("webhook_url", key.URL).Msg("send batch")
+		}
+	}
+
+	return nil
+}
+
+// sendBatch claims collected callbacks of the batch and sends them, callbacks exceeding the max size
+// are sent by next requests. Sent callbacks are removed, the batch is postponed if the request isn't delivered.
+// The rest are released if sending takes too long and sent again after the claim expires if sending is interrupted.
+func (d *Dispatcher) sendBatch(ctx context.Context, key BatchKey) error {
+	d.batchMu.Lock()
+	defer d.batchMu.Unlock()
+
+	now := d.now()
+	claimed, err := d.repo.ClaimBatch(key, now, now.Add(batchClaimTTL))
+	if err != nil {
+		return fmt.Errorf("claim batch: %w", err)
+	}
+
+	for len(claimed) > 0 {
+		if d.now().Sub(now) >= batchSendTime {
+			if err := d.repo.RetryBatch(key, d.now(), nil); err != nil {
+				return fmt.Errorf("release batch: %w", err)
+			}
+
+			return nil
+		}
+
+		size := chunkSize(claimed)
+		chunk := claimed[:size]
+		claimed = claimed[size:]
+
+		ids := make([]uint64, len(chunk))
+		callbacks := make([]Callback, len(chunk))
+		for i := range chunk {
+			ids[i] = chunk[i].ID
+			if err := json.Unmarshal(chunk[i].Payload, &callbacks[i]); err != nil {
+				return fmt.Errorf("unmarshal batch callback %d: %w", chunk[i].ID, err)
+			}
+		}
+
+		res, err := d.deliver(ctx, key.URL, callbacks, chunk[0].MaxSize > 1)
+		if err != nil {
+			return err
+		}
+
+		if res.status != DeliveryStatusSuccess {
+			return d.retryBatch(key, chunk, res)
+		}
+
+		if err := d.repo.DeleteBatchCallbacks(ids); err != nil {
+			return fmt.Errorf("delete batch callbacks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// retryBatch postpones the batch until the retry of the chunk, so the rest of the batch isn't sent ahead of it.
+// The chunk is removed if it isn't retried anymore, then the rest of the batch is sent by the next flush.
+func (d *Dispatcher) retryBatch(key BatchKey, chunk []BatchCallback, res attempt) error {
+	var (
+		attempts int
+		ids      = make([]uint64, len(chunk))
+	)
+	for i := range chunk {
+		ids[i] = chunk[i].ID
+		attempts = max(attempts, chunk[i].Attempts)
+	}
+
+	var failed []uint64
+	if res.status == DeliveryStatusFailure {
+		attempts++
+		failed = ids
+	}
+
+	retryAt, ok := d.retryAt(res, attempts)
+	if !ok {
+		d.drop(key.URL, len(chunk))
+		if err := d.repo.DeleteBatchCallbacks(ids); err != nil {
+			return fmt.Errorf("delete batch callbacks: %w", err)
+		}
+
+		retryAt, failed = d.now(), nil
+	}
+
+	if err := d.repo.RetryBatch(key, retryAt, failed); err != nil {
+		return fmt.Errorf("retry batch: %w", err)
+	}
+
+	return nil
+}
+
+// attempt is the outcome of the request: failed callbacks are retried with the backoff if the failure
+// is retryable, callbacks to the paused webhook are not sent and wait for the end of the pause
+type attempt struct {
+	status      DeliveryStatus
+	retryable   bool
+	pausedUntil time.Time
+}
+
+// deliver sends callbacks by one request, the body is the JSON array of callback bodies if they are batched.
+// It returns an error only if the health of the webhook can't be read or the probe can't be claimed before the request.
+func (d *Dispatcher) deliver(ctx context.Context, url string, callbacks []Callback, batched bool) (attempt, error) {
+	health, err := d.repo.GetHealth(url)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		health = &EndpointHealth{URL: url}
+	} else if err != nil {
+		return attempt{}, err
+	}
+
+	now := d.now()
+	deliveries := make([]Delivery, len(callbacks))
+	for i, cb := range callbacks {
+		deliveries[i] = Delivery{
+			ID:           uuid.New(),
+			CreatedAt:    now,
+			SubscriberID: cb.SubscriberID,
+			DaoID:        cb.DaoID,
+			FeedItemID:   cb.FeedItemID,
+			Action:       cb.Action,
+			URL:          url,
+			Redelivery:   cb.Redelivery,
+		}
+	}
+
+	pausedUntil, err := d.pausedUntil(health, now)
+	if err != nil {
+		return attempt{}, err
+	}
+
+	if !pausedUntil.IsZero() {
+		metricDeliveriesCounter.WithLabelValues(deliveryResultPaused).Add(float64(len(callbacks)))
+		for i := range deliveries {
+			deliveries[i].Status = DeliveryStatusPaused
+		}
+
+		d.record(deliveries)
+
+		return attempt{status: DeliveryStatusPaused, pausedUntil: pausedUntil}, nil
+	}
+
+	body := callbacks[0].Body
+	if batched {
+		body, err = batchBody(callbacks)
+		if err != nil {
+			return attempt{}, fmt.Errorf("marshal batch: %w", err)
+		}
+	}
+
+	start := time.Now()
+	res := d.post(ctx, url, body)
+	latency := time.Since(start)
+	metricDeliveryHistogram.Observe(latency.Seconds())
+
+	var rejected map[int]struct{}
+	if batched && res.err == nil && res.status == http.StatusMultiStatus {
+		rejected = rejectedCallbacks(res.response)
+	}
+
+	for i := range deliveries {
+		deliveries[i].HTTPStatus = res.status
+		deliveries[i].LatencyMs = latency.Milliseconds()
+		deliveries[i].Response = truncate(res.response, maxErrorLength)
+
+		_, isRejected := rejected[i]
+		switch {
+		case res.err != nil:
+			deliveries[i].Status = DeliveryStatusFailure
+			deliveries[i].Response = truncate(res.err.Error(), maxErrorLength)
+		case isRejected:
+			deliveries[i].Status = DeliveryStatusFailure
+		default:
+			deliveries[i].Status = DeliveryStatusSuccess
+		}
+
+		if deliveries[i].Status == DeliveryStatusSuccess {
+			metricDeliveriesCounter.WithLabelValues(deliveryResultSuccess).Inc()
+		} else {
+			metricDeliveriesCounter.WithLabelValues(deliveryResultFailure).Inc()
+		}
+	}
+
+	if res.err != nil {
+		log.Warn().Err(res.err).Str("webhook_url", url).Int("status", res.status).Int("callbacks", len(callbacks)).Msg("deliver callbacks")
+	}
+
+	// rejected callbacks of the batch don't affect the health, the webhook is available
+	var opened bool
+	health, err = d.repo.UpdateHealth(url, func(h *EndpointHealth) {
+		if res.err == nil {
+			h.recordSuccess(now, res.status)
+		} else {
+			opened = h.recordFailure(now, res.status, res.err.Error(), d.opts.Breaker)
+		}
+	})
+	if err != nil {
+		// the callback has already been sent, so failures of saving outcomes are only logged
+		log.Error().Err(err).Str("webhook_url", url).Msg("update webhook health")
+	} else if opened {
+		d.disabled(ctx, health)
+	}
+
+	d.record(deliveries)
+
+	if res.err != nil {
+		return attempt{status: DeliveryStatusFailure, retryable: retryable(res.status)}, nil
+	}
+
+	return attempt{status: DeliveryStatusSuccess}, nil
+}
+
+// pausedUntil returns the end of the pause of the webhook or the zero time if the webhook could be requested.
+// After the cooldown the probe is claimed, so the webhook is requested by the only delivery until it's finished.
+func (d *Dispatcher) pausedUntil(health *EndpointHealth, now time.Time) (time.Time, error) {
+	if health.DisabledUntil == nil {
+		return time.Time{}, nil
+	}
+
+	if health.Disabled(now) {
+		return *health.DisabledUntil, nil
+	}
+
+	until := now.Add(probeTTL)
+	claimed, err := d.repo.ClaimProbe(health.URL, *health.DisabledUntil, until)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !claimed {
+		return until, nil
+	}
+
+	return time.Time{}, nil
+}
+
+// retryable returns false if the webhook rejects the request permanently
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+}
+
+// record saves attempts to the deliveries log, it's best-effort: the failure to record the attempt
+// must not fail the delivery and cause the callback to be sent again
+func (d *Dispatcher) record(deliveries []Delivery) {
+	for i := range deliveries {
+		if err := d.repo.CreateDelivery(&deliveries[i]); err != nil {
+			log.Error().
+				Err(err).
+				Str("webhook_url", deliveries[i].URL).
+				Str("feed_item_id", deliveries[i].FeedItemID.String()).
+				Msg("create delivery")
+		}
+	}
+}
+
+type postResult struct {
+	// status is zero if the request is failed
+	status   int
+	response string
+	err      error
+}
+
+func (d *Dispatcher) post(ctx context.Context, url string, body []byte) postResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return postResult{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return postResult{err: err}
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	res := postResult{
+		status:   resp.StatusCode,
+		response: string(bytes.TrimSpace(data)),
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		res.err = fmt.Errorf("unexpected status %d: %s", resp.StatusCode, res.response)
+	}
+
+	return res
+}
+
+func (d *Dispatcher) disabled(ctx context.Context, health *EndpointHealth) {
+	metricDisabledCounter.Inc()
+	log.Warn().
+		Str("webhook_url", health.URL).
+		Int("consecutive_failures", health.ConsecutiveFailures).
+		Time("disabled_until", *health.DisabledUntil).
+		Msg("webhook is paused by the circuit breaker")
+
+	if d.opts.DisabledSubject == "" {
+		return
+	}
+
+	err := d.events.PublishJSON(ctx, d.opts.DisabledSubject, DisabledPayload{
+		URL:                 health.URL,
+		ConsecutiveFailures: health.ConsecutiveFailures,
+		LastError:           health.LastError,
+		DisabledUntil:       *health.DisabledUntil,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("webhook_url", health.URL).Msg("publish webhook disabled event")
+	}
+}
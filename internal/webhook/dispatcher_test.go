@@ -0,0 +1,526 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/goverland-labs/goverland-platform-events/events/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+type recordingPublisher struct {
+	subjects []string
+}
+
+func (p *recordingPublisher) PublishJSON(_ context.Context, subject string, _ any) error {
+	p.subjects = append(p.subjects, subject)
+
+	return nil
+}
+
+func testCallback(url string, itemID uuid.UUID) Callback {
+	return Callback{
+		CallbackPayload: core.CallbackPayload{WebhookURL: url, Body: json.RawMessage(`{"id":"item"}`)},
+		FeedItemID:      itemID,
+		Action:          "proposal.created",
+	}
+}
+
+func TestUnitDispatcherCircuitBreaker(t *testing.T) {
+	var (
+		requests atomic.Int32
+		status   atomic.Int32
+	)
+	status.Store(http.StatusInternalServerError)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	itemID := uuid.New()
+	repo := NewMemoryRepo()
+	next := &recordingPublisher{}
+	now := time.Now()
+
+	d := NewDispatcher(next, srv.Client(), repo, DispatcherOptions{
+		Breaker:         BreakerOptions{Failures: 2, Cooldown: time.Minute},
+		DisabledSubject: "webhook.disabled",
+	})
+	d.now = func() time.Time { return now }
+
+	deliver := func() {
+		t.Helper()
+		require.NoError(t, d.Deliver(ctx, testCallback(srv.URL, itemID)))
+	}
+
+	health := func() *EndpointHealth {
+		t.Helper()
+
+		h, err := repo.GetHealth(srv.URL)
+		require.NoError(t, err)
+
+		return h
+	}
+
+	deliver()
+	require.False(t, health().Disabled(now))
+	require.Equal(t, http.StatusInternalServerError, health().LastStatus)
+
+	deliver()
+	require.True(t, health().Disabled(now))
+	require.Equal(t, 2, health().ConsecutiveFailures)
+	require.Equal(t, []string{"webhook.disabled"}, next.subjects)
+
+	// paused webhooks are not requested
+	deliver()
+	require.EqualValues(t, 2, requests.Load())
+
+	// the failed probe pauses the webhook again without the new event
+	now = now.Add(2 * time.Minute)
+	deliver()
+	require.EqualValues(t, 3, requests.Load())
+	require.True(t, health().Disabled(now))
+	require.Equal(t, []string{"webhook.disabled"}, next.subjects)
+
+	// the successful probe closes the breaker
+	now = now.Add(2 * time.Minute)
+	status.Store(http.StatusOK)
+	deliver()
+	require.EqualValues(t, 4, requests.Load())
+
+	h := health()
+	require.False(t, h.Disabled(now))
+	require.Nil(t, h.DisabledUntil)
+	require.Zero(t, h.ConsecutiveFailures)
+	require.EqualValues(t, 1, h.Successes)
+	require.EqualValues(t, 3, h.Failures)
+	require.InDelta(t, 0.25, h.SuccessRate(), 0.001)
+
+	// every attempt is recorded
+	deliveries, err := repo.GetDeliveries(DeliveryFilter{FeedItemID: itemID})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 5)
+
+	statuses := make(map[DeliveryStatus]int)
+	for _, delivery := range deliveries {
+		statuses[delivery.Status]++
+	}
+	require.Equal(t, map[DeliveryStatus]int{
+		DeliveryStatusSuccess: 1,
+		DeliveryStatusFailure: 3,
+		DeliveryStatusPaused:  1,
+	}, statuses)
+
+	// the newest delivery is the successful probe
+	require.Equal(t, http.StatusOK, deliveries[0].HTTPStatus)
+	require.Equal(t, "proposal.created", deliveries[0].Action)
+	require.Contains(t, deliveries[1].Response, "unexpected status 500")
+}
+
+func TestUnitDispatcherBatches(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+		status   atomic.Int32
+		response atomic.Value
+	)
+	status.Store(http.StatusOK)
+	response.Store("")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		requests = append(requests, string(body))
+		mu.Unlock()
+
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(response.Load().(string)))
+	}))
+	defer srv.Close()
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), requests...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := NewMemoryRepo()
+	d := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{})
+	subscriberID := uuid.New()
+	items := make(map[string]uuid.UUID)
+
+	publish := func(id string, wait time.Duration, redelivery bool) {
+		t.Helper()
+
+		items[id] = uuid.New()
+		require.NoError(t, d.Deliver(context.Background(), Callback{
+			CallbackPayload: core.CallbackPayload{WebhookURL: srv.URL, Body: json.RawMessage(`{"id":"` + id + `"}`)},
+			SubscriberID:    subscriberID,
+			FeedItemID:      items[id],
+			Redelivery:      redelivery,
+			BatchMaxSize:    3,
+			BatchMaxWait:    wait,
+		}))
+	}
+
+	deliveryStatus := func(id string) DeliveryStatus {
+		t.Helper()
+
+		list, err := repo.GetDeliveries(DeliveryFilter{FeedItemID: items[id]})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		return list[0].Status
+	}
+
+	// the full batch is sent immediately in the order of callbacks
+	publish("1", time.Hour, false)
+	publish("2", time.Hour, false)
+	require.Empty(t, received())
+	publish("3", time.Hour, false)
+	require.Equal(t, []string{`[{"id":"1"},{"id":"2"},{"id":"3"}]`}, received())
+
+	// redeliveries are not batched
+	publish("redelivered", time.Hour, true)
+	require.Equal(t, `{"id":"redelivered"}`, received()[1])
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, d.Start(ctx))
+	}()
+
+	// the batch is sent after the max wait, rejected callbacks are listed by the 207 response
+	status.Store(http.StatusMultiStatus)
+	response.Store(`{"failed":[1]}`)
+	publish("4", 200*time.Millisecond, false)
+	publish("5", 200*time.Millisecond, false)
+	require.Eventually(t, func() bool { return len(received()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, `[{"id":"4"},{"id":"5"}]`, received()[2])
+	require.Equal(t, DeliveryStatusSuccess, deliveryStatus("4"))
+	require.Equal(t, DeliveryStatusFailure, deliveryStatus("5"))
+
+	// any other unsuccessful status fails the whole batch
+	status.Store(http.StatusInternalServerError)
+	response.Store("")
+	publish("6", time.Hour, false)
+	publish("7", time.Hour, false)
+	publish("8", time.Hour, false)
+	require.Equal(t, DeliveryStatusFailure, deliveryStatus("6"))
+	require.Equal(t, DeliveryStatusFailure, deliveryStatus("8"))
+
+	h, err := repo.GetHealth(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, 1, h.ConsecutiveFailures)
+
+	// collected callbacks are kept on the shutdown and sent after the restart
+	status.Store(http.StatusOK)
+	publish("9", time.Hour, false)
+	cancel()
+	<-done
+	require.Len(t, received(), 4)
+
+	restarted := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{})
+	restarted.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	require.NoError(t, restarted.flush(context.Background()))
+	require.Equal(t, `[{"id":"9"}]`, received()[4])
+
+	// sent callbacks are removed
+	require.NoError(t, restarted.flush(context.Background()))
+	require.Len(t, received(), 5)
+}
+
+type failingDeliveriesRepo struct {
+	*MemoryRepo
+}
+
+func (failingDeliveriesRepo) CreateDelivery(_ *Delivery) error {
+	return errors.New("deliveries log is unavailable")
+}
+
+func TestUnitDispatcherRecordsDeliveriesBestEffort(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad\x00request\xff"))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	itemID := uuid.New()
+
+	// the failure to record the attempt doesn't fail the delivery
+	failing := failingDeliveriesRepo{MemoryRepo: NewMemoryRepo()}
+	d := NewDispatcher(&recordingPublisher{}, srv.Client(), failing, DispatcherOptions{})
+	require.NoError(t, d.Deliver(ctx, testCallback(srv.URL, itemID)))
+
+	h, err := failing.GetHealth(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, 1, h.ConsecutiveFailures)
+
+	// the response is stored as the valid text
+	repo := NewMemoryRepo()
+	d = NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{})
+	require.NoError(t, d.Deliver(ctx, testCallback(srv.URL, itemID)))
+
+	deliveries, err := repo.GetDeliveries(DeliveryFilter{FeedItemID: itemID})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "unexpected status 400: badrequest", deliveries[0].Response)
+}
+
+func TestUnitDispatcherRetries(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+		status   atomic.Int32
+	)
+	status.Store(http.StatusServiceUnavailable)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		requests = append(requests, string(body))
+		mu.Unlock()
+
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), requests...)
+	}
+
+	ctx := context.Background()
+	repo := NewMemoryRepo()
+	now := time.Now()
+	d := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{
+		Breaker: BreakerOptions{Failures: 3, Cooldown: time.Hour},
+		Retry:   RetryOptions{MaxAttempts: 3, Backoff: time.Minute},
+	})
+	d.now = func() time.Time { return now }
+
+	publish := func(id string) {
+		t.Helper()
+
+		require.NoError(t, d.Deliver(ctx, Callback{
+			CallbackPayload: core.CallbackPayload{WebhookURL: srv.URL, Body: json.RawMessage(`{"id":"` + id + `"}`)},
+			FeedItemID:      uuid.New(),
+		}))
+	}
+
+	flush := func(after time.Duration) {
+		t.Helper()
+
+		now = now.Add(after)
+		require.NoError(t, d.flush(ctx))
+	}
+
+	// the failed callback is retried after the backoff, the delay is doubled after every attempt
+	publish("1")
+	require.Len(t, received(), 1)
+
+	flush(30 * time.Second)
+	require.Len(t, received(), 1)
+
+	flush(30 * time.Second)
+	require.Equal(t, []string{`{"id":"1"}`, `{"id":"1"}`}, received())
+
+	flush(time.Minute)
+	require.Len(t, received(), 2)
+
+	// the last attempt pauses the webhook, the callback isn't retried anymore
+	flush(time.Minute)
+	require.Len(t, received(), 3)
+
+	h, err := repo.GetHealth(srv.URL)
+	require.NoError(t, err)
+	require.True(t, h.Disabled(now))
+
+	// callbacks to the paused webhook are parked until the pause ends
+	publish("2")
+	publish("3")
+	require.Len(t, received(), 3)
+
+	flush(30 * time.Minute)
+	require.Len(t, received(), 3)
+
+	// the probe resumes deliveries and parked callbacks are sent in order
+	status.Store(http.StatusOK)
+	flush(time.Hour)
+	require.Equal(t, []string{`{"id":"2"}`, `{"id":"3"}`}, received()[3:])
+
+	flush(time.Hour)
+	require.Len(t, received(), 5)
+
+	// permanent failures are not retried
+	status.Store(http.StatusBadRequest)
+	publish("4")
+	flush(time.Hour)
+	require.Len(t, received(), 6)
+}
+
+func TestUnitDispatcherProbesPausedWebhookOnce(t *testing.T) {
+	var (
+		requests atomic.Int32
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	repo := NewMemoryRepo()
+	now := time.Now()
+
+	until := now.Add(-time.Second)
+	_, err := repo.UpdateHealth(srv.URL, func(h *EndpointHealth) {
+		h.ConsecutiveFailures = 2
+		h.DisabledUntil = &until
+	})
+	require.NoError(t, err)
+
+	d := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{
+		Breaker: BreakerOptions{Failures: 2, Cooldown: time.Minute},
+	})
+	d.now = func() time.Time { return now }
+
+	probed := make(chan struct{})
+	go func() {
+		defer close(probed)
+		require.NoError(t, d.Deliver(ctx, testCallback(srv.URL, uuid.New())))
+	}()
+	<-started
+
+	// the concurrent delivery waits for the probe, its callback is parked
+	require.NoError(t, d.Deliver(ctx, testCallback(srv.URL, uuid.New())))
+	require.EqualValues(t, 1, requests.Load())
+
+	close(release)
+	<-probed
+
+	h, err := repo.GetHealth(srv.URL)
+	require.NoError(t, err)
+	require.Nil(t, h.DisabledUntil)
+
+	// the parked callback is sent after the probe claim expires
+	now = now.Add(probeTTL)
+	require.NoError(t, d.flush(ctx))
+	require.EqualValues(t, 2, requests.Load())
+}
+
+func TestUnitDispatcherSendsBatchesOfReplicasInOrder(t *testing.T) {
+	testDispatcherReplicas(t, NewMemoryRepo())
+}
+
+func TestIntegrationDispatcherSendsBatchesOfReplicasInOrder(t *testing.T) {
+	testDispatcherReplicas(t, NewRepo(testdb.Open(t, "webhook_health", "webhook_deliveries", "webhook_batch_callbacks")))
+}
+
+// testDispatcherReplicas checks batches of the webhook are sent one by one by replicas sharing the repository
+func testDispatcherReplicas(t *testing.T, repo DataProvider) {
+	var (
+		mu       sync.Mutex
+		requests []string
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		requests = append(requests, string(body))
+		first := len(requests) == 1
+		mu.Unlock()
+
+		if first {
+			close(started)
+			<-release
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	received := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), requests...)
+	}
+
+	ctx := context.Background()
+	subscriberID := uuid.New()
+
+	first := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{})
+	second := NewDispatcher(&recordingPublisher{}, srv.Client(), repo, DispatcherOptions{})
+
+	publish := func(d *Dispatcher, id string) {
+		t.Helper()
+
+		require.NoError(t, d.Deliver(ctx, Callback{
+			CallbackPayload: core.CallbackPayload{WebhookURL: srv.URL, Body: json.RawMessage(`{"id":"` + id + `"}`)},
+			SubscriberID:    subscriberID,
+			FeedItemID:      uuid.New(),
+			BatchMaxSize:    2,
+			BatchMaxWait:    time.Hour,
+		}))
+	}
+
+	publish(first, "1")
+
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		publish(first, "2")
+	}()
+	<-started
+
+	// the full batch of the second replica waits while the first one sends the earlier batch
+	publish(second, "3")
+	publish(second, "4")
+	require.Len(t, received(), 1)
+
+	close(release)
+	<-sent
+
+	second.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	require.NoError(t, second.flush(ctx))
+	require.Equal(t, []string{`[{"id":"1"},{"id":"2"}]`, `[{"id":"3"},{"id":"4"}]`}, received())
+}
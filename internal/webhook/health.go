@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxErrorLength limits stored errors, response bodies of failed deliveries could be large
+	maxErrorLength = 512
+	// probeTTL is the time the endpoint stays paused for other deliveries while it's probed after the cooldown,
+	// it exceeds the delivery timeout, so the pause ends by itself if the probing replica is stopped
+	probeTTL = time.Minute
+)
+
+// EndpointHealth is the outcome of deliveries to the webhook url.
+// The endpoint is paused by the circuit breaker until DisabledUntil.
+type EndpointHealth struct {
+	URL                 string `gorm:"primary_key"`
+	UpdatedAt           time.Time
+	Successes           int64
+	Failures            int64
+	ConsecutiveFailures int
+	LastStatus          int
+	LastError           string
+	LastSuccessAt       *time.Time
+	LastFailureAt       *time.Time
+	DisabledUntil       *time.Time
+}
+
+func (EndpointHealth) TableName() string {
+	return "webhook_health"
+}
+
+// SuccessRate returns the share of successful deliveries, it's 1 if nothing is delivered yet
+func (h *EndpointHealth) SuccessRate() float64 {
+	total := h.Successes + h.Failures
+	if total == 0 {
+		return 1
+	}
+
+	return float64(h.Successes) / float64(total)
+}
+
+// Disabled returns true if deliveries are paused at the moment
+func (h *EndpointHealth) Disabled(now time.Time) bool {
+	return h.DisabledUntil != nil && now.Before(*h.DisabledUntil)
+}
+
+// BreakerOptions pause the endpoint for the cooldown after Failures consecutive failures.
+// After the cooldown the only delivery claiming the probe requests the endpoint: the success closes the breaker,
+// the failure pauses the endpoint again. Zero Failures disables the breaker.
+type BreakerOptions struct {
+	Failures int
+	Cooldown time.Duration
+}
+
+// recordSuccess closes the breaker
+func (h *EndpointHealth) recordSuccess(now time.Time, status int) {
+	h.Successes++
+	h.ConsecutiveFailures = 0
+	h.LastStatus = status
+	h.LastSuccessAt = &now
+	h.DisabledUntil = nil
+}
+
+// recordFailure returns true if the endpoint is paused by this failure while it was not paused before
+func (h *EndpointHealth) recordFailure(now time.Time, status int, reason string, opts BreakerOptions) bool {
+	h.Failures++
+	h.ConsecutiveFailures++
+	h.LastStatus = status
+	h.LastError = truncate(reason, maxErrorLength)
+	h.LastFailureAt = &now
+
+	if opts.Failures <= 0 || h.ConsecutiveFailures < opts.Failures {
+		return false
+	}
+
+	// the probe after the cooldown is failed, the endpoint stays paused
+	reopened := h.DisabledUntil != nil
+
+	until := now.Add(opts.Cooldown)
+	h.DisabledUntil = &until
+
+	return !reopened
+}
+
+// truncate limits the length of the string in bytes without splitting runes, invalid UTF-8 sequences
+// and NUL characters are removed because they can't be stored in text columns
+func truncate(s string, limit int) string {
+	s = strings.ReplaceAll(strings.ToValidUTF8(s, ""), "\x00", "")
+	if len(s) <= limit {
+		return s
+	}
+
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+
+	return s[:limit]
+}
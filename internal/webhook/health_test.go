@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		in    string
+		limit int
+		want  string
+	}{
+		{name: "short", in: "error", limit: 10, want: "error"},
+		{name: "long", in: "unexpected status", limit: 10, want: "unexpected"},
+		{name: "rune boundary", in: "ошибка", limit: 5, want: "ош"},
+		{name: "invalid utf8", in: "bad\xff\xfebody", limit: 10, want: "badbody"},
+		{name: "nul", in: "nul\x00body", limit: 10, want: "nulbody"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := truncate(tc.in, tc.limit)
+			require.Equal(t, tc.want, got)
+			require.True(t, utf8.ValidString(got))
+			require.LessOrEqual(t, len(got), tc.limit)
+		})
+	}
+}
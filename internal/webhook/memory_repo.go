@@ -0,0 +1,290 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemoryRepo keeps webhooks health in memory, it's used for running the application and tests without the database
+type MemoryRepo struct {
+	mu     sync.RWMutex
+	health map[string]EndpointHealth
+
+	deliveries []Delivery
+
+	batchCallbacks []BatchCallback
+	lastBatchID    uint64
+}
+
+func NewMemoryRepo() *MemoryRepo {
+	return &MemoryRepo{
+		health: make(map[string]EndpointHealth),
+	}
+}
+
+func (r *MemoryRepo) GetHealth(url string) (*EndpointHealth, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	h, ok := r.health[url]
+	if !ok {
+		return nil, fmt.Errorf("get health of %s: %w", url, gorm.ErrRecordNotFound)
+	}
+
+	return &h, nil
+}
+
+func (r *MemoryRepo) UpdateHealth(url string, fn func(h *EndpointHealth)) (*EndpointHealth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.health[url]
+	if !ok {
+		h = EndpointHealth{URL: url}
+	}
+
+	fn(&h)
+	h.UpdatedAt = time.Now()
+	r.health[url] = h
+
+	return &h, nil
+}
+
+func (r *MemoryRepo) ClaimProbe(url string, disabledUntil, until time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h, ok := r.health[url]
+	if !ok || h.DisabledUntil == nil || !h.DisabledUntil.Equal(disabledUntil) {
+		return false, nil
+	}
+
+	h.DisabledUntil = &until
+	r.health[url] = h
+
+	return true, nil
+}
+
+func (r *MemoryRepo) GetHealthByURLs(urls []string) ([]EndpointHealth, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]EndpointHealth, 0, len(urls))
+	for _, url := range urls {
+		if h, ok := r.health[url]; ok {
+			list = append(list, h)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].URL < list[j].URL
+	})
+
+	return list, nil
+}
+
+func (r *MemoryRepo) CreateDelivery(d *Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+
+	r.deliveries = append(r.deliveries, *d)
+
+	return nil
+}
+
+func (r *MemoryRepo) GetDeliveries(filter DeliveryFilter) ([]Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []Delivery
+	for i := range r.deliveries {
+		if filter.matches(&r.deliveries[i]) {
+			list = append(list, r.deliveries[i])
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+
+		return bytes.Compare(list[i].ID[:], list[j].ID[:]) < 0
+	})
+
+	if filter.Offset >= len(list) {
+		return nil, nil
+	}
+	list = list[filter.Offset:]
+
+	if filter.Limit > 0 && len(list) > filter.Limit {
+		list = list[:filter.Limit]
+	}
+
+	return list, nil
+}
+
+// WithRetentionLock runs fn, there is the only retention worker per memory repository
+func (r *MemoryRepo) WithRetentionLock(fn func() error) (bool, error) {
+	return true, fn()
+}
+
+func (r *MemoryRepo) DeleteDeliveriesBefore(before time.Time, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	kept := r.deliveries[:0]
+	for _, d := range r.deliveries {
+		if d.CreatedAt.Before(before) && deleted < int64(limit) {
+			deleted++
+			continue
+		}
+
+		kept = append(kept, d)
+	}
+	r.deliveries = kept
+
+	return deleted, nil
+}
+
+func (r *MemoryRepo) AddBatchCallback(cb *BatchCallback) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastBatchID++
+	cb.ID = r.lastBatchID
+	r.batchCallbacks = append(r.batchCallbacks, *cb)
+
+	return nil
+}
+
+func (r *MemoryRepo) CountBatchCallbacks(key BatchKey, now time.Time) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for i := range r.batchCallbacks {
+		if r.batchCallbacks[i].Key() == key && !r.batchCallbacks[i].claimed(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *MemoryRepo) GetDueBatches(now time.Time) ([]BatchKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	postponed := r.postponedBatches(now)
+
+	var keys []BatchKey
+	due := make(map[BatchKey]struct{})
+	for i := range r.batchCallbacks {
+		cb := &r.batchCallbacks[i]
+		if _, ok := postponed[cb.Key()]; ok || cb.claimed(now) || cb.Deadline.After(now) {
+			continue
+		}
+
+		if _, ok := due[cb.Key()]; !ok {
+			due[cb.Key()] = struct{}{}
+			keys = append(keys, cb.Key())
+		}
+	}
+
+	return keys, nil
+}
+
+func (r *MemoryRepo) ClaimBatch(key BatchKey, now, until time.Time) ([]BatchCallback, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.batchCallbacks {
+		cb := &r.batchCallbacks[i]
+		if cb.Key() == key && (cb.claimed(now) || cb.postponed(now)) {
+			return nil, nil
+		}
+	}
+
+	var list []BatchCallback
+	for i := range r.batchCallbacks {
+		cb := &r.batchCallbacks[i]
+		if cb.Key() != key {
+			continue
+		}
+
+		claimedUntil := until
+		cb.ClaimedUntil = &claimedUntil
+		list = append(list, *cb)
+	}
+
+	return list, nil
+}
+
+// postponedBatches returns batches waiting for the retry
+func (r *MemoryRepo) postponedBatches(now time.Time) map[BatchKey]struct{} {
+	postponed := make(map[BatchKey]struct{})
+	for i := range r.batchCallbacks {
+		if r.batchCallbacks[i].postponed(now) {
+			postponed[r.batchCallbacks[i].Key()] = struct{}{}
+		}
+	}
+
+	return postponed
+}
+
+func (r *MemoryRepo) RetryBatch(key BatchKey, until time.Time, failed []uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempted := make(map[uint64]struct{}, len(failed))
+	for _, id := range failed {
+		attempted[id] = struct{}{}
+	}
+
+	for i := range r.batchCallbacks {
+		cb := &r.batchCallbacks[i]
+		if cb.Key() != key {
+			continue
+		}
+
+		if _, ok := attempted[cb.ID]; ok {
+			cb.Attempts++
+		}
+
+		retryAt := until
+		cb.RetryAt = &retryAt
+		cb.ClaimedUntil = nil
+	}
+
+	return nil
+}
+
+func (r *MemoryRepo) DeleteBatchCallbacks(ids []uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := make(map[uint64]struct{}, len(ids))
+	for _, id := range ids {
+		deleted[id] = struct{}{}
+	}
+
+	kept := r.batchCallbacks[:0]
+	for _, cb := range r.batchCallbacks {
+		if _, ok := deleted[cb.ID]; !ok {
+			kept = append(kept, cb)
+		}
+	}
+	r.batchCallbacks = kept
+
+	return nil
+}
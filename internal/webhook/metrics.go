@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+const (
+	deliveryResultSuccess = "success"
+	deliveryResultFailure = "failure"
+	deliveryResultPaused  = "paused"
+)
+
+var metricDeliveriesCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "Count of callbacks delivered to webhooks by the result: success, failure or paused",
+	}, []string{"result"},
+)
+
+var metricDeliveryHistogram = promauto.NewHistogram(
+	prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "webhook",
+		Name:      "delivery_duration_seconds",
+		Help:      "Duration of callback requests to webhooks",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	},
+)
+
+var metricDisabledCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "webhook",
+		Name:      "endpoints_disabled_total",
+		Help:      "Count of webhooks paused by the circuit breaker after consecutive failures",
+	},
+)
+
+var metricDroppedCounter = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "webhook",
+		Name:      "dropped_callbacks_total",
+		Help:      "Count of failed callbacks which are not retried anymore",
+	},
+)
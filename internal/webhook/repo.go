@@ -0,0 +1,287 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/pglock"
+)
+
+type Repo struct {
+	db *gorm.DB
+}
+
+func NewRepo(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+func (r *Repo) GetHealth(url string) (*EndpointHealth, error) {
+	var h EndpointHealth
+
+	err := r.db.
+		Where(EndpointHealth{URL: url}).
+		First(&h).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get health of %s: %w", url, err)
+	}
+
+	return &h, nil
+}
+
+// UpdateHealth applies fn to the health of the url holding the row lock, so outcomes of concurrent deliveries
+// are not lost. The health is created if the url has not been delivered yet.
+func (r *Repo) UpdateHealth(url string, fn func(h *EndpointHealth)) (*EndpointHealth, error) {
+	var h EndpointHealth
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&EndpointHealth{URL: url}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(EndpointHealth{URL: url}).
+			First(&h).
+			Error
+		if err != nil {
+			return err
+		}
+
+		fn(&h)
+
+		return tx.Save(&h).Error
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("update health of %s: %w", url, err)
+	}
+
+	return &h, nil
+}
+
+// ClaimProbe extends the expired pause of the url until the time, it returns false if the pause
+// has been changed meanwhile, so the endpoint is probed only by one delivery
+func (r *Repo) ClaimProbe(url string, disabledUntil, until time.Time) (bool, error) {
+	res := r.db.
+		Model(&EndpointHealth{}).
+		Where("url = ? and disabled_until = ?", url, disabledUntil).
+		Update("disabled_until", until)
+
+	if res.Error != nil {
+		return false, fmt.Errorf("claim probe of %s: %w", url, res.Error)
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (r *Repo) GetHealthByURLs(urls []string) ([]EndpointHealth, error) {
+	var list []EndpointHealth
+	if len(urls) == 0 {
+		return list, nil
+	}
+
+	err := r.db.
+		Where("url in ?", urls).
+		Order("url").
+		Find(&list).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get health by urls: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *Repo) CreateDelivery(d *Delivery) error {
+	return r.db.Create(d).Error
+}
+
+func (r *Repo) GetDeliveries(filter DeliveryFilter) ([]Delivery, error) {
+	query := r.db.Model(&Delivery{})
+	if filter.SubscriberID != uuid.Nil {
+		query = query.Where("subscriber_id = ?", filter.SubscriberID)
+	}
+	if filter.DaoID != uuid.Nil {
+		query = query.Where("dao_id = ?", filter.DaoID)
+	}
+	if filter.FeedItemID != uuid.Nil {
+		query = query.Where("feed_item_id = ?", filter.FeedItemID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var list []Delivery
+	err := query.
+		Order("created_at desc, id").
+		Offset(filter.Offset).
+		Find(&list).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+
+	return list, nil
+}
+
+// WithRetentionLock runs fn holding the deliveries retention lock, it returns false without calling fn
+// if the retention is run by another replica
+func (r *Repo) WithRetentionLock(fn func() error) (bool, error) {
+	return pglock.WithSessionLock(context.Background(), r.db, pglock.DeliveriesRetentionKey, fn)
+}
+
+func (r *Repo) DeleteDeliveriesBefore(before time.Time, limit int) (int64, error) {
+	ids := r.db.
+		Model(&Delivery{}).
+		Select("id").
+		Where("created_at < ?", before).
+		Limit(limit)
+
+	res := r.db.
+		Where("id in (?)", ids).
+		Delete(&Delivery{})
+
+	return res.RowsAffected, res.Error
+}
+
+func (r *Repo) AddBatchCallback(cb *BatchCallback) error {
+	return r.db.Create(cb).Error
+}
+
+func (r *Repo) CountBatchCallbacks(key BatchKey, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.
+		Model(&BatchCallback{}).
+		Where("subscriber_id = ? and url = ?", key.SubscriberID, key.URL).
+		Where("(claimed_until is null or claimed_until <= ?)", now).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return 0, fmt.Errorf("count batch callbacks: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *Repo) GetDueBatches(now time.Time) ([]BatchKey, error) {
+	var keys []BatchKey
+	err := r.db.
+		Model(&BatchCallback{}).
+		Select("subscriber_id, url").
+		Where("(claimed_until is null or claimed_until <= ?)", now).
+		Group("subscriber_id, url").
+		Having("min(deadline) <= ? and coalesce(max(retry_at), ?) <= ?", now, now, now).
+		Scan(&keys).
+		Error
+
+	if err != nil {
+		return nil, fmt.Errorf("get due batches: %w", err)
+	}
+
+	return keys, nil
+}
+
+// ClaimBatch claims callbacks of the batch only if it isn't sent by any replica: claims of the batch are serialized
+// by the transaction lock and nothing is claimed while claimed callbacks of the batch are not expired,
+// so batches of the webhook are sent one by one across replicas
+func (r *Repo) ClaimBatch(key BatchKey, now, until time.Time) ([]BatchCallback, error) {
+	var list []BatchCallback
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		locked, err := pglock.TryXactLock(tx, pglock.BatchesClass, key.SubscriberID.String()+" "+key.URL)
+		if err != nil || !locked {
+			return err
+		}
+
+		var busy int64
+		err = tx.
+			Model(&BatchCallback{}).
+			Where("subscriber_id = ? and url = ?", key.SubscriberID, key.URL).
+			Where("claimed_until > ? or retry_at > ?", now, now).
+			Count(&busy).
+			Error
+		if err != nil || busy > 0 {
+			return err
+		}
+
+		return tx.
+			Model(&list).
+			Clauses(clause.Returning{}).
+			Where("subscriber_id = ? and url = ?", key.SubscriberID, key.URL).
+			Update("claimed_until", until).
+			Error
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("claim batch: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return list, nil
+}
+
+func (r *Repo) RetryBatch(key BatchKey, until time.Time, failed []uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(failed) > 0 {
+			err := tx.
+				Model(&BatchCallback{}).
+				Where("id in ?", failed).
+				Update("attempts", gorm.Expr("attempts + 1")).
+				Error
+			if err != nil {
+				return fmt.Errorf("count attempts: %w", err)
+			}
+		}
+
+		err := tx.
+			Model(&BatchCallback{}).
+			Where("subscriber_id = ? and url = ?", key.SubscriberID, key.URL).
+			Updates(map[string]any{
+				"retry_at":      until,
+				"claimed_until": nil,
+			}).
+			Error
+		if err != nil {
+			return fmt.Errorf("postpone batch: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repo) DeleteBatchCallbacks(ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.
+		Where("id in ?", ids).
+		Delete(&BatchCallback{}).
+		Error
+}
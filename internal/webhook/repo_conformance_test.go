@@ -0,0 +1,318 @@
+package webhook
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/testdb"
+)
+
+func TestUnitMemoryRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(_ *testing.T) DataProvider {
+		return NewMemoryRepo()
+	})
+}
+
+func TestIntegrationRepoConformance(t *testing.T) {
+	testRepoConformance(t, func(t *testing.T) DataProvider {
+		return NewRepo(testdb.Open(t, "webhook_health", "webhook_deliveries", "webhook_batch_callbacks"))
+	})
+}
+
+// testRepoConformance checks the behaviour every webhooks health and deliveries repository has to follow
+func testRepoConformance(t *testing.T, factory func(t *testing.T) DataProvider) {
+	t.Run("update and get", func(t *testing.T) {
+		repo := factory(t)
+
+		_, err := repo.GetHealth("https://example.com/hook")
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		until := time.Now().Add(time.Minute).UTC().Truncate(time.Microsecond)
+		updated, err := repo.UpdateHealth("https://example.com/hook", func(h *EndpointHealth) {
+			h.Failures = 3
+			h.ConsecutiveFailures = 3
+			h.LastStatus = 500
+			h.DisabledUntil = &until
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, updated.Failures)
+
+		_, err = repo.UpdateHealth("https://example.com/hook", func(h *EndpointHealth) {
+			h.Successes++
+		})
+		require.NoError(t, err)
+
+		stored, err := repo.GetHealth("https://example.com/hook")
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stored.Successes)
+		require.EqualValues(t, 3, stored.Failures)
+		require.Equal(t, 500, stored.LastStatus)
+		require.True(t, until.Equal(*stored.DisabledUntil))
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		repo := factory(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := repo.UpdateHealth("https://example.com/hook", func(h *EndpointHealth) {
+					h.Failures++
+				})
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		stored, err := repo.GetHealth("https://example.com/hook")
+		require.NoError(t, err)
+		require.EqualValues(t, 10, stored.Failures)
+	})
+
+	t.Run("claim probe", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			now   = time.Now().UTC().Truncate(time.Microsecond)
+			until = now.Add(-time.Minute)
+		)
+
+		claimed, err := repo.ClaimProbe("https://example.com/hook", until, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		_, err = repo.UpdateHealth("https://example.com/hook", func(h *EndpointHealth) {
+			h.DisabledUntil = &until
+		})
+		require.NoError(t, err)
+
+		// the expired pause is extended only by one of deliveries
+		claimed, err = repo.ClaimProbe("https://example.com/hook", until, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = repo.ClaimProbe("https://example.com/hook", until, now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		stored, err := repo.GetHealth("https://example.com/hook")
+		require.NoError(t, err)
+		require.True(t, now.Add(time.Minute).Equal(*stored.DisabledUntil))
+	})
+
+	t.Run("get by urls", func(t *testing.T) {
+		repo := factory(t)
+
+		for _, url := range []string{"https://b.com", "https://a.com", "https://c.com"} {
+			_, err := repo.UpdateHealth(url, func(_ *EndpointHealth) {})
+			require.NoError(t, err)
+		}
+
+		list, err := repo.GetHealthByURLs([]string{"https://b.com", "https://a.com", "https://unknown.com"})
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		require.Equal(t, "https://a.com", list[0].URL)
+		require.Equal(t, "https://b.com", list[1].URL)
+
+		list, err = repo.GetHealthByURLs(nil)
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+
+	t.Run("deliveries", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			subscriberID = uuid.New()
+			daoID        = uuid.New()
+			now          = time.Now().UTC().Truncate(time.Microsecond)
+		)
+
+		for i, status := range []DeliveryStatus{DeliveryStatusSuccess, DeliveryStatusFailure, DeliveryStatusSuccess} {
+			require.NoError(t, repo.CreateDelivery(&Delivery{
+				ID:           uuid.New(),
+				CreatedAt:    now.Add(-time.Duration(i) * time.Hour),
+				SubscriberID: subscriberID,
+				DaoID:        daoID,
+				FeedItemID:   uuid.New(),
+				Action:       "proposal.created",
+				URL:          "https://example.com/hook",
+				Status:       status,
+				HTTPStatus:   200,
+				LatencyMs:    int64(i),
+			}))
+		}
+		require.NoError(t, repo.CreateDelivery(&Delivery{ID: uuid.New(), CreatedAt: now, SubscriberID: uuid.New(), Status: DeliveryStatusPaused}))
+
+		list, err := repo.GetDeliveries(DeliveryFilter{SubscriberID: subscriberID})
+		require.NoError(t, err)
+		require.Len(t, list, 3)
+		require.EqualValues(t, 0, list[0].LatencyMs)
+		require.EqualValues(t, 2, list[2].LatencyMs)
+		require.Equal(t, daoID, list[0].DaoID)
+		require.True(t, now.Equal(list[0].CreatedAt))
+
+		list, err = repo.GetDeliveries(DeliveryFilter{DaoID: daoID, Status: DeliveryStatusSuccess, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.EqualValues(t, 2, list[0].LatencyMs)
+
+		list, err = repo.GetDeliveries(DeliveryFilter{From: now.Add(-90 * time.Minute), To: now})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, DeliveryStatusFailure, list[0].Status)
+
+		deleted, err := repo.DeleteDeliveriesBefore(now.Add(-30*time.Minute), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		deleted, err = repo.DeleteDeliveriesBefore(now.Add(-30*time.Minute), 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		list, err = repo.GetDeliveries(DeliveryFilter{})
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+	})
+
+	t.Run("batch callbacks", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			now   = time.Now().UTC().Truncate(time.Microsecond)
+			key   = BatchKey{SubscriberID: uuid.New(), URL: "https://example.com/hook"}
+			other = BatchKey{SubscriberID: uuid.New(), URL: "https://example.com/hook"}
+		)
+
+		add := func(key BatchKey, id string, deadline time.Time) {
+			t.Helper()
+
+			require.NoError(t, repo.AddBatchCallback(&BatchCallback{
+				CreatedAt:    now,
+				SubscriberID: key.SubscriberID,
+				URL:          key.URL,
+				Deadline:     deadline,
+				MaxSize:      10,
+				Payload:      json.RawMessage(`{"id":"` + id + `"}`),
+			}))
+		}
+
+		add(key, "1", now.Add(time.Minute))
+		add(other, "2", now.Add(time.Hour))
+		add(key, "3", now.Add(time.Hour))
+
+		count, err := repo.CountBatchCallbacks(key, now)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+
+		// the batch is due when its oldest callback waits for the deadline
+		keys, err := repo.GetDueBatches(now)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		keys, err = repo.GetDueBatches(now.Add(2 * time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, []BatchKey{key}, keys)
+
+		claimed, err := repo.ClaimBatch(key, now, now.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, claimed, 2)
+		require.JSONEq(t, `{"id":"1"}`, string(claimed[0].Payload))
+		require.JSONEq(t, `{"id":"3"}`, string(claimed[1].Payload))
+		require.Equal(t, 10, claimed[0].MaxSize)
+
+		// claimed callbacks are not claimed again until the claim expires
+		count, err = repo.CountBatchCallbacks(key, now)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		keys, err = repo.GetDueBatches(now.Add(2 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []BatchKey{other}, keys)
+
+		again, err := repo.ClaimBatch(key, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, again)
+
+		// callbacks added while the batch is sent are not claimed by other replicas until it's finished
+		add(key, "4", now)
+		again, err = repo.ClaimBatch(key, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, again)
+
+		again, err = repo.ClaimBatch(key, now.Add(4*time.Hour), now.Add(5*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, again, 3)
+
+		require.NoError(t, repo.DeleteBatchCallbacks([]uint64{claimed[0].ID, claimed[1].ID, again[2].ID}))
+
+		keys, err = repo.GetDueBatches(now.Add(6 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []BatchKey{other}, keys)
+
+		count, err = repo.CountBatchCallbacks(key, now.Add(6*time.Hour))
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+
+	t.Run("retry batch", func(t *testing.T) {
+		repo := factory(t)
+
+		var (
+			now = time.Now().UTC().Truncate(time.Microsecond)
+			key = BatchKey{SubscriberID: uuid.New(), URL: "https://example.com/hook"}
+		)
+
+		add := func() {
+			t.Helper()
+
+			require.NoError(t, repo.AddBatchCallback(&BatchCallback{
+				CreatedAt:    now,
+				SubscriberID: key.SubscriberID,
+				URL:          key.URL,
+				Deadline:     now,
+				MaxSize:      1,
+				Payload:      json.RawMessage(`{}`),
+			}))
+		}
+
+		add()
+		add()
+
+		claimed, err := repo.ClaimBatch(key, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, claimed, 2)
+
+		// the whole batch is postponed including callbacks added after the failure
+		require.NoError(t, repo.RetryBatch(key, now.Add(time.Hour), []uint64{claimed[0].ID}))
+		add()
+
+		keys, err := repo.GetDueBatches(now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		again, err := repo.ClaimBatch(key, now.Add(time.Minute), now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, again)
+
+		keys, err = repo.GetDueBatches(now.Add(2 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []BatchKey{key}, keys)
+
+		again, err = repo.ClaimBatch(key, now.Add(2*time.Hour), now.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, again, 3)
+		require.Equal(t, 1, again[0].Attempts)
+		require.Zero(t, again[1].Attempts)
+		require.True(t, now.Add(time.Hour).Equal(*again[0].RetryAt))
+	})
+}
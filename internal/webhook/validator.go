@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL = errors.New("invalid webhook url")
+	// ErrUnresolved is returned if the hostname can't be resolved at the moment, the registration could be retried
+	ErrUnresolved = errors.New("webhook host is not resolved")
+)
+
+// blockedNetworks are special purpose ranges which are not covered by net.IP checks
+var blockedNetworks = mustParseNetworks(
+	"0.0.0.0/8",     // this network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reserved and broadcast
+	"64:ff9b::/96",  // NAT64 could reach private IPv4 addresses
+)
+
+// Options of webhook urls validation
+type Options struct {
+	RequireHTTPS bool
+	// AllowPrivate disables checks of resolved addresses, it's meant for local environments only
+	AllowPrivate bool
+}
+
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Validator protects from webhooks targeting internal services: hostnames are resolved
+// and the url is rejected if any of addresses is loopback, private, link-local or reserved.
+type Validator struct {
+	opts     Options
+	resolver Resolver
+}
+
+func NewValidator(opts Options) *Validator {
+	return &Validator{
+		opts:     opts,
+		resolver: net.DefaultResolver,
+	}
+}
+
+// Validate checks the url at registration. Delivered callbacks are checked by the client from HTTPClient
+// when addresses are dialed or by ValidateAddress, so the scheme requirements apply only to registered urls.
+func (v *Validator) Validate(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.IsAbs() || u.Hostname() == "" {
+		return fmt.Errorf("%w: absolute url is required", ErrInvalidURL)
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if v.opts.RequireHTTPS {
+			return fmt.Errorf("%w: https is required", ErrInvalidURL)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported scheme %s", ErrInvalidURL, u.Scheme)
+	}
+
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in url are not allowed", ErrInvalidURL)
+	}
+
+	return v.checkHost(ctx, u.Hostname())
+}
+
+// ValidateAddress checks only addresses of the registered url. It's used before callbacks are published
+// for the delivery by another service, the hostname could be resolved to another address after the registration.
+func (v *Validator) ValidateAddress(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("%w: absolute url is required", ErrInvalidURL)
+	}
+
+	return v.checkHost(ctx, u.Hostname())
+}
+
+func (v *Validator) checkHost(ctx context.Context, host string) error {
+	if v.opts.AllowPrivate {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return v.CheckIP(ip)
+	}
+
+	addrs, err := v.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrUnresolved, host, err)
+	}
+
+	if len(addrs) == 0 {
+		return fmt.Errorf("%w: %s has no addresses", ErrUnresolved, host)
+	}
+
+	for _, addr := range addrs {
+		if err := v.CheckIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckIP returns an error if the address is not public
+func (v *Validator) CheckIP(ip net.IP) error {
+	if v.opts.AllowPrivate {
+		return nil
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: address %s is not public", ErrInvalidURL, ip)
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("%w: address %s is reserved", ErrInvalidURL, ip)
+		}
+	}
+
+	return nil
+}
+
+// HTTPClient returns the client which checks every dialed address, so the hostname
+// can't be rebound to the internal address after the validation. Redirects are not followed.
+func (v *Validator) HTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil {
+				return fmt.Errorf("%w: dial %s", ErrInvalidURL, address)
+			}
+
+			return v.CheckIP(ip)
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func mustParseNetworks(cidrs ...string) []*net.IPNet {
+	res := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		res = append(res, network)
+	}
+
+	return res
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	verificationType = "url_verification"
+
+	// responses are small, the limit protects from endpoints streaming huge bodies
+	maxResponseSize = 4 << 10
+)
+
+var ErrNotVerified = errors.New("webhook is not verified")
+
+type challengeRequest struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+type challengeResponse struct {
+	Challenge string `json:"challenge"`
+}
+
+// Verifier proves the ownership of the endpoint before callbacks are enabled:
+// the endpoint receives the random challenge and has to echo it in the response
+// as the JSON object {"challenge": "..."} or the plain text body.
+type Verifier struct {
+	client *http.Client
+}
+
+func NewVerifier(client *http.Client) *Verifier {
+	return &Verifier{client: client}
+}
+
+func (v *Verifier) Verify(ctx context.Context, url string) error {
+	challenge, err := newChallenge()
+	if err != nil {
+		return fmt.Errorf("generate challenge: %w", err)
+	}
+
+	body, err := json.Marshal(challengeRequest{Type: verificationType, Challenge: challenge})
+	if err != nil {
+		return fmt.Errorf("marshal challenge: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotVerified, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotVerified, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: unexpected status %d", ErrNotVerified, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("%w: read response: %w", ErrNotVerified, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(echoedChallenge(data)), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrNotVerified)
+	}
+
+	return nil
+}
+
+func echoedChallenge(data []byte) string {
+	var resp challengeResponse
+	if err := json.Unmarshal(data, &resp); err == nil {
+		return resp.Challenge
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func newChallenge() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// Checker validates urls of registered webhooks and verifies them if the verifier is set
+type Checker struct {
+	validator *Validator
+	verifier  *Verifier
+}
+
+func NewChecker(validator *Validator, verifier *Verifier) *Checker {
+	return &Checker{
+		validator: validator,
+		verifier:  verifier,
+	}
+}
+
+func (c *Checker) Check(ctx context.Context, url string) error {
+	if err := c.validator.Validate(ctx, url); err != nil {
+		return err
+	}
+
+	if c.verifier == nil {
+		return nil
+	}
+
+	return c.verifier.Verify(ctx, url)
+}
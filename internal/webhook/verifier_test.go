@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitVerifierVerify(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		handler  func(w http.ResponseWriter, req challengeRequest)
+		verified bool
+	}{
+		{
+			name: "json echo",
+			handler: func(w http.ResponseWriter, req challengeRequest) {
+				_ = json.NewEncoder(w).Encode(challengeResponse{Challenge: req.Challenge})
+			},
+			verified: true,
+		},
+		{
+			name: "plain text echo",
+			handler: func(w http.ResponseWriter, req challengeRequest) {
+				_, _ = w.Write([]byte(req.Challenge + "\n"))
+			},
+			verified: true,
+		},
+		{
+			name: "wrong challenge",
+			handler: func(w http.ResponseWriter, _ challengeRequest) {
+				_ = json.NewEncoder(w).Encode(challengeResponse{Challenge: "other"})
+			},
+		},
+		{
+			name: "ignored challenge",
+			handler: func(w http.ResponseWriter, _ challengeRequest) {
+				w.WriteHeader(http.StatusOK)
+			},
+		},
+		{
+			name: "error status",
+			handler: func(w http.ResponseWriter, req challengeRequest) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(req.Challenge))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req challengeRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Equal(t, verificationType, req.Type)
+				require.NotEmpty(t, req.Challenge)
+
+				tc.handler(w, req)
+			}))
+			defer srv.Close()
+
+			err := NewVerifier(&http.Client{Timeout: time.Second}).Verify(context.Background(), srv.URL)
+			if tc.verified {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrNotVerified)
+		})
+	}
+}
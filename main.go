@@ -1,8 +1,11 @@
 package main
 
 import (
+	"os"
+
 	"github.com/caarlos0/env/v6"
 	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/s-larionov/process-manager"
 	"github.com/shopspring/decimal"
 
@@ -33,6 +36,29 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case replayCommand:
+			if err := runReplay(os.Args[2:]); err != nil {
+				log.Fatal().Err(err).Msg("replay")
+			}
+
+			return
+		case migrateCommand:
+			if err := runMigrate(os.Args[2:]); err != nil {
+				log.Fatal().Err(err).Msg("migrate")
+			}
+
+			return
+		case apiKeyCommand:
+			if err := runAPIKey(os.Args[2:]); err != nil {
+				log.Fatal().Err(err).Msg("api key")
+			}
+
+			return
+		}
+	}
+
 	app, err := internal.NewApplication(cfg)
 	if err != nil {
 		panic(err)
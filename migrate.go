@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goverland-labs/goverland-core-feed/internal"
+)
+
+const migrateCommand = "migrate"
+
+func runMigrate(args []string) error {
+	var opts internal.MigrateOptions
+
+	fs := flag.NewFlagSet(migrateCommand, flag.ContinueOnError)
+	fs.IntVar(&opts.Steps, "steps", 1, "number of migrations to revert by down command")
+	fs.StringVar(&opts.Version, "version", "", "last version to mark as applied by baseline command")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags] up|down|status|baseline\n", migrateCommand)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("migrate command is required")
+	}
+
+	opts.Command = fs.Arg(0)
+	if opts.Command == internal.MigrateBaseline && opts.Version == "" {
+		return fmt.Errorf("version is required for the baseline command")
+	}
+
+	return internal.Migrate(cfg, opts, os.Stdout)
+}
@@ -0,0 +1,55 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+const apiKeyKey = "x-api-key"
+
+var errWrongAPIKey = status.Error(codes.Unauthenticated, "wrong api key")
+
+type APIKeyChecker interface {
+	Authenticate(ctx context.Context, key string) (*subscriber.APIKey, error)
+}
+
+// APIKeyAuthenticator identifies the subscriber by the API key passed in the x-api-key metadata
+type APIKeyAuthenticator struct {
+	keys APIKeyChecker
+}
+
+func NewAPIKeyAuthenticator(keys APIKeyChecker) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		keys: keys,
+	}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	key := metautils.ExtractIncoming(ctx).Get(apiKeyKey)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	item, err := a.keys.Authenticate(ctx, key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errWrongAPIKey
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("authenticate api key")
+
+		return nil, errInternal
+	}
+
+	return &Identity{
+		SubscriberID: item.SubscriberID,
+		Scopes:       ParseScopes(item.Scopes),
+	}, nil
+}
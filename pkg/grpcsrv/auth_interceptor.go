@@ -2,55 +2,64 @@ package grpcsrv
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	"github.com/google/uuid"
-	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
 )
 
-type SubscriberProvider interface {
-	GetByID(_ context.Context, id uuid.UUID) (*subscriber.Subscriber, error)
-}
-
-const (
-	subscriberIDKey = "subscriber_id"
-)
-
 var (
-	errWrongSubscriberID = status.Errorf(codes.Unauthenticated, "wrong subscriber identifier")
+	errCredentialsRequired = status.Error(codes.Unauthenticated, "credentials are required")
+	errScopeRequired       = status.Error(codes.PermissionDenied, "scope is not granted")
+	errSubscriberRequired  = status.Error(codes.PermissionDenied, "subscriber is not identified")
 )
 
 type Auth struct {
-	subs SubscriberProvider
+	authenticator Authenticator
+	scopes        map[string]Scope
 }
 
-func NewAuthInterceptor(subs SubscriberProvider) *Auth {
+// NewAuthInterceptor creates the interceptor checking the caller has the scope required by the called method.
+// Methods missing in the scopes require the admin scope.
+func NewAuthInterceptor(authenticator Authenticator, scopes map[string]Scope) *Auth {
 	return &Auth{
-		subs: subs,
+		authenticator: authenticator,
+		scopes:        scopes,
 	}
 }
 
-func (a *Auth) AuthAndIdentifyTickerFunc(ctx context.Context) (context.Context, error) {
-	md := metautils.ExtractIncoming(ctx)
-	requestSubID := md.Get(subscriberIDKey)
+func (a *Auth) AuthFunc(ctx context.Context) (context.Context, error) {
+	identity, err := a.authenticator.Authenticate(ctx)
+	if errors.Is(err, ErrNoCredentials) {
+		return nil, errCredentialsRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	method, _ := grpc.Method(ctx)
+	required, ok := a.scopes[method]
+	if !ok {
+		required = ScopeAdmin
+	}
 
-	if requestSubID == "" {
-		return nil, errWrongSubscriberID
+	if !identity.HasScope(required) {
+		return nil, errScopeRequired
 	}
 
-	parsed, err := uuid.Parse(requestSubID)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %s", errWrongSubscriberID, err.Error())
+	// subscriptions are always managed on behalf of the subscriber
+	if required == ScopeManageSubscriptions && identity.SubscriberID == uuid.Nil {
+		return nil, errSubscriberRequired
 	}
 
-	if _, err := a.subs.GetByID(ctx, parsed); err != nil {
-		return nil, errWrongSubscriberID
+	ctx = WithIdentity(ctx, identity)
+	if identity.SubscriberID != uuid.Nil {
+		ctx = context.WithValue(ctx, subscriber.IDKey, identity.SubscriberID)
 	}
 
-	newCtx := context.WithValue(ctx, subscriber.IDKey, parsed)
-	return newCtx, nil
+	return ctx, nil
 }
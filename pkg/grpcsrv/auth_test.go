@@ -0,0 +1,234 @@
+package grpcsrv
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+const testSecret = "secret"
+
+type staticSubscribers map[uuid.UUID]struct{}
+
+func (s staticSubscribers) GetByID(_ context.Context, id uuid.UUID) (*subscriber.Subscriber, error) {
+	if _, ok := s[id]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &subscriber.Subscriber{ID: id}, nil
+}
+
+func signToken(t *testing.T, secret string, header, claims map[string]any) string {
+	t.Helper()
+
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	unsigned := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func withMetadata(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func requireCode(t *testing.T, code codes.Code, err error) {
+	t.Helper()
+
+	require.Error(t, err)
+	require.Equal(t, code, status.Code(err), err.Error())
+}
+
+func TestUnitJWTAuthenticator(t *testing.T) {
+	subscriberID := uuid.New()
+	a := NewJWTAuthenticator(testSecret, "issuer", "feed", staticSubscribers{subscriberID: {}})
+
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+	valid := func() map[string]any {
+		return map[string]any{
+			"sub":   subscriberID.String(),
+			"scope": "feed:read subscriptions:manage",
+			"iss":   "issuer",
+			"aud":   []string{"feed", "other"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		token := signToken(t, testSecret, hs256, valid())
+
+		identity, err := a.Authenticate(withMetadata("authorization", "Bearer "+token))
+		require.NoError(t, err)
+		require.Equal(t, subscriberID, identity.SubscriberID)
+		require.Equal(t, []Scope{ScopeReadFeed, ScopeManageSubscriptions}, identity.Scopes)
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		_, err := a.Authenticate(withMetadata("authorization", "Basic dXNlcjpwYXNz"))
+		require.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	invalid := map[string]func() string{
+		"wrong secret": func() string {
+			return signToken(t, "other", hs256, valid())
+		},
+		"wrong algorithm": func() string {
+			return signToken(t, testSecret, map[string]any{"alg": "none"}, valid())
+		},
+		"expired": func() string {
+			claims := valid()
+			claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+			return signToken(t, testSecret, hs256, claims)
+		},
+		"without expiration": func() string {
+			claims := valid()
+			delete(claims, "exp")
+
+			return signToken(t, testSecret, hs256, claims)
+		},
+		"not active yet": func() string {
+			claims := valid()
+			claims["nbf"] = time.Now().Add(time.Minute).Unix()
+
+			return signToken(t, testSecret, hs256, claims)
+		},
+		"wrong audience": func() string {
+			claims := valid()
+			claims["aud"] = "other"
+
+			return signToken(t, testSecret, hs256, claims)
+		},
+		"unknown subscriber": func() string {
+			claims := valid()
+			claims["sub"] = uuid.NewString()
+
+			return signToken(t, testSecret, hs256, claims)
+		},
+		"malformed": func() string {
+			return "token"
+		},
+	}
+
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(withMetadata("authorization", "Bearer "+token()))
+			requireCode(t, codes.Unauthenticated, err)
+		})
+	}
+}
+
+type staticAuthenticator struct {
+	identity *Identity
+}
+
+func (a staticAuthenticator) Authenticate(context.Context) (*Identity, error) {
+	if a.identity == nil {
+		return nil, ErrNoCredentials
+	}
+
+	return a.identity, nil
+}
+
+func TestUnitAuthInterceptor(t *testing.T) {
+	scopes := map[string]Scope{
+		"/feed/Read":   ScopeReadFeed,
+		"/feed/Manage": ScopeManageSubscriptions,
+	}
+	subscriberID := uuid.New()
+
+	for _, tc := range []struct {
+		name     string
+		identity *Identity
+		method   string
+		code     codes.Code
+	}{
+		{
+			name:   "no credentials",
+			method: "/feed/Read",
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:     "granted scope",
+			identity: &Identity{SubscriberID: subscriberID, Scopes: []Scope{ScopeReadFeed}},
+			method:   "/feed/Read",
+			code:     codes.OK,
+		},
+		{
+			name:     "missing scope",
+			identity: &Identity{SubscriberID: subscriberID, Scopes: []Scope{ScopeReadFeed}},
+			method:   "/feed/Manage",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:     "not configured method requires admin",
+			identity: &Identity{SubscriberID: subscriberID, Scopes: []Scope{ScopeReadFeed, ScopeManageSubscriptions}},
+			method:   "/feed/Other",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:     "admin",
+			identity: &Identity{Scopes: []Scope{ScopeAdmin}},
+			method:   "/feed/Other",
+			code:     codes.OK,
+		},
+		{
+			name:     "subscriptions of unknown subscriber",
+			identity: &Identity{Scopes: []Scope{ScopeAdmin}},
+			method:   "/feed/Manage",
+			code:     codes.PermissionDenied,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := NewAuthInterceptor(NewChainAuthenticator(staticAuthenticator{identity: tc.identity}), scopes)
+
+			ctx, err := auth.AuthFunc(contextWithMethod(tc.method))
+			if tc.code != codes.OK {
+				requireCode(t, tc.code, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			identity, ok := IdentityFromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, tc.identity, identity)
+		})
+	}
+}
+
+type methodStream struct {
+	method string
+}
+
+func (s methodStream) Method() string               { return s.method }
+func (s methodStream) SetHeader(metadata.MD) error  { return nil }
+func (s methodStream) SendHeader(metadata.MD) error { return nil }
+func (s methodStream) SetTrailer(metadata.MD) error { return nil }
+
+// contextWithMethod returns the context of the called method like the gRPC server does
+func contextWithMethod(method string) context.Context {
+	return grpc.NewContextWithServerTransportStream(context.Background(), methodStream{method: method})
+}
@@ -0,0 +1,90 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Scope is the permission granted to the caller of the API
+type Scope string
+
+const (
+	ScopeReadFeed            Scope = "feed:read"
+	ScopeManageSubscriptions Scope = "subscriptions:manage"
+	// ScopeAdmin grants all scopes
+	ScopeAdmin Scope = "admin"
+)
+
+// ErrNoCredentials is returned by the authenticator if the request doesn't contain credentials it checks
+var ErrNoCredentials = errors.New("no credentials")
+
+// Identity is the authenticated caller of the API
+type Identity struct {
+	// SubscriberID is empty if the caller doesn't act on behalf of the subscriber, e.g. the admin token
+	SubscriberID uuid.UUID
+	Scopes       []Scope
+}
+
+func (i *Identity) HasScope(scope Scope) bool {
+	for _, s := range i.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator checks credentials passed in the request metadata.
+// Invalid credentials are reported by gRPC status errors.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+// ChainAuthenticator authenticates the request by the first authenticator which finds its credentials
+type ChainAuthenticator []Authenticator
+
+func NewChainAuthenticator(authenticators ...Authenticator) ChainAuthenticator {
+	return authenticators
+}
+
+func (c ChainAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	for _, a := range c {
+		identity, err := a.Authenticate(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return identity, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// ParseScopes parses the list of scopes separated by spaces
+func ParseScopes(scopes string) []Scope {
+	fields := strings.Fields(scopes)
+	res := make([]Scope, len(fields))
+	for i, f := range fields {
+		res[i] = Scope(f)
+	}
+
+	return res
+}
+
+type identityKey struct{}
+
+// WithIdentity returns the context with the authenticated caller
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller authenticated by the auth interceptor
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+
+	return identity, ok
+}
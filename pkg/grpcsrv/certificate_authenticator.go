@@ -0,0 +1,59 @@
+package grpcsrv
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertificateAuthenticator identifies the caller by the verified client certificate.
+// The common name of the subject is the subscriber identifier or one of admin names.
+type CertificateAuthenticator struct {
+	subs   SubscriberProvider
+	admins map[string]struct{}
+}
+
+func NewCertificateAuthenticator(subs SubscriberProvider, adminNames []string) *CertificateAuthenticator {
+	admins := make(map[string]struct{}, len(adminNames))
+	for _, name := range adminNames {
+		admins[name] = struct{}{}
+	}
+
+	return &CertificateAuthenticator{
+		subs:   subs,
+		admins: admins,
+	}
+}
+
+func (a *CertificateAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	name := info.State.VerifiedChains[0][0].Subject.CommonName
+	if _, ok := a.admins[name]; ok {
+		return &Identity{Scopes: []Scope{ScopeAdmin}}, nil
+	}
+
+	id, err := uuid.Parse(name)
+	if err != nil {
+		return nil, errWrongSubscriberID
+	}
+
+	if err := checkSubscriber(ctx, a.subs, id); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		SubscriberID: id,
+		Scopes:       []Scope{ScopeReadFeed, ScopeManageSubscriptions},
+	}, nil
+}
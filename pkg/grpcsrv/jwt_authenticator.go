@@ -0,0 +1,173 @@
+package grpcsrv
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationKey = "authorization"
+	bearerScheme     = "bearer "
+	jwtAlgorithm     = "HS256"
+)
+
+var errWrongToken = status.Error(codes.Unauthenticated, "wrong token")
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Scope     string      `json:"scope"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt *int64      `json:"exp"`
+	NotBefore *int64      `json:"nbf"`
+}
+
+// jwtAudience is the single audience or the list of them
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = []string{single}
+
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+
+	return nil
+}
+
+func (a jwtAudience) contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JWTAuthenticator checks HS256 tokens passed in the authorization metadata with the bearer scheme.
+// The sub claim is the subscriber identifier, it's optional for tokens with the admin scope.
+// The scope claim contains scopes separated by spaces, the exp claim is required.
+type JWTAuthenticator struct {
+	secret   []byte
+	issuer   string
+	audience string
+	subs     SubscriberProvider
+	now      func() time.Time
+}
+
+// NewJWTAuthenticator creates the authenticator, empty issuer and audience are not checked
+func NewJWTAuthenticator(secret, issuer, audience string, subs SubscriberProvider) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret:   []byte(secret),
+		issuer:   issuer,
+		audience: audience,
+		subs:     subs,
+		now:      time.Now,
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	header := metautils.ExtractIncoming(ctx).Get(authorizationKey)
+	if len(header) <= len(bearerScheme) || !strings.EqualFold(header[:len(bearerScheme)], bearerScheme) {
+		return nil, ErrNoCredentials
+	}
+
+	claims, ok := a.parse(strings.TrimSpace(header[len(bearerScheme):]))
+	if !ok {
+		return nil, errWrongToken
+	}
+
+	identity := &Identity{
+		Scopes: ParseScopes(claims.Scope),
+	}
+
+	if claims.Subject != "" {
+		id, err := uuid.Parse(claims.Subject)
+		if err != nil {
+			return nil, errWrongToken
+		}
+
+		if err := checkSubscriber(ctx, a.subs, id); err != nil {
+			return nil, err
+		}
+
+		identity.SubscriberID = id
+	}
+
+	return identity, nil
+}
+
+func (a *JWTAuthenticator) parse(token string) (*jwtClaims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, false
+	}
+
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, false
+	}
+
+	var header jwtHeader
+	if !decodeJWTPart(parts[0], &header) || header.Alg != jwtAlgorithm {
+		return nil, false
+	}
+
+	var claims jwtClaims
+	if !decodeJWTPart(parts[1], &claims) {
+		return nil, false
+	}
+
+	now := a.now().Unix()
+	if claims.ExpiresAt == nil || now >= *claims.ExpiresAt {
+		return nil, false
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return nil, false
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return nil, false
+	}
+	if a.audience != "" && !claims.Audience.contains(a.audience) {
+		return nil, false
+	}
+
+	return &claims, true
+}
+
+func decodeJWTPart(part string, v any) bool {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(data, v) == nil
+}
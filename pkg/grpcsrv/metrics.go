@@ -0,0 +1,35 @@
+package grpcsrv
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/metrics"
+)
+
+var metricLimitedCounter = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "grpc",
+		Name:      "limited_total",
+		Help:      "Count of calls rejected by limits by the reason: rate or streams",
+	}, []string{"method", "reason"},
+)
+
+var metricActiveStreamsGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "grpc",
+		Name:      "active_streams",
+		Help:      "Count of active streams counted by the limiter",
+	},
+)
+
+var metricLimitedCallersGauge = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "grpc",
+		Name:      "limited_callers",
+		Help:      "Count of callers with partially used rate limits",
+	},
+)
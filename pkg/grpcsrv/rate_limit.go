@@ -0,0 +1,243 @@
+package grpcsrv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	retryAfterKey = "retry-after"
+
+	limitReasonRate    = "rate"
+	limitReasonStreams = "streams"
+
+	// idle buckets are removed after they are refilled, so callers are not tracked forever
+	bucketsSweepInterval = time.Minute
+)
+
+// LimitOptions limits calls of every caller. Zero Rate disables the rate limit, zero MaxStreams disables the streams cap.
+type LimitOptions struct {
+	// Rate is the count of calls per second refilling the bucket of Burst calls
+	Rate       float64
+	Burst      int
+	MaxStreams int
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Limiter limits the rate of calls with token buckets and the count of concurrent streams per caller.
+// The caller is the authenticated subscriber or the peer address for calls without the subscriber,
+// so the limiter has to be placed after the auth interceptor.
+type Limiter struct {
+	opts LimitOptions
+	now  func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	streams map[string]int
+}
+
+func NewLimiter(opts LimitOptions) *Limiter {
+	if opts.Burst < 1 {
+		opts.Burst = 1
+	}
+
+	return &Limiter{
+		opts:    opts,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+		streams: make(map[string]int),
+	}
+}
+
+func (l *Limiter) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := l.allow(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (l *Limiter) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	if err := l.allow(ctx, info.FullMethod); err != nil {
+		return err
+	}
+
+	caller := callerOf(ctx)
+	if !l.acquireStream(caller) {
+		metricLimitedCounter.WithLabelValues(info.FullMethod, limitReasonStreams).Inc()
+
+		return streamsExceeded(caller, l.opts.MaxStreams)
+	}
+	defer l.releaseStream(caller)
+
+	return handler(srv, ss)
+}
+
+func (l *Limiter) allow(ctx context.Context, method string) error {
+	wait, ok := l.take(callerOf(ctx))
+	if ok {
+		return nil
+	}
+
+	metricLimitedCounter.WithLabelValues(method, limitReasonRate).Inc()
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterKey, formatRetryAfter(wait)))
+
+	st, err := status.New(codes.ResourceExhausted, "rate limit is exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit is exceeded")
+	}
+
+	return st.Err()
+}
+
+// streamsExceeded returns the error with the quota failure, the stream could be opened after another one is closed
+func streamsExceeded(caller string, limit int) error {
+	msg := fmt.Sprintf("too many streams, the limit is %d", limit)
+
+	st, err := status.New(codes.ResourceExhausted, msg).
+		WithDetails(&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     caller,
+				Description: msg,
+			}},
+		})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, msg)
+	}
+
+	return st.Err()
+}
+
+// take takes the token from the bucket of the caller or returns the time until the next token
+func (l *Limiter) take(caller string) (time.Duration, bool) {
+	if l.opts.Rate <= 0 {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[caller]
+	if !ok {
+		b = &bucket{tokens: float64(l.opts.Burst), updated: now}
+		l.buckets[caller] = b
+	}
+
+	b.tokens = math.Min(float64(l.opts.Burst), b.tokens+now.Sub(b.updated).Seconds()*l.opts.Rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return 0, true
+	}
+
+	return time.Duration((1 - b.tokens) / l.opts.Rate * float64(time.Second)), false
+}
+
+func (l *Limiter) acquireStream(caller string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.opts.MaxStreams > 0 && l.streams[caller] >= l.opts.MaxStreams {
+		return false
+	}
+
+	l.streams[caller]++
+	metricActiveStreamsGauge.Inc()
+
+	return true
+}
+
+func (l *Limiter) releaseStream(caller string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.streams[caller]--
+	if l.streams[caller] <= 0 {
+		delete(l.streams, caller)
+	}
+	metricActiveStreamsGauge.Dec()
+}
+
+func (l *Limiter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(bucketsSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			l.sweep()
+		}
+	}
+}
+
+// sweep removes buckets which are refilled, they are equal to new ones
+func (l *Limiter) sweep() {
+	if l.opts.Rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for caller, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.opts.Rate >= float64(l.opts.Burst) {
+			delete(l.buckets, caller)
+		}
+	}
+
+	metricLimitedCallersGauge.Set(float64(len(l.buckets)))
+}
+
+func callerOf(ctx context.Context) string {
+	if identity, ok := IdentityFromContext(ctx); ok && identity.SubscriberID != uuid.Nil {
+		return identity.SubscriberID.String()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+
+		return "peer:" + host
+	}
+
+	return "unknown"
+}
+
+// formatRetryAfter returns whole seconds like the HTTP Retry-After header
+func formatRetryAfter(wait time.Duration) string {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.Itoa(seconds)
+}
@@ -0,0 +1,111 @@
+package grpcsrv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func subscriberContext(id uuid.UUID) context.Context {
+	return WithIdentity(context.Background(), &Identity{SubscriberID: id})
+}
+
+func TestUnitLimiterRate(t *testing.T) {
+	now := time.Now()
+	l := NewLimiter(LimitOptions{Rate: 2, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/feed/Read"}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	first, second := subscriberContext(uuid.New()), subscriberContext(uuid.New())
+
+	for i := 0; i < 2; i++ {
+		_, err := l.UnaryInterceptor(first, nil, info, handler)
+		require.NoError(t, err)
+	}
+
+	_, err := l.UnaryInterceptor(first, nil, info, handler)
+	requireCode(t, codes.ResourceExhausted, err)
+
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	require.NotNil(t, retry)
+	require.Equal(t, 500*time.Millisecond, retry.GetRetryDelay().AsDuration())
+
+	// buckets are separated by subscribers
+	_, err = l.UnaryInterceptor(second, nil, info, handler)
+	require.NoError(t, err)
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = l.UnaryInterceptor(first, nil, info, handler)
+	require.NoError(t, err)
+
+	// refilled buckets are removed
+	now = now.Add(time.Second)
+	l.sweep()
+	require.Empty(t, l.buckets)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestUnitLimiterStreams(t *testing.T) {
+	l := NewLimiter(LimitOptions{MaxStreams: 1})
+	stream := testServerStream{ctx: subscriberContext(uuid.New())}
+	info := &grpc.StreamServerInfo{FullMethod: "/feed/Watch"}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- l.StreamInterceptor(nil, stream, info, func(any, grpc.ServerStream) error {
+			close(started)
+			<-release
+
+			return nil
+		})
+	}()
+	<-started
+
+	err := l.StreamInterceptor(nil, stream, info, func(any, grpc.ServerStream) error { return nil })
+	requireCode(t, codes.ResourceExhausted, err)
+	requireQuotaFailure(t, err)
+
+	close(release)
+	require.NoError(t, <-done)
+
+	err = l.StreamInterceptor(nil, stream, info, func(any, grpc.ServerStream) error { return nil })
+	require.NoError(t, err)
+	require.Empty(t, l.streams)
+}
+
+func requireQuotaFailure(t *testing.T, err error) {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if failure, ok := detail.(*errdetails.QuotaFailure); ok {
+			require.NotEmpty(t, failure.GetViolations())
+
+			return
+		}
+	}
+
+	t.Fatal("there is no quota failure in details")
+}
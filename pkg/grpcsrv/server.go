@@ -5,11 +5,12 @@ import (
 	"google.golang.org/grpc"
 )
 
-func NewGrpcServer(excludePath []string, auth grpcauth.AuthFunc) *grpc.Server {
-	server := grpc.NewServer(
+func NewGrpcServer(excludePath []string, auth grpcauth.AuthFunc, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
 		StdUnaryMiddleware(UnaryReflectionFilter(excludePath, grpcauth.UnaryServerInterceptor(auth))),
 		StdStreamMiddleware(StreamReflectionFilter(excludePath, grpcauth.StreamServerInterceptor(auth))),
 	)
+	server := grpc.NewServer(opts...)
 
 	StdRegister(server)
 
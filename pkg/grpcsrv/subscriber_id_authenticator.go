@@ -0,0 +1,75 @@
+package grpcsrv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/goverland-labs/goverland-core-feed/internal/subscriber"
+)
+
+const (
+	subscriberIDKey = "subscriber_id"
+)
+
+var (
+	errWrongSubscriberID = status.Error(codes.Unauthenticated, "wrong subscriber identifier")
+	errInternal          = status.Error(codes.Internal, "internal error")
+)
+
+type SubscriberProvider interface {
+	GetByID(_ context.Context, id uuid.UUID) (*subscriber.Subscriber, error)
+}
+
+// SubscriberIDAuthenticator identifies the subscriber by the identifier passed in the subscriber_id metadata.
+// It's deprecated: the identifier is not a secret, use API keys or JWT instead.
+type SubscriberIDAuthenticator struct {
+	subs SubscriberProvider
+}
+
+func NewSubscriberIDAuthenticator(subs SubscriberProvider) *SubscriberIDAuthenticator {
+	return &SubscriberIDAuthenticator{
+		subs: subs,
+	}
+}
+
+func (a *SubscriberIDAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	requestSubID := metautils.ExtractIncoming(ctx).Get(subscriberIDKey)
+	if requestSubID == "" {
+		return nil, ErrNoCredentials
+	}
+
+	parsed, err := uuid.Parse(requestSubID)
+	if err != nil {
+		return nil, errWrongSubscriberID
+	}
+
+	if err := checkSubscriber(ctx, a.subs, parsed); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		SubscriberID: parsed,
+		Scopes:       []Scope{ScopeReadFeed, ScopeManageSubscriptions},
+	}, nil
+}
+
+func checkSubscriber(ctx context.Context, subs SubscriberProvider, id uuid.UUID) error {
+	_, err := subs.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errWrongSubscriberID
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("get subscriber")
+
+		return errInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package grpcsrv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ClientAuth is the mode of client certificates verification
+type ClientAuth string
+
+const (
+	ClientAuthNone ClientAuth = "none"
+	// ClientAuthOptional verifies client certificates if they are passed
+	ClientAuthOptional ClientAuth = "optional"
+	ClientAuthRequire  ClientAuth = "require"
+)
+
+// TLSOptions are files of the server certificate and CA certificates of clients
+type TLSOptions struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   ClientAuth
+}
+
+type tlsState struct {
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modified  []time.Time
+}
+
+// CertReloader keeps the server certificate and client CA certificates loaded from files
+// and reloads them when files are changed, so certificates are rotated without the restart.
+type CertReloader struct {
+	opts     TLSOptions
+	interval time.Duration
+
+	mu    sync.RWMutex
+	state *tlsState
+}
+
+// NewCertReloader loads certificates, files are checked for changes every interval
+func NewCertReloader(opts TLSOptions, interval time.Duration) (*CertReloader, error) {
+	switch opts.ClientAuth {
+	case ClientAuthNone, ClientAuthOptional, ClientAuthRequire:
+	default:
+		return nil, fmt.Errorf("unknown client auth mode: %s", opts.ClientAuth)
+	}
+
+	if opts.ClientAuth != ClientAuthNone && opts.ClientCAFile == "" {
+		return nil, fmt.Errorf("client CA file is required for the client auth mode %s", opts.ClientAuth)
+	}
+
+	r := &CertReloader{
+		opts:     opts,
+		interval: interval,
+	}
+
+	state, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.state = state
+
+	return r, nil
+}
+
+// TLSConfig returns the server config using the last loaded certificates for every new connection
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			state := r.state
+			r.mu.RUnlock()
+
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*state.cert},
+				ClientCAs:    state.clientCAs,
+				ClientAuth:   r.clientAuthType(),
+			}, nil
+		},
+	}
+}
+
+func (r *CertReloader) clientAuthType() tls.ClientAuthType {
+	switch r.opts.ClientAuth {
+	case ClientAuthOptional:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
+
+func (r *CertReloader) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := r.Reload(); err != nil {
+				// connections keep using the previous certificates
+				log.Error().Err(err).Msg("reload tls certificates")
+			}
+		}
+	}
+}
+
+// Reload loads certificates if any of files is changed and returns true if certificates are replaced
+func (r *CertReloader) Reload() (bool, error) {
+	modified, err := r.modified()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	changed := !equalTimes(modified, r.state.modified)
+	r.mu.RUnlock()
+
+	if !changed {
+		return false, nil
+	}
+
+	state, err := r.load()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	r.state = state
+	r.mu.Unlock()
+
+	log.Info().Str("cert", r.opts.CertFile).Msg("tls certificates are reloaded")
+
+	return true, nil
+}
+
+func (r *CertReloader) load() (*tlsState, error) {
+	modified, err := r.modified()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load certificate: %w", err)
+	}
+
+	state := &tlsState{
+		cert:     &cert,
+		modified: modified,
+	}
+
+	if r.opts.ClientCAFile != "" {
+		data, err := os.ReadFile(r.opts.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA: %w", err)
+		}
+
+		state.clientCAs = x509.NewCertPool()
+		if !state.clientCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("client CA file %s has no certificates", r.opts.ClientCAFile)
+		}
+	}
+
+	return state, nil
+}
+
+func (r *CertReloader) modified() ([]time.Time, error) {
+	files := []string{r.opts.CertFile, r.opts.KeyFile}
+	if r.opts.ClientCAFile != "" {
+		files = append(files, r.opts.ClientCAFile)
+	}
+
+	res := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", file, err)
+		}
+
+		res[i] = info.ModTime()
+	}
+
+	return res, nil
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
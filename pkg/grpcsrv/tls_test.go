@@ -0,0 +1,213 @@
+package grpcsrv
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCert(t *testing.T, name string, serial int64, parent *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c *testCert) keyPEM(t *testing.T) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCert) tlsCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	cert, err := tls.X509KeyPair(c.pem, c.keyPEM(t))
+	require.NoError(t, err)
+
+	return cert
+}
+
+func writeTestFiles(t *testing.T, dir string, ca, server *testCert, modified time.Time) TLSOptions {
+	t.Helper()
+
+	opts := TLSOptions{
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "ca.pem"),
+		ClientAuth:   ClientAuthRequire,
+	}
+
+	for file, data := range map[string][]byte{
+		opts.CertFile:     server.pem,
+		opts.KeyFile:      server.keyPEM(t),
+		opts.ClientCAFile: ca.pem,
+	} {
+		require.NoError(t, os.WriteFile(file, data, 0o600))
+		require.NoError(t, os.Chtimes(file, modified, modified))
+	}
+
+	return opts
+}
+
+func TestUnitCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", 1, nil)
+	client := newTestCert(t, "client", 2, ca)
+
+	opts := writeTestFiles(t, dir, ca, newTestCert(t, "server", 10, ca), time.Now().Add(-time.Minute))
+	reloader, err := NewCertReloader(opts, time.Minute)
+	require.NoError(t, err)
+
+	serverSerial := func() int64 {
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.cert)
+
+		clientConn, serverConn := net.Pipe()
+		defer clientConn.Close()
+		defer serverConn.Close()
+
+		go func() {
+			_ = tls.Server(serverConn, reloader.TLSConfig()).Handshake()
+		}()
+
+		conn := tls.Client(clientConn, &tls.Config{
+			RootCAs:      pool,
+			ServerName:   "127.0.0.1",
+			Certificates: []tls.Certificate{client.tlsCertificate(t)},
+		})
+		require.NoError(t, conn.Handshake())
+
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	require.EqualValues(t, 10, serverSerial())
+
+	changed, err := reloader.Reload()
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	writeTestFiles(t, dir, ca, newTestCert(t, "server", 11, ca), time.Now())
+
+	changed, err = reloader.Reload()
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.EqualValues(t, 11, serverSerial())
+
+	// broken files don't replace loaded certificates
+	require.NoError(t, os.WriteFile(opts.CertFile, []byte("broken"), 0o600))
+	require.NoError(t, os.Chtimes(opts.CertFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+
+	_, err = reloader.Reload()
+	require.Error(t, err)
+	require.EqualValues(t, 11, serverSerial())
+}
+
+func TestUnitCertificateAuthenticator(t *testing.T) {
+	ca := newTestCert(t, "ca", 1, nil)
+	subscriberID := uuid.New()
+
+	reloader, err := NewCertReloader(writeTestFiles(t, t.TempDir(), ca, newTestCert(t, "server", 2, ca), time.Now()), time.Minute)
+	require.NoError(t, err)
+
+	authenticator := NewCertificateAuthenticator(staticSubscribers{subscriberID: {}}, []string{"ops"})
+	auth := NewAuthInterceptor(authenticator, map[string]Scope{
+		"/grpc.health.v1.Health/Check": ScopeReadFeed,
+	})
+
+	srv := NewGrpcServer(nil, auth.AuthFunc, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	check := func(t *testing.T, name string) codes.Code {
+		t.Helper()
+
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.cert)
+
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:      pool,
+			ServerName:   "127.0.0.1",
+			Certificates: []tls.Certificate{newTestCert(t, name, 3, ca).tlsCertificate(t)},
+		})))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+		return status.Code(err)
+	}
+
+	require.Equal(t, codes.OK, check(t, subscriberID.String()))
+	require.Equal(t, codes.OK, check(t, "ops"))
+	require.Equal(t, codes.Unauthenticated, check(t, uuid.NewString()))
+	require.Equal(t, codes.Unauthenticated, check(t, "unknown"))
+}
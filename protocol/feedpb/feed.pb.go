@@ -30,6 +30,8 @@ const (
 	FeedInfo_DAO         FeedInfo_Type = 1
 	FeedInfo_Proposal    FeedInfo_Type = 2
 	FeedInfo_Delegate    FeedInfo_Type = 3
+	FeedInfo_Discussion  FeedInfo_Type = 4
+	FeedInfo_Vote        FeedInfo_Type = 5
 )
 
 // Enum value maps for FeedInfo_Type.
@@ -39,12 +41,16 @@ var (
 		1: "DAO",
 		2: "Proposal",
 		3: "Delegate",
+		4: "Discussion",
+		5: "Vote",
 	}
 	FeedInfo_Type_value = map[string]int32{
 		"Unspecified": 0,
 		"DAO":         1,
 		"Proposal":    2,
 		"Delegate":    3,
+		"Discussion":  4,
+		"Vote":        5,
 	}
 )
 
@@ -78,19 +84,26 @@ func (FeedInfo_Type) EnumDescriptor() ([]byte, []int) {
 type FeedTimelineItem_TimelineAction int32
 
 const (
-	FeedTimelineItem_Unspecified                 FeedTimelineItem_TimelineAction = 0
-	FeedTimelineItem_DaoCreated                  FeedTimelineItem_TimelineAction = 1
-	FeedTimelineItem_DaoUpdated                  FeedTimelineItem_TimelineAction = 2
-	FeedTimelineItem_ProposalCreated             FeedTimelineItem_TimelineAction = 3
-	FeedTimelineItem_ProposalUpdated             FeedTimelineItem_TimelineAction = 4
-	FeedTimelineItem_ProposalVotingStartsSoon    FeedTimelineItem_TimelineAction = 5
-	FeedTimelineItem_ProposalVotingStarted       FeedTimelineItem_TimelineAction = 6
-	FeedTimelineItem_ProposalVotingQuorumReached FeedTimelineItem_TimelineAction = 7
-	FeedTimelineItem_ProposalVotingEnded         FeedTimelineItem_TimelineAction = 8
-	FeedTimelineItem_ProposalVotingEndsSoon      FeedTimelineItem_TimelineAction = 9
-	FeedTimelineItem_DelegateCreateProposal      FeedTimelineItem_TimelineAction = 10
-	FeedTimelineItem_DelegateVotingVoted         FeedTimelineItem_TimelineAction = 11
-	FeedTimelineItem_DelegateVotingSkipVote      FeedTimelineItem_TimelineAction = 12
+	FeedTimelineItem_Unspecified                    FeedTimelineItem_TimelineAction = 0
+	FeedTimelineItem_DaoCreated                     FeedTimelineItem_TimelineAction = 1
+	FeedTimelineItem_DaoUpdated                     FeedTimelineItem_TimelineAction = 2
+	FeedTimelineItem_ProposalCreated                FeedTimelineItem_TimelineAction = 3
+	FeedTimelineItem_ProposalUpdated                FeedTimelineItem_TimelineAction = 4
+	FeedTimelineItem_ProposalVotingStartsSoon       FeedTimelineItem_TimelineAction = 5
+	FeedTimelineItem_ProposalVotingStarted          FeedTimelineItem_TimelineAction = 6
+	FeedTimelineItem_ProposalVotingQuorumReached    FeedTimelineItem_TimelineAction = 7
+	FeedTimelineItem_ProposalVotingEnded            FeedTimelineItem_TimelineAction = 8
+	FeedTimelineItem_ProposalVotingEndsSoon         FeedTimelineItem_TimelineAction = 9
+	FeedTimelineItem_DelegateCreateProposal         FeedTimelineItem_TimelineAction = 10
+	FeedTimelineItem_DelegateVotingVoted            FeedTimelineItem_TimelineAction = 11
+	FeedTimelineItem_DelegateVotingSkipVote         FeedTimelineItem_TimelineAction = 12
+	FeedTimelineItem_DelegateCreated                FeedTimelineItem_TimelineAction = 13
+	FeedTimelineItem_DelegateDelegationExpired      FeedTimelineItem_TimelineAction = 14
+	FeedTimelineItem_DelegateDelegationExpiringSoon FeedTimelineItem_TimelineAction = 15
+	FeedTimelineItem_DiscussionCreated              FeedTimelineItem_TimelineAction = 16
+	FeedTimelineItem_DiscussionReplied              FeedTimelineItem_TimelineAction = 17
+	FeedTimelineItem_DiscussionLinkedToProposal     FeedTimelineItem_TimelineAction = 18
+	FeedTimelineItem_VoteCreated                    FeedTimelineItem_TimelineAction = 19
 )
 
 // Enum value maps for FeedTimelineItem_TimelineAction.
@@ -109,21 +122,35 @@ var (
 		10: "DelegateCreateProposal",
 		11: "DelegateVotingVoted",
 		12: "DelegateVotingSkipVote",
+		13: "DelegateCreated",
+		14: "DelegateDelegationExpired",
+		15: "DelegateDelegationExpiringSoon",
+		16: "DiscussionCreated",
+		17: "DiscussionReplied",
+		18: "DiscussionLinkedToProposal",
+		19: "VoteCreated",
 	}
 	FeedTimelineItem_TimelineAction_value = map[string]int32{
-		"Unspecified":                 0,
-		"DaoCreated":                  1,
-		"DaoUpdated":                  2,
-		"ProposalCreated":             3,
-		"ProposalUpdated":             4,
-		"ProposalVotingStartsSoon":    5,
-		"ProposalVotingStarted":       6,
-		"ProposalVotingQuorumReached": 7,
-		"ProposalVotingEnded":         8,
-		"ProposalVotingEndsSoon":      9,
-		"DelegateCreateProposal":      10,
-		"DelegateVotingVoted":         11,
-		"DelegateVotingSkipVote":      12,
+		"Unspecified":                    0,
+		"DaoCreated":                     1,
+		"DaoUpdated":                     2,
+		"ProposalCreated":                3,
+		"ProposalUpdated":                4,
+		"ProposalVotingStartsSoon":       5,
+		"ProposalVotingStarted":          6,
+		"ProposalVotingQuorumReached":    7,
+		"ProposalVotingEnded":            8,
+		"ProposalVotingEndsSoon":         9,
+		"DelegateCreateProposal":         10,
+		"DelegateVotingVoted":            11,
+		"DelegateVotingSkipVote":         12,
+		"DelegateCreated":                13,
+		"DelegateDelegationExpired":      14,
+		"DelegateDelegationExpiringSoon": 15,
+		"DiscussionCreated":              16,
+		"DiscussionReplied":              17,
+		"DiscussionLinkedToProposal":     18,
+		"VoteCreated":                    19,
 	}
 )
 
@@ -332,6 +359,7 @@ type FeedByFilterRequest struct {
 	Offset        *uint64  `protobuf:"varint,5,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	DaoIds        []string `protobuf:"bytes,6,rep,name=dao_ids,json=daoIds,proto3" json:"dao_ids,omitempty"`
 	IsActive      *bool    `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	Voters        []string `protobuf:"bytes,8,rep,name=voters,proto3" json:"voters,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -416,6 +444,13 @@ func (x *FeedByFilterRequest) GetIsActive() bool {
 	return false
 }
 
+func (x *FeedByFilterRequest) GetVoters() []string {
+	if x != nil {
+		return x.Voters
+	}
+	return nil
+}
+
 type FeedByFilterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*FeedInfo            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -476,7 +511,7 @@ var file_feedpb_feed_proto_rawDesc = string([]byte{
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
 	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd6, 0x03, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf0, 0x03, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64,
 	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
 	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
@@ -502,69 +537,84 @@ var file_feedpb_feed_proto_rawDesc = string([]byte{
 	0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
 	0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65,
 	0x6c, 0x69, 0x6e, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69,
-	0x6e, 0x65, 0x22, 0x3c, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e,
+	0x6e, 0x65, 0x22, 0x56, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e,
 	0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x44,
 	0x41, 0x4f, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
 	0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x10, 0x03,
-	0x22, 0xdc, 0x03, 0x0a, 0x10, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e,
-	0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x12, 0x3f, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x27, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69,
-	0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c,
-	0x69, 0x6e, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x22, 0xcb, 0x02, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66,
-	0x69, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x61, 0x6f, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x61, 0x6f, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x64, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
-	0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x72,
-	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x10, 0x04, 0x12,
-	0x1c, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e,
-	0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x73, 0x53, 0x6f, 0x6f, 0x6e, 0x10, 0x05, 0x12, 0x19, 0x0a,
-	0x15, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x10, 0x06, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x70,
-	0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d,
-	0x52, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f,
-	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x64, 0x65, 0x64,
-	0x10, 0x08, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f,
-	0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x64, 0x73, 0x53, 0x6f, 0x6f, 0x6e, 0x10, 0x09, 0x12, 0x1a,
-	0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x10, 0x0a, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x65,
-	0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x56, 0x6f, 0x74, 0x65,
-	0x64, 0x10, 0x0b, 0x12, 0x1a, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x56,
-	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x6b, 0x69, 0x70, 0x56, 0x6f, 0x74, 0x65, 0x10, 0x0c, 0x22,
-	0x86, 0x02, 0x0a, 0x13, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x06, 0x64, 0x61, 0x6f, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x48, 0x00, 0x52, 0x05, 0x64,
-	0x61, 0x6f, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88,
-	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x04, 0x48, 0x02, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01, 0x12,
-	0x17, 0x0a, 0x07, 0x64, 0x61, 0x6f, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x61, 0x6f, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x61,
-	0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x08, 0x69,
-	0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64,
-	0x61, 0x6f, 0x5f, 0x69, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42,
-	0x09, 0x0a, 0x07, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69,
-	0x73, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x5f, 0x0a, 0x14, 0x46, 0x65, 0x65, 0x64,
-	0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x26, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0x50, 0x0a, 0x04, 0x46, 0x65, 0x65,
-	0x64, 0x12, 0x48, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x12, 0x1b, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
-	0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e,
-	0x3b, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0x04,
+	0x12, 0x08, 0x0a, 0x04, 0x56, 0x6f, 0x74, 0x65, 0x10, 0x05, 0x22, 0x93, 0x05, 0x0a, 0x10, 0x46,
+	0x65, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3f, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x66, 0x65, 0x65,
+	0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x82, 0x04, 0x0a, 0x0e,
+	0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0f,
+	0x0a, 0x0b, 0x55, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x00, 0x12,
+	0x0e, 0x0a, 0x0a, 0x44, 0x61, 0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12,
+	0x0e, 0x0a, 0x0a, 0x44, 0x61, 0x6f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x10, 0x02, 0x12,
+	0x13, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x73, 0x53, 0x6f, 0x6f, 0x6e, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x10, 0x06, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f,
+	0x74, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x52, 0x65, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x64, 0x65, 0x64, 0x10, 0x08, 0x12, 0x1a, 0x0a, 0x16,
+	0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x6e,
+	0x64, 0x73, 0x53, 0x6f, 0x6f, 0x6e, 0x10, 0x09, 0x12, 0x1a, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73,
+	0x61, 0x6c, 0x10, 0x0a, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x56, 0x6f, 0x74, 0x65, 0x64, 0x10, 0x0b, 0x12, 0x1a, 0x0a,
+	0x16, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x56, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x53,
+	0x6b, 0x69, 0x70, 0x56, 0x6f, 0x74, 0x65, 0x10, 0x0c, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x65, 0x6c,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x0d, 0x12, 0x1d,
+	0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x10, 0x0e, 0x12, 0x22, 0x0a,
+	0x1e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x6f, 0x6f, 0x6e, 0x10,
+	0x0f, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x10, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63,
+	0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x10, 0x11, 0x12,
+	0x1e, 0x0a, 0x1a, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6e,
+	0x6b, 0x65, 0x64, 0x54, 0x6f, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x10, 0x12, 0x12,
+	0x0f, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x13,
+	0x22, 0x9e, 0x02, 0x0a, 0x13, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x06, 0x64, 0x61, 0x6f, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x48, 0x00, 0x52, 0x05,
+	0x64, 0x61, 0x6f, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x61, 0x6f, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x61, 0x6f, 0x49, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x08,
+	0x69, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x6f, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x74,
+	0x65, 0x72, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x61, 0x6f, 0x5f, 0x69, 0x64, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x69, 0x73, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x22, 0x5f, 0x0a, 0x14, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70,
+	0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x32, 0x50, 0x0a, 0x04, 0x46, 0x65, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e,
+	0x46, 0x65, 0x65, 0x64, 0x42, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
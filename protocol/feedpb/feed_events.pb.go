@@ -29,6 +29,8 @@ const (
 	FeedItemType_FEED_ITEM_TYPE_DAO         FeedItemType = 1
 	FeedItemType_FEED_ITEM_TYPE_PROPOSAL    FeedItemType = 2
 	FeedItemType_FEED_ITEM_TYPE_DELEGATE    FeedItemType = 3
+	FeedItemType_FEED_ITEM_TYPE_DISCUSSION  FeedItemType = 4
+	FeedItemType_FEED_ITEM_TYPE_VOTE        FeedItemType = 5
 )
 
 // Enum value maps for FeedItemType.
@@ -38,12 +40,16 @@ var (
 		1: "FEED_ITEM_TYPE_DAO",
 		2: "FEED_ITEM_TYPE_PROPOSAL",
 		3: "FEED_ITEM_TYPE_DELEGATE",
+		4: "FEED_ITEM_TYPE_DISCUSSION",
+		5: "FEED_ITEM_TYPE_VOTE",
 	}
 	FeedItemType_value = map[string]int32{
 		"FEED_ITEM_TYPE_UNSPECIFIED": 0,
 		"FEED_ITEM_TYPE_DAO":         1,
 		"FEED_ITEM_TYPE_PROPOSAL":    2,
 		"FEED_ITEM_TYPE_DELEGATE":    3,
+		"FEED_ITEM_TYPE_DISCUSSION":  4,
+		"FEED_ITEM_TYPE_VOTE":        5,
 	}
 )
 
@@ -513,6 +519,215 @@ func (x *Delegate) GetDueDate() *timestamppb.Timestamp {
 	return nil
 }
 
+type Discussion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	DaoInternalId string                 `protobuf:"bytes,3,opt,name=dao_internal_id,json=daoInternalId,proto3" json:"dao_internal_id,omitempty"`
+	ProposalId    string                 `protobuf:"bytes,4,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	Url           string                 `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	RepliesCount  uint64                 `protobuf:"varint,8,opt,name=replies_count,json=repliesCount,proto3" json:"replies_count,omitempty"`
+	Timeline      []*Timeline            `protobuf:"bytes,9,rep,name=timeline,proto3" json:"timeline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Discussion) Reset() {
+	*x = Discussion{}
+	mi := &file_feedpb_feed_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Discussion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discussion) ProtoMessage() {}
+
+func (x *Discussion) ProtoReflect() protoreflect.Message {
+	mi := &file_feedpb_feed_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discussion.ProtoReflect.Descriptor instead.
+func (*Discussion) Descriptor() ([]byte, []int) {
+	return file_feedpb_feed_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Discussion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Discussion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Discussion) GetDaoInternalId() string {
+	if x != nil {
+		return x.DaoInternalId
+	}
+	return ""
+}
+
+func (x *Discussion) GetProposalId() string {
+	if x != nil {
+		return x.ProposalId
+	}
+	return ""
+}
+
+func (x *Discussion) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Discussion) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Discussion) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Discussion) GetRepliesCount() uint64 {
+	if x != nil {
+		return x.RepliesCount
+	}
+	return 0
+}
+
+func (x *Discussion) GetTimeline() []*Timeline {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+type Vote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	DaoInternalId string                 `protobuf:"bytes,3,opt,name=dao_internal_id,json=daoInternalId,proto3" json:"dao_internal_id,omitempty"`
+	ProposalId    string                 `protobuf:"bytes,4,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
+	Voter         string                 `protobuf:"bytes,5,opt,name=voter,proto3" json:"voter,omitempty"`
+	// choice is a raw json value, its format depends on the proposal type
+	Choice        string  `protobuf:"bytes,6,opt,name=choice,proto3" json:"choice,omitempty"`
+	Reason        string  `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	VotingPower   float64 `protobuf:"fixed64,8,opt,name=voting_power,json=votingPower,proto3" json:"voting_power,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vote) Reset() {
+	*x = Vote{}
+	mi := &file_feedpb_feed_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vote) ProtoMessage() {}
+
+func (x *Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_feedpb_feed_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vote.ProtoReflect.Descriptor instead.
+func (*Vote) Descriptor() ([]byte, []int) {
+	return file_feedpb_feed_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Vote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vote) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vote) GetDaoInternalId() string {
+	if x != nil {
+		return x.DaoInternalId
+	}
+	return ""
+}
+
+func (x *Vote) GetProposalId() string {
+	if x != nil {
+		return x.ProposalId
+	}
+	return ""
+}
+
+func (x *Vote) GetVoter() string {
+	if x != nil {
+		return x.Voter
+	}
+	return ""
+}
+
+func (x *Vote) GetChoice() string {
+	if x != nil {
+		return x.Choice
+	}
+	return ""
+}
+
+func (x *Vote) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Vote) GetVotingPower() float64 {
+	if x != nil {
+		return x.VotingPower
+	}
+	return 0
+}
+
 type FeedItem struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
@@ -523,6 +738,8 @@ type FeedItem struct {
 	//	*FeedItem_Dao
 	//	*FeedItem_Proposal
 	//	*FeedItem_Delegate
+	//	*FeedItem_Discussion
+	//	*FeedItem_Vote
 	Snapshot      isFeedItem_Snapshot `protobuf_oneof:"snapshot"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -530,7 +747,7 @@ type FeedItem struct {
 
 func (x *FeedItem) Reset() {
 	*x = FeedItem{}
-	mi := &file_feedpb_feed_events_proto_msgTypes[5]
+	mi := &file_feedpb_feed_events_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -542,7 +759,7 @@ func (x *FeedItem) String() string {
 func (*FeedItem) ProtoMessage() {}
 
 func (x *FeedItem) ProtoReflect() protoreflect.Message {
-	mi := &file_feedpb_feed_events_proto_msgTypes[5]
+	mi := &file_feedpb_feed_events_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +772,7 @@ func (x *FeedItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeedItem.ProtoReflect.Descriptor instead.
 func (*FeedItem) Descriptor() ([]byte, []int) {
-	return file_feedpb_feed_events_proto_rawDescGZIP(), []int{5}
+	return file_feedpb_feed_events_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FeedItem) GetCreatedAt() *timestamppb.Timestamp {
@@ -613,6 +830,24 @@ func (x *FeedItem) GetDelegate() *Delegate {
 	return nil
 }
 
+func (x *FeedItem) GetDiscussion() *Discussion {
+	if x != nil {
+		if x, ok := x.Snapshot.(*FeedItem_Discussion); ok {
+			return x.Discussion
+		}
+	}
+	return nil
+}
+
+func (x *FeedItem) GetVote() *Vote {
+	if x != nil {
+		if x, ok := x.Snapshot.(*FeedItem_Vote); ok {
+			return x.Vote
+		}
+	}
+	return nil
+}
+
 type isFeedItem_Snapshot interface {
 	isFeedItem_Snapshot()
 }
@@ -629,12 +864,24 @@ type FeedItem_Delegate struct {
 	Delegate *Delegate `protobuf:"bytes,12,opt,name=delegate,proto3,oneof"`
 }
 
+type FeedItem_Discussion struct {
+	Discussion *Discussion `protobuf:"bytes,13,opt,name=discussion,proto3,oneof"`
+}
+
+type FeedItem_Vote struct {
+	Vote *Vote `protobuf:"bytes,14,opt,name=vote,proto3,oneof"`
+}
+
 func (*FeedItem_Dao) isFeedItem_Snapshot() {}
 
 func (*FeedItem_Proposal) isFeedItem_Snapshot() {}
 
 func (*FeedItem_Delegate) isFeedItem_Snapshot() {}
 
+func (*FeedItem_Discussion) isFeedItem_Snapshot() {}
+
+func (*FeedItem_Vote) isFeedItem_Snapshot() {}
+
 var File_feedpb_feed_events_proto protoreflect.FileDescriptor
 
 var file_feedpb_feed_events_proto_rawDesc = string([]byte{
@@ -724,41 +971,86 @@ var file_feedpb_feed_events_proto_rawDesc = string([]byte{
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x07, 0x64, 0x75, 0x65, 0x44,
 	0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x64, 0x75, 0x65, 0x5f, 0x64,
-	0x61, 0x74, 0x65, 0x22, 0xb7, 0x02, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65,
-	0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x1f, 0x0a, 0x03, 0x64, 0x61, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
-	0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x44, 0x41, 0x4f, 0x48, 0x00, 0x52, 0x03, 0x64, 0x61,
-	0x6f, 0x12, 0x2e, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f,
-	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
-	0x6c, 0x12, 0x2e, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
-	0x65, 0x42, 0x0a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2a, 0x80, 0x01,
-	0x0a, 0x0c, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1e,
-	0x0a, 0x1a, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16,
-	0x0a, 0x12, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x44, 0x41, 0x4f, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49,
-	0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x4f, 0x53, 0x41,
-	0x4c, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45, 0x4d,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x47, 0x41, 0x54, 0x45, 0x10, 0x03,
-	0x32, 0x53, 0x0a, 0x0a, 0x46, 0x65, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x45,
-	0x0a, 0x0f, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
-	0x65, 0x12, 0x1e, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x49,
-	0x74, 0x65, 0x6d, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x66, 0x65, 0x65, 0x64, 0x70,
-	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x65, 0x22, 0xb3, 0x02, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a,
+	0x0f, 0x64, 0x61, 0x6f, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x61, 0x6f, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x74,
+	0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x83, 0x02, 0x0a, 0x04, 0x56, 0x6f,
+	0x74, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a,
+	0x0f, 0x64, 0x61, 0x6f, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x61, 0x6f, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68,
+	0x6f, 0x69, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x22,
+	0x91, 0x03, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x14, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74,
+	0x65, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x03,
+	0x64, 0x61, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x65, 0x65, 0x64,
+	0x70, 0x62, 0x2e, 0x44, 0x41, 0x4f, 0x48, 0x00, 0x52, 0x03, 0x64, 0x61, 0x6f, 0x12, 0x2e, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x2e, 0x0a,
+	0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a,
+	0x0a, 0x64, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x75,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x75, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x48,
+	0x00, 0x52, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x2a, 0xb8, 0x01, 0x0a, 0x0c, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45,
+	0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45,
+	0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x41, 0x4f, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17,
+	0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50,
+	0x52, 0x4f, 0x50, 0x4f, 0x53, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x46, 0x45, 0x45,
+	0x44, 0x5f, 0x49, 0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45,
+	0x47, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12, 0x1d, 0x0a, 0x19, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49,
+	0x54, 0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x55, 0x53, 0x53,
+	0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x46, 0x45, 0x45, 0x44, 0x5f, 0x49, 0x54,
+	0x45, 0x4d, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x05, 0x32, 0x53,
+	0x0a, 0x0a, 0x46, 0x65, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x0f,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12,
+	0x1e, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x49, 0x74, 0x65,
+	0x6d, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x3b, 0x66, 0x65, 0x65, 0x64, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -774,7 +1066,7 @@ func file_feedpb_feed_events_proto_rawDescGZIP() []byte {
 }
 
 var file_feedpb_feed_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_feedpb_feed_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_feedpb_feed_events_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_feedpb_feed_events_proto_goTypes = []any{
 	(FeedItemType)(0),              // 0: feedpb.FeedItemType
 	(*EventsSubscribeRequest)(nil), // 1: feedpb.EventsSubscribeRequest
@@ -782,33 +1074,40 @@ var file_feedpb_feed_events_proto_goTypes = []any{
 	(*DAO)(nil),                    // 3: feedpb.DAO
 	(*Proposal)(nil),               // 4: feedpb.Proposal
 	(*Delegate)(nil),               // 5: feedpb.Delegate
-	(*FeedItem)(nil),               // 6: feedpb.FeedItem
-	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+	(*Discussion)(nil),             // 6: feedpb.Discussion
+	(*Vote)(nil),                   // 7: feedpb.Vote
+	(*FeedItem)(nil),               // 8: feedpb.FeedItem
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
 }
 var file_feedpb_feed_events_proto_depIdxs = []int32{
 	0,  // 0: feedpb.EventsSubscribeRequest.subscription_types:type_name -> feedpb.FeedItemType
-	7,  // 1: feedpb.EventsSubscribeRequest.last_updated_at:type_name -> google.protobuf.Timestamp
-	7,  // 2: feedpb.Timeline.created_at:type_name -> google.protobuf.Timestamp
-	7,  // 3: feedpb.DAO.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: feedpb.EventsSubscribeRequest.last_updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 2: feedpb.Timeline.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 3: feedpb.DAO.created_at:type_name -> google.protobuf.Timestamp
 	2,  // 4: feedpb.DAO.timeline:type_name -> feedpb.Timeline
-	7,  // 5: feedpb.Proposal.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: feedpb.Proposal.created_at:type_name -> google.protobuf.Timestamp
 	2,  // 6: feedpb.Proposal.timeline:type_name -> feedpb.Timeline
-	7,  // 7: feedpb.Proposal.vote_start:type_name -> google.protobuf.Timestamp
-	7,  // 8: feedpb.Proposal.vote_end:type_name -> google.protobuf.Timestamp
-	7,  // 9: feedpb.Delegate.due_date:type_name -> google.protobuf.Timestamp
-	7,  // 10: feedpb.FeedItem.created_at:type_name -> google.protobuf.Timestamp
-	7,  // 11: feedpb.FeedItem.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 12: feedpb.FeedItem.type:type_name -> feedpb.FeedItemType
-	3,  // 13: feedpb.FeedItem.dao:type_name -> feedpb.DAO
-	4,  // 14: feedpb.FeedItem.proposal:type_name -> feedpb.Proposal
-	5,  // 15: feedpb.FeedItem.delegate:type_name -> feedpb.Delegate
-	1,  // 16: feedpb.FeedEvents.EventsSubscribe:input_type -> feedpb.EventsSubscribeRequest
-	6,  // 17: feedpb.FeedEvents.EventsSubscribe:output_type -> feedpb.FeedItem
-	17, // [17:18] is the sub-list for method output_type
-	16, // [16:17] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	9,  // 7: feedpb.Proposal.vote_start:type_name -> google.protobuf.Timestamp
+	9,  // 8: feedpb.Proposal.vote_end:type_name -> google.protobuf.Timestamp
+	9,  // 9: feedpb.Delegate.due_date:type_name -> google.protobuf.Timestamp
+	9,  // 10: feedpb.Discussion.created_at:type_name -> google.protobuf.Timestamp
+	2,  // 11: feedpb.Discussion.timeline:type_name -> feedpb.Timeline
+	9,  // 12: feedpb.Vote.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 13: feedpb.FeedItem.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 14: feedpb.FeedItem.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 15: feedpb.FeedItem.type:type_name -> feedpb.FeedItemType
+	3,  // 16: feedpb.FeedItem.dao:type_name -> feedpb.DAO
+	4,  // 17: feedpb.FeedItem.proposal:type_name -> feedpb.Proposal
+	5,  // 18: feedpb.FeedItem.delegate:type_name -> feedpb.Delegate
+	6,  // 19: feedpb.FeedItem.discussion:type_name -> feedpb.Discussion
+	7,  // 20: feedpb.FeedItem.vote:type_name -> feedpb.Vote
+	1,  // 21: feedpb.FeedEvents.EventsSubscribe:input_type -> feedpb.EventsSubscribeRequest
+	8,  // 22: feedpb.FeedEvents.EventsSubscribe:output_type -> feedpb.FeedItem
+	22, // [22:23] is the sub-list for method output_type
+	21, // [21:22] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_feedpb_feed_events_proto_init() }
@@ -818,10 +1117,12 @@ func file_feedpb_feed_events_proto_init() {
 	}
 	file_feedpb_feed_events_proto_msgTypes[0].OneofWrappers = []any{}
 	file_feedpb_feed_events_proto_msgTypes[4].OneofWrappers = []any{}
-	file_feedpb_feed_events_proto_msgTypes[5].OneofWrappers = []any{
+	file_feedpb_feed_events_proto_msgTypes[7].OneofWrappers = []any{
 		(*FeedItem_Dao)(nil),
 		(*FeedItem_Proposal)(nil),
 		(*FeedItem_Delegate)(nil),
+		(*FeedItem_Discussion)(nil),
+		(*FeedItem_Vote)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -829,7 +1130,7 @@ func file_feedpb_feed_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_feedpb_feed_events_proto_rawDesc), len(file_feedpb_feed_events_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
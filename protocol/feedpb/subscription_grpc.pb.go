@@ -20,8 +20,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Subscription_Subscribe_FullMethodName   = "/feedpb.Subscription/Subscribe"
-	Subscription_Unsubscribe_FullMethodName = "/feedpb.Subscription/Unsubscribe"
+	Subscription_Subscribe_FullMethodName       = "/feedpb.Subscription/Subscribe"
+	Subscription_Unsubscribe_FullMethodName     = "/feedpb.Subscription/Unsubscribe"
+	Subscription_FollowAddress_FullMethodName   = "/feedpb.Subscription/FollowAddress"
+	Subscription_UnfollowAddress_FullMethodName = "/feedpb.Subscription/UnfollowAddress"
 )
 
 // SubscriptionClient is the client API for Subscription service.
@@ -30,6 +32,8 @@ const (
 type SubscriptionClient interface {
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	FollowAddress(ctx context.Context, in *FollowAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnfollowAddress(ctx context.Context, in *UnfollowAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type subscriptionClient struct {
@@ -60,12 +64,34 @@ func (c *subscriptionClient) Unsubscribe(ctx context.Context, in *UnsubscribeReq
 	return out, nil
 }
 
+func (c *subscriptionClient) FollowAddress(ctx context.Context, in *FollowAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Subscription_FollowAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriptionClient) UnfollowAddress(ctx context.Context, in *UnfollowAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Subscription_UnfollowAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SubscriptionServer is the server API for Subscription service.
 // All implementations must embed UnimplementedSubscriptionServer
 // for forward compatibility.
 type SubscriptionServer interface {
 	Subscribe(context.Context, *SubscribeRequest) (*emptypb.Empty, error)
 	Unsubscribe(context.Context, *UnsubscribeRequest) (*emptypb.Empty, error)
+	FollowAddress(context.Context, *FollowAddressRequest) (*emptypb.Empty, error)
+	UnfollowAddress(context.Context, *UnfollowAddressRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedSubscriptionServer()
 }
 
@@ -82,6 +108,12 @@ func (UnimplementedSubscriptionServer) Subscribe(context.Context, *SubscribeRequ
 func (UnimplementedSubscriptionServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
 }
+func (UnimplementedSubscriptionServer) FollowAddress(context.Context, *FollowAddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowAddress not implemented")
+}
+func (UnimplementedSubscriptionServer) UnfollowAddress(context.Context, *UnfollowAddressRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowAddress not implemented")
+}
 func (UnimplementedSubscriptionServer) mustEmbedUnimplementedSubscriptionServer() {}
 func (UnimplementedSubscriptionServer) testEmbeddedByValue()                      {}
 
@@ -139,6 +171,42 @@ func _Subscription_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Subscription_FollowAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServer).FollowAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscription_FollowAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServer).FollowAddress(ctx, req.(*FollowAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Subscription_UnfollowAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriptionServer).UnfollowAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscription_UnfollowAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriptionServer).UnfollowAddress(ctx, req.(*UnfollowAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Subscription_ServiceDesc is the grpc.ServiceDesc for Subscription service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +222,14 @@ var Subscription_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Unsubscribe",
 			Handler:    _Subscription_Unsubscribe_Handler,
 		},
+		{
+			MethodName: "FollowAddress",
+			Handler:    _Subscription_FollowAddress_Handler,
+		},
+		{
+			MethodName: "UnfollowAddress",
+			Handler:    _Subscription_UnfollowAddress_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feedpb/subscription.proto",
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: feedpb/webhook_deliveries.proto
+
+package feedpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WebhookDeliveries_ListDeliveries_FullMethodName = "/feedpb.WebhookDeliveries/ListDeliveries"
+	WebhookDeliveries_Redeliver_FullMethodName      = "/feedpb.WebhookDeliveries/Redeliver"
+)
+
+// WebhookDeliveriesClient is the client API for WebhookDeliveries service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookDeliveriesClient interface {
+	// ListDeliveries returns attempts to deliver callbacks from the newest ones
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+	// Redeliver sends callbacks of the feed item to webhooks of the subscriber again
+	Redeliver(ctx context.Context, in *RedeliverRequest, opts ...grpc.CallOption) (*RedeliverResponse, error)
+}
+
+type webhookDeliveriesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookDeliveriesClient(cc grpc.ClientConnInterface) WebhookDeliveriesClient {
+	return &webhookDeliveriesClient{cc}
+}
+
+func (c *webhookDeliveriesClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, WebhookDeliveries_ListDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookDeliveriesClient) Redeliver(ctx context.Context, in *RedeliverRequest, opts ...grpc.CallOption) (*RedeliverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RedeliverResponse)
+	err := c.cc.Invoke(ctx, WebhookDeliveries_Redeliver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookDeliveriesServer is the server API for WebhookDeliveries service.
+// All implementations must embed UnimplementedWebhookDeliveriesServer
+// for forward compatibility.
+type WebhookDeliveriesServer interface {
+	// ListDeliveries returns attempts to deliver callbacks from the newest ones
+	ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	// Redeliver sends callbacks of the feed item to webhooks of the subscriber again
+	Redeliver(context.Context, *RedeliverRequest) (*RedeliverResponse, error)
+	mustEmbedUnimplementedWebhookDeliveriesServer()
+}
+
+// UnimplementedWebhookDeliveriesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWebhookDeliveriesServer struct{}
+
+func (UnimplementedWebhookDeliveriesServer) ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedWebhookDeliveriesServer) Redeliver(context.Context, *RedeliverRequest) (*RedeliverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Redeliver not implemented")
+}
+func (UnimplementedWebhookDeliveriesServer) mustEmbedUnimplementedWebhookDeliveriesServer() {}
+func (UnimplementedWebhookDeliveriesServer) testEmbeddedByValue()                           {}
+
+// UnsafeWebhookDeliveriesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookDeliveriesServer will
+// result in compilation errors.
+type UnsafeWebhookDeliveriesServer interface {
+	mustEmbedUnimplementedWebhookDeliveriesServer()
+}
+
+func RegisterWebhookDeliveriesServer(s grpc.ServiceRegistrar, srv WebhookDeliveriesServer) {
+	// If the following call pancis, it indicates UnimplementedWebhookDeliveriesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WebhookDeliveries_ServiceDesc, srv)
+}
+
+func _WebhookDeliveries_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookDeliveriesServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookDeliveries_ListDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookDeliveriesServer).ListDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookDeliveries_Redeliver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookDeliveriesServer).Redeliver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookDeliveries_Redeliver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookDeliveriesServer).Redeliver(ctx, req.(*RedeliverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookDeliveries_ServiceDesc is the grpc.ServiceDesc for WebhookDeliveries service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookDeliveries_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "feedpb.WebhookDeliveries",
+	HandlerType: (*WebhookDeliveriesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _WebhookDeliveries_ListDeliveries_Handler,
+		},
+		{
+			MethodName: "Redeliver",
+			Handler:    _WebhookDeliveries_Redeliver_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "feedpb/webhook_deliveries.proto",
+}
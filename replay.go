@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/goverland-labs/goverland-core-feed/internal"
+)
+
+const replayCommand = "replay"
+
+func runReplay(args []string) error {
+	var (
+		opts            internal.ReplayOptions
+		subjects        string
+		fromTime        string
+		allowDuplicates bool
+	)
+
+	fs := flag.NewFlagSet(replayCommand, flag.ContinueOnError)
+	fs.StringVar(&opts.Source, "source", internal.ReplaySourceJetStream, "events source: jetstream or file")
+	fs.StringVar(&opts.File, "file", "", "path to the newline-delimited JSON dump, each line is {\"subject\": ..., \"data\": ...}")
+	fs.StringVar(&opts.Stream, "stream", "", "JetStream stream name")
+	fs.StringVar(&subjects, "subjects", "", "comma separated subjects to replay, all supported subjects by default")
+	fs.Uint64Var(&opts.FromSeq, "from-seq", 0, "first stream sequence to replay")
+	fs.Uint64Var(&opts.ToSeq, "to-seq", 0, "last stream sequence to replay, the last sequence at the start by default")
+	fs.StringVar(&fromTime, "from-time", "", "replay stream events published since the time in RFC3339")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "don't store feed items, write the diff of feed items to stdout")
+	fs.BoolVar(&opts.IgnoreProcessed, "ignore-processed", false, "apply already processed events again, requires -allow-duplicates unless -dry-run")
+	fs.BoolVar(&allowDuplicates, "allow-duplicates", false, "confirm that -ignore-processed duplicates delegate items and non-unique timeline actions")
+	fs.IntVar(&opts.ProgressEvery, "progress-every", 1000, "log progress every N events")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if subjects != "" {
+		opts.Subjects = strings.Split(subjects, ",")
+	}
+
+	if fromTime != "" {
+		t, err := time.Parse(time.RFC3339, fromTime)
+		if err != nil {
+			return fmt.Errorf("parse from-time: %w", err)
+		}
+		opts.FromTime = t
+	}
+
+	switch {
+	case opts.Source == internal.ReplaySourceFile && opts.File == "":
+		return fmt.Errorf("file is required for the file source")
+	case opts.Source == internal.ReplaySourceJetStream && opts.Stream == "":
+		return fmt.Errorf("stream is required for the jetstream source")
+	case opts.IgnoreProcessed && !opts.DryRun && !allowDuplicates:
+		// delegate items and non-unique timeline actions have no natural key, so they are stored again
+		return fmt.Errorf("ignore-processed duplicates delegate items and non-unique timeline actions, pass -allow-duplicates to confirm")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	return internal.Replay(ctx, cfg, opts, os.Stdout)
+}
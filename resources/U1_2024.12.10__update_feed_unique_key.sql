@@ -0,0 +1,4 @@
+-- updated actions of dao and proposal items are not reverted
+drop index if exists feed_items_unique_index;
+
+create unique index feed_items_dao_proposal_uindex on feed_items (dao_id, proposal_id);
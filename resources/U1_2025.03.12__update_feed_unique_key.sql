@@ -0,0 +1,3 @@
+drop index if exists feed_items_unique_index;
+
+create unique index feed_items_unique_index on feed_items (dao_id, proposal_id, type, action);
@@ -0,0 +1,9 @@
+delete from feed_items where type = 'discussion';
+
+drop index if exists feed_items_discussion_unique_index;
+
+drop index if exists feed_items_unique_index;
+
+create unique index if not exists feed_items_unique_index
+    on feed_items (dao_id, proposal_id, type, action)
+    where type != 'delegate';
@@ -0,0 +1,15 @@
+drop table if exists address_subscriptions;
+
+delete from feed_items where type = 'vote';
+
+drop index if exists feed_items_voter_index;
+
+drop index if exists feed_items_vote_unique_index;
+
+drop index if exists feed_items_unique_index;
+
+create unique index if not exists feed_items_unique_index
+    on feed_items (dao_id, proposal_id, type, action)
+    where type not in ('delegate', 'discussion');
+
+alter table feed_items drop column if exists voter;
@@ -0,0 +1,3 @@
+drop index if exists feed_items_type_triggered_at_index;
+
+drop table if exists feed_items_archive;
@@ -0,0 +1,58 @@
+alter table feed_items rename to feed_items_partitioned;
+alter index feed_items_pkey rename to feed_items_partitioned_pkey;
+
+create table feed_items
+(
+    id            uuid primary key,
+    created_at    timestamp with time zone,
+    updated_at    timestamp with time zone,
+    deleted_at    timestamp with time zone,
+    dao_id        uuid,
+    proposal_id   text,
+    discussion_id text,
+    type          text,
+    action        text,
+    snapshot      jsonb,
+    timeline      jsonb,
+    triggered_at  timestamp with time zone,
+    voter         text
+);
+
+insert into feed_items (id, created_at, updated_at, deleted_at, dao_id, proposal_id, discussion_id, type, action,
+                        snapshot, timeline, triggered_at, voter)
+select id,
+       created_at,
+       updated_at,
+       deleted_at,
+       dao_id,
+       proposal_id,
+       discussion_id,
+       type,
+       action,
+       snapshot,
+       timeline,
+       triggered_at,
+       voter
+from feed_items_partitioned;
+
+-- drops all partitions as well
+drop table feed_items_partitioned;
+
+create index idx_feed_items_dao_proposal_discussion_ids_action on feed_items (dao_id, proposal_id, discussion_id, action);
+create index feed_items_dao_id_index on feed_items (dao_id);
+create index feed_items_proposal_id_index on feed_items (proposal_id);
+create index feed_items_triggered_at_index on feed_items (triggered_at);
+create index feed_items_type_triggered_at_index on feed_items (type, triggered_at);
+create index feed_items_voter_index on feed_items (voter) where type = 'vote';
+
+create unique index feed_items_unique_index
+    on feed_items (dao_id, proposal_id, type, action)
+    where type not in ('delegate', 'discussion', 'vote');
+
+create unique index feed_items_discussion_unique_index
+    on feed_items (discussion_id)
+    where type = 'discussion';
+
+create unique index feed_items_vote_unique_index
+    on feed_items (proposal_id, voter)
+    where type = 'vote';
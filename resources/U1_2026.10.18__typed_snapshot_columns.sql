@@ -0,0 +1,10 @@
+drop index if exists feed_items_author_index;
+drop index if exists feed_items_vote_end_index;
+drop index if exists feed_items_state_index;
+
+alter table feed_items
+    drop column if exists author,
+    drop column if exists vote_end,
+    drop column if exists vote_start,
+    drop column if exists spam,
+    drop column if exists state;
@@ -0,0 +1,3 @@
+alter table subscribers
+    drop column if exists batch_max_size,
+    drop column if exists batch_max_wait_ms;
@@ -0,0 +1,3 @@
+drop index if exists idx_address_subscriptions_subscriber_id_address;
+
+create index idx_address_subscriptions_subscriber_id_address on address_subscriptions (subscriber_id, address);
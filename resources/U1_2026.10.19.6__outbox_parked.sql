@@ -0,0 +1,8 @@
+drop index if exists outbox_messages_parked_index;
+
+drop index if exists outbox_messages_pending_index;
+
+create index outbox_messages_pending_index on outbox_messages (id) where published_at is null;
+
+alter table outbox_messages
+    drop column parked_at;
@@ -0,0 +1 @@
+drop table if exists feed_item_keys;
@@ -0,0 +1,3 @@
+alter table webhook_batch_callbacks
+    drop column if exists attempts,
+    drop column if exists retry_at;
@@ -0,0 +1,9 @@
+drop index if exists feed_items_unique_index;
+
+create unique index if not exists feed_items_unique_index
+    on feed_items (dao_id, proposal_id, type, action)
+    where type not in ('delegate', 'discussion');
+
+create unique index if not exists feed_items_discussion_unique_index
+    on feed_items (discussion_id)
+    where type = 'discussion';
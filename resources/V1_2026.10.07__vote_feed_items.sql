@@ -0,0 +1,29 @@
+alter table feed_items add voter text;
+
+drop index if exists feed_items_unique_index;
+
+create unique index if not exists feed_items_unique_index
+    on feed_items (dao_id, proposal_id, type, action)
+    where type not in ('delegate', 'discussion', 'vote');
+
+create unique index if not exists feed_items_vote_unique_index
+    on feed_items (proposal_id, voter)
+    where type = 'vote';
+
+create index if not exists feed_items_voter_index on feed_items (voter) where type = 'vote';
+
+create table address_subscriptions
+(
+    id            uuid primary key,
+    created_at    timestamp with time zone,
+    updated_at    timestamp with time zone,
+    deleted_at    timestamp with time zone,
+    subscriber_id uuid,
+    address       text
+);
+
+create index idx_address_subscriptions_deleted_at on address_subscriptions (deleted_at);
+
+create index idx_address_subscriptions_subscriber_id_address on address_subscriptions (subscriber_id, address);
+
+create index idx_address_subscriptions_address on address_subscriptions (address);
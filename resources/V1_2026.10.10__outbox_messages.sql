@@ -0,0 +1,15 @@
+create table outbox_messages
+(
+    id           bigserial primary key,
+    created_at   timestamp with time zone,
+    dao_id       uuid,
+    subject      text,
+    payload      jsonb,
+    published_at timestamp with time zone,
+    attempts     integer default 0,
+    last_error   text
+);
+
+create index outbox_messages_pending_index on outbox_messages (id) where published_at is null;
+
+create index outbox_messages_published_at_index on outbox_messages (published_at) where published_at is not null;
@@ -0,0 +1,7 @@
+create table processed_events
+(
+    key        text primary key,
+    created_at timestamp with time zone
+);
+
+create index processed_events_created_at_index on processed_events (created_at);
@@ -0,0 +1,13 @@
+create table feed_items_archive
+(
+    id           uuid primary key,
+    type         text,
+    dao_id       uuid,
+    triggered_at timestamp with time zone,
+    archived_at  timestamp with time zone,
+    data         jsonb
+);
+
+create index feed_items_archive_dao_id_index on feed_items_archive (dao_id);
+
+create index feed_items_type_triggered_at_index on feed_items (type, triggered_at);
@@ -0,0 +1,75 @@
+-- feed_items is recreated as the table partitioned by month on created_at, existing items are copied.
+-- Unique indexes must contain the partition key, so the uniqueness of feed items is kept by the application.
+alter table feed_items rename to feed_items_legacy;
+alter index feed_items_pkey rename to feed_items_legacy_pkey;
+
+create table feed_items
+(
+    id            uuid                     not null,
+    created_at    timestamp with time zone not null,
+    updated_at    timestamp with time zone,
+    deleted_at    timestamp with time zone,
+    triggered_at  timestamp with time zone,
+    dao_id        uuid,
+    proposal_id   text,
+    discussion_id text,
+    voter         text,
+    type          text,
+    action        text,
+    snapshot      jsonb,
+    timeline      jsonb,
+    primary key (id, created_at)
+) partition by range (created_at);
+
+create table feed_items_default partition of feed_items default;
+
+-- partitions for existing items and next months, further partitions are created by the application
+do
+$$
+    declare
+        partition_start timestamp := date_trunc('month', coalesce((select min(created_at) from feed_items_legacy), now()) at time zone 'UTC');
+    begin
+        while partition_start <= date_trunc('month', (now() + interval '3 months') at time zone 'UTC')
+            loop
+                execute format(
+                        'create table if not exists %I partition of feed_items for values from (%L) to (%L)',
+                        'feed_items_y' || to_char(partition_start, 'YYYY') || 'm' || to_char(partition_start, 'MM'),
+                        to_char(partition_start, 'YYYY-MM-DD') || ' 00:00:00+00',
+                        to_char(partition_start + interval '1 month', 'YYYY-MM-DD') || ' 00:00:00+00'
+                    );
+                partition_start := partition_start + interval '1 month';
+            end loop;
+    end
+$$;
+
+insert into feed_items (id, created_at, updated_at, deleted_at, triggered_at, dao_id, proposal_id, discussion_id, voter,
+                        type, action, snapshot, timeline)
+select id,
+       coalesce(created_at, updated_at, now()),
+       updated_at,
+       deleted_at,
+       triggered_at,
+       dao_id,
+       proposal_id,
+       discussion_id,
+       voter,
+       type,
+       action,
+       snapshot,
+       timeline
+from feed_items_legacy;
+
+drop table feed_items_legacy;
+
+create index idx_feed_items_dao_proposal_discussion_ids_action on feed_items (dao_id, proposal_id, discussion_id, action);
+create index feed_items_dao_id_index on feed_items (dao_id);
+create index feed_items_proposal_id_index on feed_items (proposal_id);
+create index feed_items_triggered_at_index on feed_items (triggered_at);
+create index feed_items_updated_at_index on feed_items (updated_at);
+create index feed_items_type_triggered_at_index on feed_items (type, triggered_at);
+create index feed_items_voter_index on feed_items (voter) where type = 'vote';
+
+-- lookup indexes of unique keys
+create index feed_items_unique_key_index on feed_items (dao_id, proposal_id, type, action);
+create index feed_items_discussion_key_index on feed_items (discussion_id) where type = 'discussion';
+create index feed_items_vote_key_index on feed_items (proposal_id, voter) where type = 'vote';
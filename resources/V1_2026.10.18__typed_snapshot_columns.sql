@@ -0,0 +1,17 @@
+alter table feed_items
+    add column if not exists state      text,
+    add column if not exists spam       boolean,
+    add column if not exists vote_start timestamp with time zone,
+    add column if not exists vote_end   timestamp with time zone,
+    add column if not exists author     text not null default '';
+
+update feed_items
+set state      = snapshot ->> 'state',
+    spam       = (snapshot ->> 'spam')::boolean,
+    vote_start = to_timestamp((snapshot ->> 'start')::double precision),
+    vote_end   = to_timestamp((snapshot ->> 'end')::double precision),
+    author     = coalesce(snapshot ->> 'author', '');
+
+create index if not exists feed_items_state_index on feed_items (state);
+create index if not exists feed_items_vote_end_index on feed_items (vote_end);
+create index if not exists feed_items_author_index on feed_items (author);
@@ -0,0 +1,15 @@
+create table webhook_endpoints
+(
+    id            uuid primary key,
+    created_at    timestamp with time zone,
+    updated_at    timestamp with time zone,
+    subscriber_id uuid    not null,
+    url           text    not null,
+    enabled       boolean not null default true,
+    format        text    not null default 'full',
+    types         jsonb,
+    actions       jsonb,
+    dao_ids       jsonb
+);
+
+create index webhook_endpoints_subscriber_id_index on webhook_endpoints (subscriber_id);
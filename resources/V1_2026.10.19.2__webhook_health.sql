@@ -0,0 +1,13 @@
+create table webhook_health
+(
+    url                  text primary key,
+    updated_at           timestamp with time zone,
+    successes            bigint  not null default 0,
+    failures             bigint  not null default 0,
+    consecutive_failures integer not null default 0,
+    last_status          integer not null default 0,
+    last_error           text    not null default '',
+    last_success_at      timestamp with time zone,
+    last_failure_at      timestamp with time zone,
+    disabled_until       timestamp with time zone
+);
@@ -0,0 +1,20 @@
+create table webhook_deliveries
+(
+    id            uuid primary key,
+    created_at    timestamp with time zone not null,
+    subscriber_id uuid    not null,
+    dao_id        uuid    not null,
+    feed_item_id  uuid    not null,
+    action        text    not null default '',
+    url           text    not null,
+    status        text    not null,
+    http_status   integer not null default 0,
+    latency_ms    bigint  not null default 0,
+    response      text    not null default '',
+    redelivery    boolean not null default false
+);
+
+create index webhook_deliveries_subscriber_id_created_at_index on webhook_deliveries (subscriber_id, created_at desc);
+create index webhook_deliveries_dao_id_created_at_index on webhook_deliveries (dao_id, created_at desc);
+create index webhook_deliveries_feed_item_id_index on webhook_deliveries (feed_item_id);
+create index webhook_deliveries_created_at_index on webhook_deliveries (created_at);
@@ -0,0 +1,3 @@
+alter table subscribers
+    add column batch_max_size    integer not null default 0,
+    add column batch_max_wait_ms integer not null default 0;
@@ -0,0 +1,16 @@
+-- concurrent follows could create duplicates, the oldest subscription is kept
+update address_subscriptions s
+set deleted_at = now()
+where s.deleted_at is null
+  and exists(select 1
+             from address_subscriptions d
+             where d.subscriber_id = s.subscriber_id
+               and d.address = s.address
+               and d.deleted_at is null
+               and (d.created_at, d.id) < (s.created_at, s.id));
+
+drop index if exists idx_address_subscriptions_subscriber_id_address;
+
+create unique index idx_address_subscriptions_subscriber_id_address
+    on address_subscriptions (subscriber_id, address)
+    where deleted_at is null;
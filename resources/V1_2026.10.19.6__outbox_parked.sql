@@ -0,0 +1,8 @@
+alter table outbox_messages
+    add column parked_at timestamp with time zone;
+
+drop index if exists outbox_messages_pending_index;
+
+create index outbox_messages_pending_index on outbox_messages (id) where published_at is null and parked_at is null;
+
+create index outbox_messages_parked_index on outbox_messages (id) where parked_at is not null;
@@ -0,0 +1,25 @@
+-- Unique keys of feed items. The partitioned feed_items table can't have unique indexes without the partition key,
+-- so the uniqueness is enforced by the primary key of the non-partitioned table.
+create table feed_item_keys
+(
+    key             text primary key,
+    item_id         uuid                     not null,
+    item_created_at timestamp with time zone not null
+);
+
+create index feed_item_keys_item_id_index on feed_item_keys (item_id);
+
+-- keys have the same format as the application builds, the oldest item is kept for duplicated keys
+insert into feed_item_keys (key, item_id, item_created_at)
+select distinct on (key) key, id, created_at
+from (select case type
+                 when 'vote' then type || '/' || coalesce(proposal_id, '') || '/' || coalesce(voter, '')
+                 when 'discussion' then type || '/' || coalesce(discussion_id, '')
+                 else type || '/' || coalesce(dao_id::text, '00000000-0000-0000-0000-000000000000') || '/' ||
+                      coalesce(proposal_id, '') || '/' || coalesce(action, '')
+                 end as key,
+             id,
+             created_at
+      from feed_items
+      where type <> 'delegate') k
+order by key, created_at, id;
@@ -0,0 +1,13 @@
+create table webhook_batch_callbacks
+(
+    id            bigserial primary key,
+    created_at    timestamp with time zone not null,
+    subscriber_id uuid    not null,
+    url           text    not null,
+    deadline      timestamp with time zone not null,
+    max_size      integer not null,
+    payload       jsonb   not null,
+    claimed_until timestamp with time zone
+);
+
+create index webhook_batch_callbacks_subscriber_id_url_id_index on webhook_batch_callbacks (subscriber_id, url, id);
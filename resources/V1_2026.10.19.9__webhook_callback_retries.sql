@@ -0,0 +1,3 @@
+alter table webhook_batch_callbacks
+    add column attempts integer not null default 0,
+    add column retry_at timestamp with time zone;
@@ -0,0 +1,12 @@
+create table api_keys
+(
+    id            uuid primary key,
+    created_at    timestamp with time zone,
+    revoked_at    timestamp with time zone,
+    subscriber_id uuid not null,
+    hash          text not null,
+    scopes        text not null default ''
+);
+
+create unique index api_keys_hash_index on api_keys (hash);
+create index api_keys_subscriber_id_index on api_keys (subscriber_id);
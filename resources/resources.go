@@ -0,0 +1,10 @@
+// Package resources contains versioned SQL migrations of the database schema.
+// V<version>__<description>.sql files apply the migration, U<version>__<description>.sql files revert it.
+package resources
+
+import (
+	"embed"
+)
+
+//go:embed *.sql
+var Migrations embed.FS